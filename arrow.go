@@ -0,0 +1,870 @@
+package tada
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"reflect"
+	"regexp"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// -- Apache Arrow IPC
+
+// arrow message header types
+const (
+	arrowSchemaMessage          = 1
+	arrowDictionaryBatchMessage = 2
+	arrowRecordBatchMessage     = 3
+)
+
+// arrow type ids
+const (
+	arrowNull            = 1
+	arrowInt             = 2
+	arrowFloatingPoint   = 3
+	arrowBinary          = 4
+	arrowUtf8            = 5
+	arrowBool            = 6
+	arrowDate            = 8
+	arrowTime            = 9
+	arrowTimestamp       = 10
+	arrowLargeBinary     = 19
+	arrowLargeUtf8       = 20
+	arrowMetadataVersion = 4 // V5
+)
+
+// arrow time units
+const (
+	arrowSecond      = 0
+	arrowMillisecond = 1
+	arrowMicrosecond = 2
+	arrowNanosecond  = 3
+)
+
+var arrowMagic = []byte("ARROW1")
+
+// arrowContinuation precedes the length of every encapsulated message.
+const arrowContinuation = 0xFFFFFFFF
+
+// an arrowField is a column in an arrow schema
+type arrowField struct {
+	name         string
+	typeID       uint8
+	typ          fbTable
+	dictionary   bool
+	dictionaryID int64
+	indexType    fbTable
+	hasIndexType bool
+}
+
+// ArrowReader reads Apache Arrow IPC data (either the file or the stream format) into a DataFrame.
+type ArrowReader struct {
+	LabelLevels int
+	Name        string
+	r           io.Reader
+}
+
+// NewArrowReader returns a default ArrowReader.
+func NewArrowReader(r io.Reader) ArrowReader {
+	return ArrowReader{
+		LabelLevels: 0,
+		r:           r,
+	}
+}
+
+// Read reads a DataFrame from Apache Arrow IPC data. The file format and the stream format are detected automatically,
+// and all record batches are concatenated.
+// Only flat (non-nested), uncompressed schemas are supported. Dictionary-encoded columns are decoded into their value type.
+//
+// Arrow types are read as:
+// Bool -> []bool;
+// Int -> []int64;
+// FloatingPoint -> []float64;
+// Utf8, LargeUtf8, Binary, and LargeBinary -> []string;
+// Date -> []civil.Date;
+// Time -> []civil.Time;
+// Timestamp -> []time.Time (in UTC);
+// Null -> []string (all null).
+// Null values (i.e., unset bits in the validity bitmap) are read as the zero value of the column type.
+//
+// If the data was written by ArrowWriter, the label levels, column level names, and DataFrame name are restored from the schema metadata.
+// If the data was written from a pandas DataFrame, its index columns are read as label levels.
+// Otherwise, the first r.LabelLevels columns are read as label levels.
+func (r ArrowReader) Read() (*DataFrame, error) {
+	b, err := ioutil.ReadAll(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("reading arrow: %v", err)
+	}
+	containers, metadata, err := readArrow(b)
+	if err != nil {
+		return nil, fmt.Errorf("reading arrow: %v", err)
+	}
+	numLabels := r.LabelLevels
+	var meta *dataFrameMetadata
+	if v, ok := metadata[dataFrameMetadataKey]; ok {
+		meta = new(dataFrameMetadata)
+		err := json.Unmarshal([]byte(v), meta)
+		if err != nil {
+			return nil, fmt.Errorf("reading arrow: metadata: %v", err)
+		}
+		numLabels = meta.NumLabelLevels
+	} else if v, ok := metadata["pandas"]; ok {
+		containers, numLabels = arrangePandasIndex(containers, v)
+	}
+	if numLabels >= len(containers) {
+		return nil, fmt.Errorf("reading arrow: number of label levels (%d) must be less than number of columns (%d)",
+			numLabels, len(containers))
+	}
+	name := r.Name
+	if meta != nil && name == "" {
+		name = meta.Name
+	}
+	df := containersToDF(containers, 1, numLabels, name)
+	if meta != nil && len(meta.ColLevelNames) > 0 {
+		df.colLevelNames = meta.ColLevelNames
+	}
+	return df, nil
+}
+
+var pandasDefaultIndexName = regexp.MustCompile(`^__index_level_(\d+)__$`)
+
+// arrangePandasIndex moves the index columns identified in pandas metadata in front of all other containers
+// and returns the rearranged containers and the number of index columns.
+// Range indexes are not stored as columns and are ignored.
+func arrangePandasIndex(containers []*valueContainer, pandasMetadata string) ([]*valueContainer, int) {
+	var meta struct {
+		IndexColumns []interface{} `json:"index_columns"`
+	}
+	err := json.Unmarshal([]byte(pandasMetadata), &meta)
+	if err != nil {
+		return containers, 0
+	}
+	var labels, values []*valueContainer
+	isLabel := make(map[int]bool)
+	for _, col := range meta.IndexColumns {
+		name, ok := col.(string)
+		if !ok {
+			continue
+		}
+		for k := range containers {
+			if containers[k].name == name && !isLabel[k] {
+				isLabel[k] = true
+				labels = append(labels, containers[k])
+				break
+			}
+		}
+	}
+	for k := range containers {
+		if !isLabel[k] {
+			values = append(values, containers[k])
+		}
+	}
+	for _, label := range labels {
+		label.name = pandasDefaultIndexName.ReplaceAllString(label.name, "*$1")
+	}
+	return append(labels, values...), len(labels)
+}
+
+// ArrowWriter writes a DataFrame as Apache Arrow IPC data.
+type ArrowWriter struct {
+	IncludeLabels bool
+	Stream        bool
+	w             io.Writer
+}
+
+// NewArrowWriter returns an *ArrowWriter with default settings.
+// By default, the IPC file format is written, and label levels are written as ordinary columns
+// and restored as label levels by ArrowReader.
+// To write the IPC stream format instead, set Stream to true.
+func NewArrowWriter(w io.Writer) *ArrowWriter {
+	return &ArrowWriter{
+		IncludeLabels: true,
+		w:             w,
+	}
+}
+
+// Write writes df to w as a single uncompressed record batch. Null values are preserved in each column's validity bitmap.
+//
+// Container types are written as:
+// []float64 and []float32 -> Float64;
+// []bool -> Bool;
+// signed integer slices -> Int64;
+// []time.Time -> Timestamp(MICROSECOND, "UTC") (truncated to microseconds);
+// []civil.Date -> Date32;
+// []civil.Time -> Time64(NANOSECOND);
+// all other types are converted to string and written as Utf8.
+//
+// The number of label levels, the column level names, and the DataFrame name are written to the schema metadata.
+func (w *ArrowWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing arrow: %v", df.err)
+	}
+	containers := df.values
+	var numLabels int
+	if w.IncludeLabels {
+		containers = append(df.labels, df.values...)
+		numLabels = len(df.labels)
+	}
+	meta, err := json.Marshal(dataFrameMetadata{
+		Name:           df.name,
+		NumLabelLevels: numLabels,
+		ColLevelNames:  df.colLevelNames,
+	})
+	if err != nil {
+		return fmt.Errorf("writing arrow: %v", err)
+	}
+	b, err := writeArrow(containers, string(meta), w.Stream)
+	if err != nil {
+		return fmt.Errorf("writing arrow: %v", err)
+	}
+	_, err = w.w.Write(b)
+	if err != nil {
+		return fmt.Errorf("writing arrow: %v", err)
+	}
+	return nil
+}
+
+// -- arrow internals
+
+// readArrow reads arrow IPC data into one valueContainer per column and returns the schema metadata.
+func readArrow(b []byte) (containers []*valueContainer, metadata map[string]string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed message: %v", r)
+		}
+	}()
+	if len(b) >= 8 && bytes.Equal(b[:6], arrowMagic) {
+		// file format: the messages are in stream format between the leading magic and the footer
+		if len(b) < 18 || !bytes.Equal(b[len(b)-6:], arrowMagic) {
+			return nil, nil, fmt.Errorf("file is missing trailing magic bytes")
+		}
+		footerLength := int(int32(binary.LittleEndian.Uint32(b[len(b)-10:])))
+		if footerLength < 0 || footerLength > len(b)-18 {
+			return nil, nil, fmt.Errorf("invalid footer length (%d)", footerLength)
+		}
+		b = b[8 : len(b)-10-footerLength]
+	}
+	var fields []arrowField
+	var values []interface{}
+	var isNull [][]bool
+	dictionaries := make(map[int64]interface{})
+	var pos int
+	for pos+4 <= len(b) {
+		metadataLength := int(int32(binary.LittleEndian.Uint32(b[pos:])))
+		pos += 4
+		if uint32(metadataLength) == arrowContinuation {
+			if pos+4 > len(b) {
+				break
+			}
+			metadataLength = int(int32(binary.LittleEndian.Uint32(b[pos:])))
+			pos += 4
+		}
+		if metadataLength == 0 {
+			// end of stream
+			break
+		}
+		if metadataLength < 0 || pos+metadataLength > len(b) {
+			return nil, nil, fmt.Errorf("message metadata exceeds data length")
+		}
+		message := fbRoot(b[pos : pos+metadataLength])
+		pos += metadataLength
+		bodyLength := int(message.int64(3, 0))
+		if bodyLength < 0 || pos+bodyLength > len(b) {
+			return nil, nil, fmt.Errorf("message body exceeds data length")
+		}
+		body := b[pos : pos+bodyLength]
+		pos += bodyLength
+
+		header, _ := message.table(2)
+		switch message.uint8(1, 0) {
+		case arrowSchemaMessage:
+			fields, metadata, err = readArrowSchema(header)
+			if err != nil {
+				return nil, nil, fmt.Errorf("schema: %v", err)
+			}
+			values = make([]interface{}, len(fields))
+			isNull = make([][]bool, len(fields))
+			for k := range fields {
+				values[k] = arrowEmptySlice(fields[k])
+				isNull[k] = []bool{}
+			}
+		case arrowDictionaryBatchMessage:
+			id := header.int64(0, 0)
+			var field *arrowField
+			for k := range fields {
+				if fields[k].dictionary && fields[k].dictionaryID == id {
+					field = &fields[k]
+				}
+			}
+			if field == nil {
+				return nil, nil, fmt.Errorf("dictionary batch: no field with dictionary id %d", id)
+			}
+			data, _ := header.table(1)
+			valueField := *field
+			valueField.dictionary = false
+			dictionary, err := readArrowRecordBatch(data, body, []arrowField{valueField}, nil)
+			if err != nil {
+				return nil, nil, fmt.Errorf("dictionary batch: %v", err)
+			}
+			if header.bool(2, false) && dictionaries[id] != nil {
+				dictionaries[id] = reflect.AppendSlice(
+					reflect.ValueOf(dictionaries[id]), reflect.ValueOf(dictionary[0].slice)).Interface()
+			} else {
+				dictionaries[id] = dictionary[0].slice
+			}
+		case arrowRecordBatchMessage:
+			if fields == nil {
+				return nil, nil, fmt.Errorf("record batch precedes schema")
+			}
+			batch, err := readArrowRecordBatch(header, body, fields, dictionaries)
+			if err != nil {
+				return nil, nil, fmt.Errorf("record batch: %v", err)
+			}
+			for k := range batch {
+				values[k] = reflect.AppendSlice(reflect.ValueOf(values[k]), reflect.ValueOf(batch[k].slice)).Interface()
+				isNull[k] = append(isNull[k], batch[k].isNull...)
+			}
+		default:
+			// ignore tensors and unknown message types
+			continue
+		}
+	}
+	if fields == nil {
+		return nil, nil, fmt.Errorf("missing schema")
+	}
+	if len(fields) == 0 {
+		return nil, nil, fmt.Errorf("schema must contain at least one column")
+	}
+	containers = make([]*valueContainer, len(fields))
+	for k := range fields {
+		containers[k] = newValueContainer(values[k], isNull[k], fields[k].name)
+	}
+	return containers, metadata, nil
+}
+
+func readArrowSchema(schema fbTable) ([]arrowField, map[string]string, error) {
+	if schema.int16(0, 0) != 0 {
+		return nil, nil, fmt.Errorf("big-endian data is not supported")
+	}
+	fieldTables := schema.tables(1)
+	fields := make([]arrowField, len(fieldTables))
+	for k, f := range fieldTables {
+		fields[k].name = f.string(0)
+		fields[k].typeID = f.uint8(2, 0)
+		fields[k].typ, _ = f.table(3)
+		if _, n := f.vector(5); n > 0 {
+			return nil, nil, fmt.Errorf("column %s: nested columns are not supported", fields[k].name)
+		}
+		if dictionary, ok := f.table(4); ok {
+			fields[k].dictionary = true
+			fields[k].dictionaryID = dictionary.int64(0, 0)
+			fields[k].indexType, fields[k].hasIndexType = dictionary.table(1)
+		}
+		switch fields[k].typeID {
+		case arrowNull, arrowInt, arrowFloatingPoint, arrowBinary, arrowUtf8, arrowBool,
+			arrowDate, arrowTime, arrowTimestamp, arrowLargeBinary, arrowLargeUtf8:
+		default:
+			return nil, nil, fmt.Errorf("column %s: unsupported type (%d)", fields[k].name, fields[k].typeID)
+		}
+	}
+	metadata := make(map[string]string)
+	for _, kv := range schema.tables(2) {
+		metadata[kv.string(0)] = kv.string(1)
+	}
+	return fields, metadata, nil
+}
+
+// arrowEmptySlice returns an empty slice of the type returned when reading field.
+func arrowEmptySlice(field arrowField) interface{} {
+	// duck error because no values are decoded
+	ret, _ := decodeArrowValues(field.typeID, field.typ, 0, func() []byte { return nil })
+	return ret
+}
+
+// readArrowRecordBatch reads the columns described by fields from the body of a record batch message.
+func readArrowRecordBatch(batch fbTable, body []byte, fields []arrowField, dictionaries map[int64]interface{}) ([]*valueContainer, error) {
+	if _, ok := batch.table(3); ok {
+		return nil, fmt.Errorf("compressed record batches are not supported")
+	}
+	n := int(batch.int64(0, 0))
+	nodes := batch.structs(1, 16)
+	buffers := batch.structs(2, 16)
+	if len(nodes) != len(fields) {
+		return nil, fmt.Errorf("number of field nodes (%d) does not match schema (%d)", len(nodes), len(fields))
+	}
+	var counter int
+	var bufErr error
+	nextBuffer := func() []byte {
+		if counter >= len(buffers) {
+			bufErr = fmt.Errorf("missing buffer")
+			return nil
+		}
+		offset := int(binary.LittleEndian.Uint64(buffers[counter]))
+		length := int(binary.LittleEndian.Uint64(buffers[counter][8:]))
+		counter++
+		if offset < 0 || length < 0 || offset+length > len(body) {
+			bufErr = fmt.Errorf("buffer exceeds message body")
+			return nil
+		}
+		return body[offset : offset+length]
+	}
+	ret := make([]*valueContainer, len(fields))
+	for k, field := range fields {
+		length := int(binary.LittleEndian.Uint64(nodes[k]))
+		if length != n {
+			return nil, fmt.Errorf("column %s: length (%d) does not match record batch (%d)", field.name, length, n)
+		}
+		isNull := make([]bool, n)
+		if field.typeID == arrowNull {
+			for i := range isNull {
+				isNull[i] = true
+			}
+			ret[k] = &valueContainer{slice: make([]string, n), isNull: isNull, name: field.name}
+			continue
+		}
+		start := counter
+		validity := nextBuffer()
+		if len(validity) > 0 {
+			if len(validity) < (n+7)/8 {
+				return nil, fmt.Errorf("column %s: validity bitmap is too short", field.name)
+			}
+			for i := range isNull {
+				isNull[i] = validity[i/8]&(1<<uint(i%8)) == 0
+			}
+		}
+		var vals interface{}
+		var err error
+		if field.dictionary {
+			vals, err = readArrowDictionaryColumn(field, n, isNull, dictionaries, nextBuffer)
+		} else {
+			vals, err = decodeArrowValues(field.typeID, field.typ, n, nextBuffer)
+		}
+		// every column consumes a fixed number of buffers, even if it is empty
+		counter = start + arrowBufferCount(field)
+		if bufErr != nil {
+			return nil, fmt.Errorf("column %s: %v", field.name, bufErr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", field.name, err)
+		}
+		// replace null values with the zero value of the column type
+		v := reflect.ValueOf(vals)
+		zero := reflect.Zero(v.Type().Elem())
+		for i := range isNull {
+			if isNull[i] {
+				v.Index(i).Set(zero)
+			}
+		}
+		ret[k] = &valueContainer{slice: vals, isNull: isNull, name: field.name}
+	}
+	return ret, nil
+}
+
+// arrowBufferCount returns the number of buffers (including the validity bitmap) that represent field in a record batch.
+func arrowBufferCount(field arrowField) int {
+	if field.dictionary {
+		return 2
+	}
+	switch field.typeID {
+	case arrowNull:
+		return 0
+	case arrowUtf8, arrowBinary, arrowLargeUtf8, arrowLargeBinary:
+		return 3
+	default:
+		return 2
+	}
+}
+
+func readArrowDictionaryColumn(
+	field arrowField, n int, isNull []bool, dictionaries map[int64]interface{}, nextBuffer func() []byte) (interface{}, error) {
+	dictionary, ok := dictionaries[field.dictionaryID]
+	if !ok {
+		return nil, fmt.Errorf("missing dictionary (%d)", field.dictionaryID)
+	}
+	bitWidth, signed := int32(32), true
+	if field.hasIndexType {
+		bitWidth, signed = field.indexType.int32(0, 0), field.indexType.bool(1, false)
+	}
+	indices, err := decodeArrowInts(nextBuffer(), n, bitWidth, signed)
+	if err != nil {
+		return nil, fmt.Errorf("dictionary indices: %v", err)
+	}
+	l := reflect.ValueOf(dictionary).Len()
+	if l == 0 {
+		return reflect.MakeSlice(reflect.TypeOf(dictionary), n, n).Interface(), nil
+	}
+	index := make([]int, n)
+	for i := range indices {
+		if isNull[i] {
+			continue
+		}
+		if indices[i] < 0 || indices[i] >= int64(l) {
+			return nil, fmt.Errorf("dictionary index out of range [%d] with length %d", indices[i], l)
+		}
+		index[i] = int(indices[i])
+	}
+	return subsetInterfaceSlice(dictionary, index), nil
+}
+
+// decodeArrowInts decodes n integers of bitWidth from the data buffer of an Int column.
+func decodeArrowInts(buf []byte, n int, bitWidth int32, signed bool) ([]int64, error) {
+	width := int(bitWidth) / 8
+	switch width {
+	case 1, 2, 4, 8:
+	default:
+		return nil, fmt.Errorf("unsupported integer bit width (%d)", bitWidth)
+	}
+	if len(buf) < n*width {
+		return nil, fmt.Errorf("data buffer is too short")
+	}
+	ret := make([]int64, n)
+	for i := range ret {
+		b := buf[i*width:]
+		switch {
+		case width == 1 && signed:
+			ret[i] = int64(int8(b[0]))
+		case width == 1:
+			ret[i] = int64(b[0])
+		case width == 2 && signed:
+			ret[i] = int64(int16(binary.LittleEndian.Uint16(b)))
+		case width == 2:
+			ret[i] = int64(binary.LittleEndian.Uint16(b))
+		case width == 4 && signed:
+			ret[i] = int64(int32(binary.LittleEndian.Uint32(b)))
+		case width == 4:
+			ret[i] = int64(binary.LittleEndian.Uint32(b))
+		default:
+			v := binary.LittleEndian.Uint64(b)
+			if !signed && v > math.MaxInt64 {
+				return nil, fmt.Errorf("unsigned value %d overflows int64", v)
+			}
+			ret[i] = int64(v)
+		}
+	}
+	return ret, nil
+}
+
+func arrowTimeUnit(unit int16) int64 {
+	switch unit {
+	case arrowSecond:
+		return int64(time.Second)
+	case arrowMillisecond:
+		return int64(time.Millisecond)
+	case arrowMicrosecond:
+		return int64(time.Microsecond)
+	default:
+		return int64(time.Nanosecond)
+	}
+}
+
+// decodeArrowValues decodes n values of an arrow type from the buffers (after the validity bitmap) returned by nextBuffer.
+func decodeArrowValues(typeID uint8, typ fbTable, n int, nextBuffer func() []byte) (interface{}, error) {
+	switch typeID {
+	case arrowInt:
+		if n == 0 {
+			return []int64{}, nil
+		}
+		return decodeArrowInts(nextBuffer(), n, typ.int32(0, 0), typ.bool(1, false))
+	case arrowFloatingPoint:
+		precision := typ.int16(0, 0)
+		if n == 0 {
+			return []float64{}, nil
+		}
+		buf := nextBuffer()
+		ret := make([]float64, n)
+		switch precision {
+		case 1:
+			if len(buf) < 4*n {
+				return nil, fmt.Errorf("data buffer is too short")
+			}
+			for i := range ret {
+				ret[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:])))
+			}
+		case 2:
+			if len(buf) < 8*n {
+				return nil, fmt.Errorf("data buffer is too short")
+			}
+			for i := range ret {
+				ret[i] = math.Float64frombits(binary.LittleEndian.Uint64(buf[8*i:]))
+			}
+		default:
+			return nil, fmt.Errorf("unsupported floating point precision (%d)", precision)
+		}
+		return ret, nil
+	case arrowBool:
+		if n == 0 {
+			return []bool{}, nil
+		}
+		buf := nextBuffer()
+		if len(buf) < (n+7)/8 {
+			return nil, fmt.Errorf("data buffer is too short")
+		}
+		ret := make([]bool, n)
+		for i := range ret {
+			ret[i] = buf[i/8]&(1<<uint(i%8)) != 0
+		}
+		return ret, nil
+	case arrowUtf8, arrowBinary, arrowLargeUtf8, arrowLargeBinary:
+		if n == 0 {
+			return []string{}, nil
+		}
+		bitWidth := int32(32)
+		if typeID == arrowLargeUtf8 || typeID == arrowLargeBinary {
+			bitWidth = 64
+		}
+		offsets, err := decodeArrowInts(nextBuffer(), n+1, bitWidth, true)
+		if err != nil {
+			return nil, fmt.Errorf("offsets: %v", err)
+		}
+		data := nextBuffer()
+		ret := make([]string, n)
+		for i := range ret {
+			if offsets[i] < 0 || offsets[i] > offsets[i+1] || offsets[i+1] > int64(len(data)) {
+				return nil, fmt.Errorf("offset out of range")
+			}
+			ret[i] = string(data[offsets[i]:offsets[i+1]])
+		}
+		return ret, nil
+	case arrowDate:
+		if n == 0 {
+			return []civil.Date{}, nil
+		}
+		unit := typ.int16(0, 1)
+		ret := make([]civil.Date, n)
+		if unit == 0 {
+			days, err := decodeArrowInts(nextBuffer(), n, 32, true)
+			if err != nil {
+				return nil, err
+			}
+			for i := range ret {
+				ret[i] = unixEpochDate.AddDays(int(days[i]))
+			}
+			return ret, nil
+		}
+		millis, err := decodeArrowInts(nextBuffer(), n, 64, true)
+		if err != nil {
+			return nil, err
+		}
+		for i := range ret {
+			ret[i] = civil.DateOf(time.Unix(millis[i]/1000, millis[i]%1000*1e6).UTC())
+		}
+		return ret, nil
+	case arrowTime:
+		if n == 0 {
+			return []civil.Time{}, nil
+		}
+		nanosPerUnit := arrowTimeUnit(typ.int16(0, arrowMillisecond))
+		units, err := decodeArrowInts(nextBuffer(), n, typ.int32(1, 32), true)
+		if err != nil {
+			return nil, err
+		}
+		ret := make([]civil.Time, n)
+		for i := range ret {
+			ret[i] = civil.TimeOf(time.Unix(0, units[i]*nanosPerUnit).UTC())
+		}
+		return ret, nil
+	case arrowTimestamp:
+		if n == 0 {
+			return []time.Time{}, nil
+		}
+		nanosPerUnit := arrowTimeUnit(typ.int16(0, arrowSecond))
+		unitsPerSecond := int64(time.Second) / nanosPerUnit
+		units, err := decodeArrowInts(nextBuffer(), n, 64, true)
+		if err != nil {
+			return nil, err
+		}
+		ret := make([]time.Time, n)
+		for i := range ret {
+			ret[i] = time.Unix(units[i]/unitsPerSecond, units[i]%unitsPerSecond*nanosPerUnit).UTC()
+		}
+		return ret, nil
+	case arrowNull:
+		return []string{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type (%d)", typeID)
+	}
+}
+
+// writeArrow writes containers as an arrow IPC file (or stream) with a single record batch.
+// If metadata is not empty, it is written to the schema metadata.
+func writeArrow(containers []*valueContainer, metadata string, stream bool) ([]byte, error) {
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("must have at least one container")
+	}
+	n := containers[0].len()
+	fields := make([]fbFields, len(containers))
+	nodes := new(bytes.Buffer)
+	buffers := new(bytes.Buffer)
+	body := new(bytes.Buffer)
+	for k := range containers {
+		var nullCount int
+		validity := make([]byte, (n+7)/8)
+		for i := range containers[k].isNull {
+			if containers[k].isNull[i] {
+				nullCount++
+			} else {
+				validity[i/8] |= 1 << uint(i%8)
+			}
+		}
+		typeID, typ, columnBuffers, err := encodeArrowColumn(containers[k])
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", containers[k].name, err)
+		}
+		fields[k] = fbFields{containers[k].name, true, typeID, typ, nil, []fbFields{}}
+		binary.Write(nodes, binary.LittleEndian, []int64{int64(n), int64(nullCount)})
+		for _, buf := range append([][]byte{validity}, columnBuffers...) {
+			binary.Write(buffers, binary.LittleEndian, []int64{int64(body.Len()), int64(len(buf))})
+			body.Write(buf)
+			for body.Len()%8 != 0 {
+				body.WriteByte(0)
+			}
+		}
+	}
+	schema := fbFields{
+		int16(0), // little-endian
+		fields,
+		nil,
+	}
+	if metadata != "" {
+		schema[2] = []fbFields{{dataFrameMetadataKey, metadata}}
+	}
+	recordBatch := fbFields{
+		int64(n),
+		fbStructs{size: 16, data: nodes.Bytes()},
+		fbStructs{size: 16, data: buffers.Bytes()},
+	}
+
+	buf := new(bytes.Buffer)
+	if !stream {
+		buf.Write(arrowMagic)
+		buf.Write([]byte{0, 0})
+	}
+	_, _, err := writeArrowMessage(buf, arrowSchemaMessage, schema, nil)
+	if err != nil {
+		return nil, fmt.Errorf("schema: %v", err)
+	}
+	offset := buf.Len()
+	metadataLength, bodyLength, err := writeArrowMessage(buf, arrowRecordBatchMessage, recordBatch, body.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("record batch: %v", err)
+	}
+	// end of stream
+	binary.Write(buf, binary.LittleEndian, []uint32{arrowContinuation, 0})
+	if stream {
+		return buf.Bytes(), nil
+	}
+	block := new(bytes.Buffer)
+	binary.Write(block, binary.LittleEndian, int64(offset))
+	binary.Write(block, binary.LittleEndian, []int32{int32(metadataLength), 0})
+	binary.Write(block, binary.LittleEndian, int64(bodyLength))
+	footer, err := encodeFlatbuffer(fbFields{
+		int16(arrowMetadataVersion),
+		schema,
+		fbStructs{size: 24},
+		fbStructs{size: 24, data: block.Bytes()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("footer: %v", err)
+	}
+	buf.Write(footer)
+	binary.Write(buf, binary.LittleEndian, int32(len(footer)))
+	buf.Write(arrowMagic)
+	return buf.Bytes(), nil
+}
+
+// writeArrowMessage writes an encapsulated message and returns the length of its metadata (including the prefix) and body.
+func writeArrowMessage(w *bytes.Buffer, headerType uint8, header fbFields, body []byte) (int, int, error) {
+	message, err := encodeFlatbuffer(fbFields{
+		int16(arrowMetadataVersion),
+		headerType,
+		header,
+		int64(len(body)),
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	binary.Write(w, binary.LittleEndian, []uint32{arrowContinuation, uint32(len(message))})
+	w.Write(message)
+	w.Write(body)
+	return 8 + len(message), len(body), nil
+}
+
+// encodeArrowColumn returns the arrow type of vc and its buffers (after the validity bitmap).
+func encodeArrowColumn(vc *valueContainer) (uint8, fbFields, [][]byte, error) {
+	buf := new(bytes.Buffer)
+	switch vc.slice.(type) {
+	case []float64, []float32:
+		binary.Write(buf, binary.LittleEndian, vc.float64().slice)
+		return arrowFloatingPoint, fbFields{int16(2)}, [][]byte{buf.Bytes()}, nil
+	case []bool:
+		vals := vc.slice.([]bool)
+		bits := make([]byte, (len(vals)+7)/8)
+		for i := range vals {
+			if vals[i] {
+				bits[i/8] |= 1 << uint(i%8)
+			}
+		}
+		return arrowBool, fbFields{}, [][]byte{bits}, nil
+	case []int, []int8, []int16, []int32, []int64:
+		v := reflect.ValueOf(vc.slice)
+		vals := make([]int64, v.Len())
+		for i := range vals {
+			vals[i] = v.Index(i).Int()
+		}
+		binary.Write(buf, binary.LittleEndian, vals)
+		return arrowInt, fbFields{int32(64), true}, [][]byte{buf.Bytes()}, nil
+	case []time.Time:
+		vals := vc.slice.([]time.Time)
+		micros := make([]int64, len(vals))
+		for i := range vals {
+			if !vc.isNull[i] {
+				micros[i] = vals[i].Unix()*1e6 + int64(vals[i].Nanosecond())/1e3
+			}
+		}
+		binary.Write(buf, binary.LittleEndian, micros)
+		return arrowTimestamp, fbFields{int16(arrowMicrosecond), "UTC"}, [][]byte{buf.Bytes()}, nil
+	case []civil.Date:
+		vals := vc.slice.([]civil.Date)
+		days := make([]int32, len(vals))
+		for i := range vals {
+			if !vc.isNull[i] {
+				days[i] = int32(vals[i].DaysSince(unixEpochDate))
+			}
+		}
+		binary.Write(buf, binary.LittleEndian, days)
+		return arrowDate, fbFields{int16(0)}, [][]byte{buf.Bytes()}, nil
+	case []civil.Time:
+		vals := vc.slice.([]civil.Time)
+		nanos := make([]int64, len(vals))
+		for i := range vals {
+			if !vc.isNull[i] {
+				t := vals[i]
+				nanos[i] = int64(t.Hour*3600+t.Minute*60+t.Second)*1e9 + int64(t.Nanosecond)
+			}
+		}
+		binary.Write(buf, binary.LittleEndian, nanos)
+		return arrowTime, fbFields{int16(arrowNanosecond), int32(64)}, [][]byte{buf.Bytes()}, nil
+	default:
+		vals := vc.string().slice
+		offsets := make([]int32, len(vals)+1)
+		for i := range vals {
+			if !vc.isNull[i] {
+				buf.WriteString(vals[i])
+			}
+			if buf.Len() > math.MaxInt32 {
+				return 0, nil, nil, fmt.Errorf("string data exceeds maximum length (%d)", math.MaxInt32)
+			}
+			offsets[i+1] = int32(buf.Len())
+		}
+		offsetBuf := new(bytes.Buffer)
+		binary.Write(offsetBuf, binary.LittleEndian, offsets)
+		return arrowUtf8, fbFields{}, [][]byte{offsetBuf.Bytes(), buf.Bytes()}, nil
+	}
+}
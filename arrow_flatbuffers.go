@@ -0,0 +1,275 @@
+package tada
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// -- flatbuffers
+
+// an fbTable is a flatbuffers table that has been located within a buffer.
+// Accessors panic if the buffer is malformed; callers recover at the boundary of the decoding function.
+type fbTable struct {
+	b   []byte
+	pos int
+}
+
+func fbRoot(b []byte) fbTable {
+	return fbTable{b: b, pos: int(binary.LittleEndian.Uint32(b))}
+}
+
+// offset returns the position of field relative to the table, or 0 if the field is not present.
+func (t fbTable) offset(field int) int {
+	vtable := t.pos - int(int32(binary.LittleEndian.Uint32(t.b[t.pos:])))
+	vtableSize := int(binary.LittleEndian.Uint16(t.b[vtable:]))
+	o := 4 + 2*field
+	if o+2 > vtableSize {
+		return 0
+	}
+	return int(binary.LittleEndian.Uint16(t.b[vtable+o:]))
+}
+
+func (t fbTable) uint8(field int, defaultValue uint8) uint8 {
+	o := t.offset(field)
+	if o == 0 {
+		return defaultValue
+	}
+	return t.b[t.pos+o]
+}
+
+func (t fbTable) bool(field int, defaultValue bool) bool {
+	o := t.offset(field)
+	if o == 0 {
+		return defaultValue
+	}
+	return t.b[t.pos+o] != 0
+}
+
+func (t fbTable) int16(field int, defaultValue int16) int16 {
+	o := t.offset(field)
+	if o == 0 {
+		return defaultValue
+	}
+	return int16(binary.LittleEndian.Uint16(t.b[t.pos+o:]))
+}
+
+func (t fbTable) int32(field int, defaultValue int32) int32 {
+	o := t.offset(field)
+	if o == 0 {
+		return defaultValue
+	}
+	return int32(binary.LittleEndian.Uint32(t.b[t.pos+o:]))
+}
+
+func (t fbTable) int64(field int, defaultValue int64) int64 {
+	o := t.offset(field)
+	if o == 0 {
+		return defaultValue
+	}
+	return int64(binary.LittleEndian.Uint64(t.b[t.pos+o:]))
+}
+
+// indirect returns the position referenced by the offset stored in field
+func (t fbTable) indirect(field int) (int, bool) {
+	o := t.offset(field)
+	if o == 0 {
+		return 0, false
+	}
+	p := t.pos + o
+	return p + int(binary.LittleEndian.Uint32(t.b[p:])), true
+}
+
+func (t fbTable) table(field int) (fbTable, bool) {
+	p, ok := t.indirect(field)
+	if !ok {
+		return fbTable{}, false
+	}
+	return fbTable{b: t.b, pos: p}, true
+}
+
+func (t fbTable) string(field int) string {
+	p, ok := t.indirect(field)
+	if !ok {
+		return ""
+	}
+	l := int(binary.LittleEndian.Uint32(t.b[p:]))
+	return string(t.b[p+4 : p+4+l])
+}
+
+// vector returns the position of the first element in a vector field and the number of elements
+func (t fbTable) vector(field int) (int, int) {
+	p, ok := t.indirect(field)
+	if !ok {
+		return 0, 0
+	}
+	return p + 4, int(binary.LittleEndian.Uint32(t.b[p:]))
+}
+
+func (t fbTable) tables(field int) []fbTable {
+	start, n := t.vector(field)
+	ret := make([]fbTable, n)
+	for i := range ret {
+		p := start + 4*i
+		ret[i] = fbTable{b: t.b, pos: p + int(binary.LittleEndian.Uint32(t.b[p:]))}
+	}
+	return ret
+}
+
+// structs returns the raw bytes of each element in a vector of structs of size
+func (t fbTable) structs(field int, size int) [][]byte {
+	start, n := t.vector(field)
+	ret := make([][]byte, n)
+	for i := range ret {
+		ret[i] = t.b[start+i*size : start+(i+1)*size]
+	}
+	return ret
+}
+
+// fbFields describes a flatbuffers table to be encoded. The index of each value is its field id, and nil values are omitted.
+// Values may be: bool, uint8, int16, int32, int64, string, fbFields, []fbFields, or fbStructs.
+type fbFields []interface{}
+
+// fbStructs is a vector of inline structs, each of which is size bytes and 8-byte aligned.
+type fbStructs struct {
+	size int
+	data []byte
+}
+
+// an fbBuilder encodes flatbuffers front to back: every table is written before the objects it refers to,
+// so that all offsets are unsigned and point forward, as the format requires.
+type fbBuilder struct {
+	buf []byte
+}
+
+func (b *fbBuilder) pad(alignment int) {
+	for len(b.buf)%alignment != 0 {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+func (b *fbBuilder) putUint32(pos int, v uint32) {
+	binary.LittleEndian.PutUint32(b.buf[pos:], v)
+}
+
+func fbScalarSize(v interface{}) int {
+	switch v.(type) {
+	case bool, uint8:
+		return 1
+	case int16:
+		return 2
+	case int64:
+		return 8
+	default:
+		// int32 and offsets
+		return 4
+	}
+}
+
+// table writes the vtable and table for fields, followed by any referenced objects, and returns the position of the table.
+func (b *fbBuilder) table(fields fbFields) (int, error) {
+	offsets := make([]int, len(fields))
+	size := 4
+	for i := range fields {
+		if fields[i] == nil {
+			continue
+		}
+		s := fbScalarSize(fields[i])
+		for size%s != 0 {
+			size++
+		}
+		offsets[i] = size
+		size += s
+	}
+	b.pad(2)
+	vtable := len(b.buf)
+	b.buf = append(b.buf, make([]byte, 4+2*len(fields))...)
+	binary.LittleEndian.PutUint16(b.buf[vtable:], uint16(4+2*len(fields)))
+	binary.LittleEndian.PutUint16(b.buf[vtable+2:], uint16(size))
+	for i := range offsets {
+		binary.LittleEndian.PutUint16(b.buf[vtable+4+2*i:], uint16(offsets[i]))
+	}
+	b.pad(8)
+	table := len(b.buf)
+	b.buf = append(b.buf, make([]byte, size)...)
+	b.putUint32(table, uint32(table-vtable))
+	for i := range fields {
+		p := table + offsets[i]
+		switch v := fields[i].(type) {
+		case nil:
+		case bool:
+			if v {
+				b.buf[p] = 1
+			}
+		case uint8:
+			b.buf[p] = v
+		case int16:
+			binary.LittleEndian.PutUint16(b.buf[p:], uint16(v))
+		case int32:
+			b.putUint32(p, uint32(v))
+		case int64:
+			binary.LittleEndian.PutUint64(b.buf[p:], uint64(v))
+		default:
+			child, err := b.object(v)
+			if err != nil {
+				return 0, fmt.Errorf("field %d: %v", i, err)
+			}
+			b.putUint32(p, uint32(child-p))
+		}
+	}
+	return table, nil
+}
+
+// object writes a string, table, or vector and returns its position.
+func (b *fbBuilder) object(v interface{}) (int, error) {
+	switch v := v.(type) {
+	case string:
+		b.pad(4)
+		p := len(b.buf)
+		b.buf = append(b.buf, make([]byte, 4)...)
+		b.putUint32(p, uint32(len(v)))
+		b.buf = append(b.buf, v...)
+		b.buf = append(b.buf, 0)
+		return p, nil
+	case fbFields:
+		return b.table(v)
+	case []fbFields:
+		b.pad(4)
+		p := len(b.buf)
+		b.buf = append(b.buf, make([]byte, 4+4*len(v))...)
+		b.putUint32(p, uint32(len(v)))
+		for i := range v {
+			child, err := b.table(v[i])
+			if err != nil {
+				return 0, err
+			}
+			elem := p + 4 + 4*i
+			b.putUint32(elem, uint32(child-elem))
+		}
+		return p, nil
+	case fbStructs:
+		// the elements (not the length prefix) must be 8-byte aligned
+		b.pad(4)
+		if (len(b.buf)+4)%8 != 0 {
+			b.buf = append(b.buf, 0, 0, 0, 0)
+		}
+		p := len(b.buf)
+		b.buf = append(b.buf, make([]byte, 4)...)
+		b.putUint32(p, uint32(len(v.data)/v.size))
+		b.buf = append(b.buf, v.data...)
+		return p, nil
+	default:
+		return 0, fmt.Errorf("unsupported type (%T)", v)
+	}
+}
+
+// encodeFlatbuffer encodes root as a flatbuffer, padded to a multiple of 8 bytes.
+func encodeFlatbuffer(root fbFields) ([]byte, error) {
+	b := &fbBuilder{buf: make([]byte, 4)}
+	pos, err := b.table(root)
+	if err != nil {
+		return nil, fmt.Errorf("encoding flatbuffer: %v", err)
+	}
+	b.putUint32(0, uint32(pos))
+	b.pad(8)
+	return b.buf, nil
+}
@@ -0,0 +1,67 @@
+package tada
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_encodeFlatbuffer(t *testing.T) {
+	b, err := encodeFlatbuffer(fbFields{
+		int16(-2),
+		uint8(3),
+		fbFields{"foo", true},
+		int64(1 << 40),
+		[]fbFields{{int32(1)}, {int32(2)}},
+		fbStructs{size: 16, data: []byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}},
+		nil,
+		"bar",
+	})
+	if err != nil {
+		t.Errorf("encodeFlatbuffer() error = %v", err)
+		return
+	}
+	if len(b)%8 != 0 {
+		t.Errorf("encodeFlatbuffer() len = %v, want multiple of 8", len(b))
+	}
+	root := fbRoot(b)
+	if root.pos%8 != 0 {
+		t.Errorf("encodeFlatbuffer() root position = %v, want 8-byte aligned", root.pos)
+	}
+	if got := root.int16(0, 0); got != -2 {
+		t.Errorf("fbTable.int16() = %v, want -2", got)
+	}
+	if got := root.uint8(1, 0); got != 3 {
+		t.Errorf("fbTable.uint8() = %v, want 3", got)
+	}
+	child, ok := root.table(2)
+	if !ok || child.string(0) != "foo" || !child.bool(1, false) {
+		t.Errorf("fbTable.table() = %v, %v, %v, want foo, true", ok, child.string(0), child.bool(1, false))
+	}
+	if got := root.int64(3, 0); got != 1<<40 {
+		t.Errorf("fbTable.int64() = %v, want %v", got, int64(1<<40))
+	}
+	tables := root.tables(4)
+	if len(tables) != 2 || tables[0].int32(0, 0) != 1 || tables[1].int32(0, 0) != 2 {
+		t.Errorf("fbTable.tables() returned unexpected values")
+	}
+	structs := root.structs(5, 16)
+	want := [][]byte{{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0}}
+	if !reflect.DeepEqual(structs, want) {
+		t.Errorf("fbTable.structs() = %v, want %v", structs, want)
+	}
+	if start, _ := root.vector(5); start%8 != 0 {
+		t.Errorf("fbTable.vector() start = %v, want 8-byte aligned", start)
+	}
+	if _, ok := root.table(6); ok {
+		t.Errorf("fbTable.table() on omitted field = true, want false")
+	}
+	if got := root.int32(6, 7); got != 7 {
+		t.Errorf("fbTable.int32() on omitted field = %v, want default 7", got)
+	}
+	if got := root.string(7); got != "bar" {
+		t.Errorf("fbTable.string() = %v, want bar", got)
+	}
+	if got := root.int32(20, 5); got != 5 {
+		t.Errorf("fbTable.int32() beyond vtable = %v, want default 5", got)
+	}
+}
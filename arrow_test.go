@@ -0,0 +1,253 @@
+package tada
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+func TestArrowWriter_Write(t *testing.T) {
+	d := time.Date(2020, 1, 1, 12, 30, 0, 500000000, time.UTC)
+	// the writer may cache string values in the input, so each test case receives a new DataFrame
+	df := func() *DataFrame {
+		return &DataFrame{
+			values: []*valueContainer{
+				{slice: []float64{1.5, 0, 3}, isNull: []bool{false, true, false}, id: mockID, name: "foo"},
+				{slice: []string{"a", "", "c"}, isNull: []bool{false, true, false}, id: mockID, name: "bar"},
+				{slice: []bool{true, false, true}, isNull: []bool{false, false, false}, id: mockID, name: "baz"},
+				{slice: []time.Time{d, {}, d}, isNull: []bool{false, true, false}, id: mockID, name: "qux"},
+				{slice: []civil.Date{{Year: 2020, Month: 1, Day: 2}, {Year: 1969, Month: 12, Day: 31}, {}},
+					isNull: []bool{false, false, true}, id: mockID, name: "corge"},
+				{slice: []civil.Time{{Hour: 1, Minute: 2, Second: 3, Nanosecond: 4}, {}, {Hour: 23}},
+					isNull: []bool{false, true, false}, id: mockID, name: "grault"},
+			},
+			labels: []*valueContainer{
+				{slice: []int64{0, -1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+			name:          "qux"}
+	}
+	type fields struct {
+		IncludeLabels bool
+		Stream        bool
+	}
+	type args struct {
+		df *DataFrame
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - file", fields{IncludeLabels: true}, args{df()}, df(), false},
+		{"pass - stream", fields{IncludeLabels: true, Stream: true}, args{df()}, df(), false},
+		{"pass - exclude labels", fields{IncludeLabels: false},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo|bar"}},
+				labels: []*valueContainer{
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "*1"}}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []int64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo|bar"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "*1"}},
+			false},
+		{"pass - empty", fields{IncludeLabels: true},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{}, isNull: []bool{}, id: mockID, name: "foo"},
+					{slice: []float64{}, isNull: []bool{}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{
+					{slice: []int64{}, isNull: []bool{}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{}, isNull: []bool{}, id: mockID, name: "foo"},
+					{slice: []float64{}, isNull: []bool{}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{
+					{slice: []int64{}, isNull: []bool{}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - error in df", fields{IncludeLabels: true},
+			args{dataFrameWithError(errors.New("foo"))},
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := NewArrowWriter(b)
+			w.IncludeLabels = tt.fields.IncludeLabels
+			w.Stream = tt.fields.Stream
+			if err := w.Write(tt.args.df); (err != nil) != tt.wantErr {
+				t.Errorf("ArrowWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if bytes.HasPrefix(b.Bytes(), arrowMagic) == tt.fields.Stream {
+				t.Errorf("ArrowWriter.Write() wrote file format = %v, want %v", !tt.fields.Stream, !tt.fields.Stream)
+			}
+			got, err := NewArrowReader(b).Read()
+			if err != nil {
+				t.Errorf("ArrowReader.Read() error = %v", err)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("ArrowWriter.Write() -> ArrowReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// writeMockDictionaryStream writes an arrow stream with one dictionary-encoded string column
+// whose values are ["bar", null, "foo"] and pandas metadata that identifies the column as the index.
+func writeMockDictionaryStream() []byte {
+	buf := new(bytes.Buffer)
+	writeArrowMessage(buf, arrowSchemaMessage, fbFields{
+		int16(0),
+		[]fbFields{
+			{"__index_level_0__", true, uint8(arrowUtf8), fbFields{},
+				fbFields{int64(0), fbFields{int32(8), true}}, []fbFields{}},
+			{"baz", true, uint8(arrowInt), fbFields{int32(16), false}, nil, []fbFields{}},
+		},
+		[]fbFields{{"pandas", `{"index_columns": ["__index_level_0__"]}`}},
+	}, nil)
+
+	dictionaryBody := new(bytes.Buffer)
+	binary.Write(dictionaryBody, binary.LittleEndian, []int32{0, 3, 6, 0})
+	dictionaryBody.WriteString("foobar\x00\x00")
+	dictionaryBuffers := new(bytes.Buffer)
+	binary.Write(dictionaryBuffers, binary.LittleEndian, []int64{0, 0, 0, 12, 16, 6})
+	writeArrowMessage(buf, arrowDictionaryBatchMessage, fbFields{
+		int64(0),
+		fbFields{
+			int64(2),
+			fbStructs{size: 16, data: []byte{2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+			fbStructs{size: 16, data: dictionaryBuffers.Bytes()},
+		},
+	}, dictionaryBody.Bytes())
+
+	body := []byte{
+		5, 0, 0, 0, 0, 0, 0, 0, // validity
+		1, 7, 0, 0, 0, 0, 0, 0, // indices
+		10, 0, 20, 0, 30, 0, 0, 0, // uint16 values
+	}
+	nodes := new(bytes.Buffer)
+	binary.Write(nodes, binary.LittleEndian, []int64{3, 1, 3, 0})
+	buffers := new(bytes.Buffer)
+	binary.Write(buffers, binary.LittleEndian, []int64{0, 1, 8, 3, 16, 0, 16, 6})
+	writeArrowMessage(buf, arrowRecordBatchMessage, fbFields{
+		int64(3),
+		fbStructs{size: 16, data: nodes.Bytes()},
+		fbStructs{size: 16, data: buffers.Bytes()},
+	}, body)
+	binary.Write(buf, binary.LittleEndian, []uint32{arrowContinuation, 0})
+	return buf.Bytes()
+}
+
+func TestArrowReader_Read(t *testing.T) {
+	noMetadata, _ := writeArrow([]*valueContainer{
+		{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+		{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+	}, "", true)
+	file, _ := writeArrow([]*valueContainer{
+		{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+	}, "", false)
+	type fields struct {
+		LabelLevels int
+		Name        string
+		b           []byte
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - label levels", fields{LabelLevels: 1, Name: "baz", b: noMetadata},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				colLevelNames: []string{"*0"},
+				name:          "baz"},
+			false},
+		{"pass - dictionary and pandas index", fields{b: writeMockDictionaryStream()},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []int64{10, 20, 30}, isNull: []bool{false, false, false}, id: mockID, name: "baz"}},
+				labels: []*valueContainer{
+					{slice: []string{"bar", "", "foo"}, isNull: []bool{false, true, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - too many label levels", fields{LabelLevels: 2, b: noMetadata},
+			nil, true},
+		{"fail - not arrow", fields{b: []byte("foo,bar\n1,2\n")},
+			nil, true},
+		{"fail - truncated stream", fields{b: noMetadata[:40]},
+			nil, true},
+		{"fail - truncated file", fields{b: file[:len(file)-4]},
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewArrowReader(bytes.NewReader(tt.fields.b))
+			r.LabelLevels = tt.fields.LabelLevels
+			r.Name = tt.fields.Name
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ArrowReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("ArrowReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArrowReader_Read_pyarrow(t *testing.T) {
+	for _, name := range []string{"pyarrow.arrow", "pyarrow_dictionary.arrows"} {
+		t.Run(name, func(t *testing.T) {
+			b := readPyarrowFixture(t, name)
+			got, err := NewArrowReader(bytes.NewReader(b)).Read()
+			if err != nil {
+				t.Fatalf("ArrowReader.Read() error = %v", err)
+			}
+			if !EqualDataFrames(got, pyarrowFixture()) {
+				t.Errorf("ArrowReader.Read() = %v, want %v", got, pyarrowFixture())
+			}
+		})
+	}
+}
+
+// test_pyarrow/tada.arrow is checked by test_pyarrow/generate.py, so it must match the current output of ArrowWriter.
+func TestArrowWriter_Write_pyarrowFixture(t *testing.T) {
+	want, err := ioutil.ReadFile(filepath.Join("test_pyarrow", "tada.arrow"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := new(bytes.Buffer)
+	w := NewArrowWriter(got)
+	w.IncludeLabels = false
+	err = w.Write(pyarrowFixture())
+	if err != nil {
+		t.Fatalf("ArrowWriter.Write() error = %v", err)
+	}
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Errorf("ArrowWriter.Write() does not match test_pyarrow/tada.arrow " +
+			"(if the change is intended, replace the file and check it with test_pyarrow/generate.py)")
+	}
+}
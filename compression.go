@@ -0,0 +1,247 @@
+package tada
+
+import (
+	"bufio"
+	"compress/bzip2"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// -- compression and text encoding
+
+// NewDecodingReader returns a reader of the decompressed, UTF-8 encoded contents of r,
+// which may be passed to any Reader constructor (e.g., NewCSVReader).
+//
+// Compression is detected from the magic bytes at the start of r: gzip, bzip2, and Zstandard (zstd) are supported.
+// After decompression, a UTF-8 byte order mark is removed, and text with a UTF-16 (little- or big-endian) byte order mark
+// is transcoded to UTF-8. Any other bytes that are not valid UTF-8 are read as Latin-1 (Windows-1252) and transcoded to UTF-8.
+// Errors from detection are returned by the first call to Read.
+func NewDecodingReader(r io.Reader) io.Reader {
+	return &decodingReader{src: r}
+}
+
+// Open opens the named file for reading with NewDecodingReader. The caller should close the file when finished.
+func Open(name string) (io.ReadCloser, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %v", err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{NewDecodingReader(f), f}, nil
+}
+
+// NewCompressingWriter returns a writer that compresses everything written to w with compression.
+// The caller must close the writer to flush the compressed data (the underlying w is not closed).
+// Bzip2 is not supported for writing.
+// Zstd output is buffered in full and written on Close.
+func NewCompressingWriter(w io.Writer, compression Compression) (io.WriteCloser, error) {
+	err := checkWritableCompression(compression)
+	if err != nil {
+		return nil, err
+	}
+	switch compression {
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return &zstdWriter{w: w}, nil
+	default:
+		return nopWriteCloser{w}, nil
+	}
+}
+
+// checkWritableCompression returns an error if compression is not supported by NewCompressingWriter.
+func checkWritableCompression(compression Compression) error {
+	switch compression {
+	case NoCompression, Gzip, Zstd:
+		return nil
+	case Bzip2:
+		return fmt.Errorf("compressing: bzip2 is not supported for writing")
+	default:
+		return fmt.Errorf("compressing: unsupported compression (%d)", compression)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// detectCompression returns the compression format identified by the magic bytes at the start of b.
+func detectCompression(b []byte) Compression {
+	switch {
+	case len(b) >= 2 && b[0] == 0x1F && b[1] == 0x8B:
+		return Gzip
+	case len(b) >= 3 && string(b[:3]) == "BZh":
+		return Bzip2
+	case len(b) >= 4 && binary.LittleEndian.Uint32(b) == zstdMagic:
+		return Zstd
+	default:
+		return NoCompression
+	}
+}
+
+type decodingReader struct {
+	src io.Reader
+	r   io.Reader
+	err error
+}
+
+func (d *decodingReader) Read(p []byte) (int, error) {
+	if d.r == nil && d.err == nil {
+		d.r, d.err = decode(d.src)
+	}
+	if d.err != nil {
+		return 0, d.err
+	}
+	return d.r.Read(p)
+}
+
+// decode wraps r with a decompressor (if r is compressed) and a transcoder to UTF-8.
+func decode(r io.Reader) (io.Reader, error) {
+	decompressed, err := decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	text := bufio.NewReader(decompressed)
+	bom, _ := text.Peek(3)
+	switch {
+	case len(bom) >= 3 && bom[0] == 0xEF && bom[1] == 0xBB && bom[2] == 0xBF:
+		text.Discard(3)
+		return text, nil
+	case len(bom) >= 2 && bom[0] == 0xFF && bom[1] == 0xFE:
+		text.Discard(2)
+		return &utf16Reader{r: text, order: binary.LittleEndian}, nil
+	case len(bom) >= 2 && bom[0] == 0xFE && bom[1] == 0xFF:
+		text.Discard(2)
+		return &utf16Reader{r: text, order: binary.BigEndian}, nil
+	default:
+		return &latin1FallbackReader{r: text}, nil
+	}
+}
+
+// decompress wraps r with a decompressor if r is compressed.
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(4)
+	switch detectCompression(magic) {
+	case Gzip:
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("decoding: gzip: %v", err)
+		}
+		return gz, nil
+	case Bzip2:
+		return bzip2.NewReader(br), nil
+	case Zstd:
+		return newZstdReader(br), nil
+	default:
+		return br, nil
+	}
+}
+
+// a utf16Reader transcodes UTF-16 to UTF-8. Unpaired surrogates are replaced with utf8.RuneError.
+type utf16Reader struct {
+	r     *bufio.Reader
+	order binary.ByteOrder
+	out   []byte
+}
+
+func (u *utf16Reader) Read(p []byte) (int, error) {
+	for len(u.out) == 0 {
+		unit, err := u.next()
+		if err != nil {
+			return 0, err
+		}
+		r := rune(unit)
+		if utf16.IsSurrogate(r) {
+			r = utf8.RuneError
+			if b, _ := u.r.Peek(2); len(b) == 2 {
+				if paired := utf16.DecodeRune(rune(unit), rune(u.order.Uint16(b))); paired != utf8.RuneError {
+					r = paired
+					u.r.Discard(2)
+				}
+			}
+		}
+		var buf [utf8.UTFMax]byte
+		n := utf8.EncodeRune(buf[:], r)
+		u.out = append(u.out, buf[:n]...)
+	}
+	n := copy(p, u.out)
+	u.out = u.out[n:]
+	return n, nil
+}
+
+func (u *utf16Reader) next() (uint16, error) {
+	var b [2]byte
+	_, err := io.ReadFull(u.r, b[:])
+	if err == io.ErrUnexpectedEOF {
+		return 0, fmt.Errorf("decoding: utf-16: odd number of bytes")
+	}
+	if err != nil {
+		return 0, err
+	}
+	return u.order.Uint16(b[:]), nil
+}
+
+// a latin1FallbackReader passes through valid UTF-8 and transcodes every other byte from Windows-1252 to UTF-8.
+type latin1FallbackReader struct {
+	r   *bufio.Reader
+	out []byte
+}
+
+// windows1252 maps bytes 0x80-0x9F to runes. All other bytes have the same value in Latin-1 and Unicode.
+var windows1252 = [32]rune{
+	0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021, 0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+	0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014, 0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+}
+
+func (l *latin1FallbackReader) Read(p []byte) (int, error) {
+	if len(l.out) == 0 {
+		chunk, err := l.r.Peek(4096)
+		if len(chunk) == 0 {
+			return 0, err
+		}
+		l.out = l.out[:0]
+		var i int
+		for i < len(chunk) {
+			c := chunk[i]
+			if c < utf8.RuneSelf {
+				l.out = append(l.out, c)
+				i++
+				continue
+			}
+			// wait for the rest of a rune that is split across chunks
+			if !utf8.FullRune(chunk[i:]) && err == nil && i > 0 {
+				break
+			}
+			r, size := utf8.DecodeRune(chunk[i:])
+			if r == utf8.RuneError && size == 1 {
+				r = rune(c)
+				if c < 0xA0 {
+					r = windows1252[c-0x80]
+				}
+				var buf [utf8.UTFMax]byte
+				n := utf8.EncodeRune(buf[:], r)
+				l.out = append(l.out, buf[:n]...)
+				i++
+				continue
+			}
+			l.out = append(l.out, chunk[i:i+size]...)
+			i += size
+		}
+		l.r.Discard(i)
+	}
+	n := copy(p, l.out)
+	l.out = l.out[n:]
+	return n, nil
+}
@@ -0,0 +1,157 @@
+package tada
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func gzipBytes(b []byte) []byte {
+	buf := new(bytes.Buffer)
+	w := gzip.NewWriter(buf)
+	w.Write(b)
+	w.Close()
+	return buf.Bytes()
+}
+
+func TestNewDecodingReader(t *testing.T) {
+	// printf 'foo,bar\n1,2\n' | bzip2
+	bzip2Data := []byte{0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xf7, 0x56,
+		0x3e, 0x90, 0x00, 0x00, 0x04, 0x59, 0x80, 0x00, 0x10, 0x00, 0x04, 0x30,
+		0x00, 0x31, 0x00, 0x90, 0x00, 0x20, 0x00, 0x22, 0x03, 0x23, 0xd4, 0x20,
+		0xc9, 0x88, 0x12, 0x13, 0xea, 0xbc, 0x0f, 0x17, 0x72, 0x45, 0x38, 0x50,
+		0x90, 0xf7, 0x56, 0x3e, 0x90}
+	utf16LE := []byte{0xFF, 0xFE, 'f', 0, 0xE9, 0, ',', 0, 0x3D, 0xD8, 0x00, 0xDE, '\n', 0}
+	utf16BE := []byte{0xFE, 0xFF, 0, 'f', 0, 0xE9, 0, ',', 0xD8, 0x3D, 0xDE, 0x00, 0, '\n'}
+	tests := []struct {
+		name    string
+		input   []byte
+		want    string
+		wantErr bool
+	}{
+		{"plain", []byte("foo,bar\n"), "foo,bar\n", false},
+		{"utf-8 with bom", []byte("\xEF\xBB\xBFfoo,bar\n"), "foo,bar\n", false},
+		{"utf-16le with bom", utf16LE, "fé,😀\n", false},
+		{"utf-16be with bom", utf16BE, "fé,😀\n", false},
+		{"latin-1", []byte("caf\xE9,\x80,ok\n"), "café,€,ok\n", false},
+		{"mixed utf-8 and latin-1", []byte("café,caf\xE9\n"), "café,café\n", false},
+		{"gzip", gzipBytes([]byte("foo,bar\n")), "foo,bar\n", false},
+		{"gzip with utf-16le", gzipBytes(utf16LE), "fé,😀\n", false},
+		{"bzip2", bzip2Data, "foo,bar\n1,2\n", false},
+		{"zstd", zstdCompress([]byte("\xEF\xBB\xBFfoo,bar\n")), "foo,bar\n", false},
+		{"empty", []byte{}, "", false},
+		{"fail - truncated gzip header", []byte{0x1F, 0x8B, 0x08}, "", true},
+		{"fail - odd utf-16", []byte{0xFF, 0xFE, 'f'}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ioutil.ReadAll(NewDecodingReader(bytes.NewReader(tt.input)))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewDecodingReader() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("NewDecodingReader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDecodingReader_long(t *testing.T) {
+	// multi-byte runes split across internal chunks
+	want := strings.Repeat("é,ü\n", 5000)
+	got, err := ioutil.ReadAll(NewDecodingReader(strings.NewReader(want)))
+	if err != nil || string(got) != want {
+		t.Errorf("NewDecodingReader() error = %v, output matches = %v", err, string(got) == want)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "foo.csv.gz")
+	ioutil.WriteFile(name, gzipBytes([]byte("foo,bar\n1,2\n")), 0644)
+	f, err := Open(name)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer f.Close()
+	df, err := NewCSVReader(f).Read()
+	if err != nil {
+		t.Fatalf("Open() -> CSVReader.Read() error = %v", err)
+	}
+	want := &DataFrame{values: []*valueContainer{
+		{slice: []string{"1"}, isNull: []bool{false}, id: mockID, name: "foo"},
+		{slice: []string{"2"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+		labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"}}
+	if !EqualDataFrames(df, want) {
+		t.Errorf("Open() -> CSVReader.Read() = %v, want %v", df, want)
+	}
+	if _, err := Open(filepath.Join(dir, "bar.csv")); err == nil {
+		t.Errorf("Open() missing file error = nil, want error")
+	}
+}
+
+func TestCSVWriter_Write_compression(t *testing.T) {
+	df := &DataFrame{values: []*valueContainer{
+		{slice: []string{"1", "2"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+		labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"}}
+	tests := []struct {
+		name        string
+		compression Compression
+		wantErr     bool
+	}{
+		{"gzip", Gzip, false},
+		{"zstd", Zstd, false},
+		{"none", NoCompression, false},
+		{"fail - bzip2", Bzip2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := NewCSVWriter(buf)
+			w.Compression = tt.compression
+			w.Comma = ';'
+			err := w.Write(df)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CSVWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := detectCompression(buf.Bytes()); got != tt.compression {
+				t.Errorf("CSVWriter.Write() compression = %v, want %v", got, tt.compression)
+			}
+			got, err := ioutil.ReadAll(NewDecodingReader(buf))
+			if err != nil {
+				t.Errorf("CSVWriter.Write() -> NewDecodingReader() error = %v", err)
+				return
+			}
+			if want := "foo\n1\n2\n"; string(got) != want {
+				t.Errorf("CSVWriter.Write() -> NewDecodingReader() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCSVWriter_Write_compressionWithoutNewCSVWriter(t *testing.T) {
+	df := &DataFrame{values: []*valueContainer{
+		{slice: []string{"1"}, isNull: []bool{false}, id: mockID, name: "foo"}},
+		labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"}}
+	w := &CSVWriter{RecordWriter: &RecordWriter{}, Writer: csv.NewWriter(new(bytes.Buffer)), Compression: Gzip}
+	if err := w.Write(df); err == nil {
+		t.Errorf("CSVWriter.Write() without NewCSVWriter error = nil, want error")
+	}
+}
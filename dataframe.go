@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/ptiger10/tablewriter"
 )
@@ -100,8 +102,9 @@ func ConcatSeries(series ...*Series) (*DataFrame, error) {
 }
 
 // Cast coerces the underlying container values (column or label level) to
-// []float64, []string, []time.Time (aka timezone-aware DateTime), []civil.Date, or []civil.Time
+// []float64, []string, []time.Time (aka timezone-aware DateTime), []civil.Date, []civil.Time, []int64, or []bool
 // and caches the []byte values of the container (if inexpensive).
+// When casting to Int64, a non-null value that cannot be represented as int64 (e.g., 1.5 or 1e19) sets an error on df.
 // Use cast to improve performance when calling multiple operations on values.
 func (df *DataFrame) Cast(containerAsType map[string]DType) {
 	mergedLabelsAndCols := append(df.labels, df.values...)
@@ -111,7 +114,11 @@ func (df *DataFrame) Cast(containerAsType map[string]DType) {
 			df.resetWithError(fmt.Errorf("type casting: %v", err))
 			return
 		}
-		mergedLabelsAndCols[index].cast(dtype)
+		err = mergedLabelsAndCols[index].cast(dtype)
+		if err != nil {
+			df.resetWithError(fmt.Errorf("type casting: %v: %v", name, err))
+			return
+		}
 	}
 	return
 }
@@ -297,7 +304,7 @@ func listNamesAtLevel(columns []*valueContainer, level int, numLevels int) ([]st
 		return nil, fmt.Errorf("level out of range: %d >= %d", level, numLevels)
 	}
 	for k := range columns {
-		levels := splitNameIntoLevels(columns[k].name)
+		levels := splitNameIntoNumLevels(columns[k].name, numLevels)
 		ret[k] = levels[level]
 	}
 	return ret, nil
@@ -1231,7 +1238,7 @@ func (df *DataFrame) Transpose() *DataFrame {
 	// iterate over columns
 	for k := range df.values {
 		// write label values
-		splitColName := splitNameIntoLevels(df.values[k].name)
+		splitColName := splitNameIntoNumLevels(df.values[k].name, df.numColLevels())
 		for l := range splitColName {
 			labels[l][k] = splitColName[l]
 			labelsIsNull[l][k] = false
@@ -1332,7 +1339,7 @@ func (df *DataFrame) PromoteToColLevel(name string) *DataFrame {
 		// m -> incrementor of unique values in the column to be promoted
 		for m, uniqueValue := range uniqueValuesToPromote {
 			newColumnIndex := k*len(uniqueValuesToPromote) + m
-			newHeader := joinLevelsIntoName([]string{uniqueValue, df.values[k].name})
+			newHeader := joinLevelsIntoName(append([]string{uniqueValue}, splitNameIntoNumLevels(df.values[k].name, df.numColLevels())...))
 			colNames[newColumnIndex] = newHeader
 			// each item in newVals is a slice of the same type as originalVals at that column position
 			newVals[newColumnIndex] = reflect.MakeSlice(originalVals.Type(), numNewRows, numNewRows).Interface()
@@ -1372,6 +1379,254 @@ func (df *DataFrame) PromoteToColLevel(name string) *DataFrame {
 	}
 }
 
+// Stack moves the innermost column level into a new innermost label level (named after the column level),
+// which is the inverse of Unstack.
+// Each row is repeated once for every unique name in the innermost column level (in order of first appearance),
+// and each group of columns that share the same outer levels is combined into a single column.
+// Combinations that do not exist as columns in the original DataFrame are null.
+// If the combined columns have different types, their values are converted to string.
+// The DataFrame must have at least two column levels.
+// Returns a new DataFrame.
+func (df *DataFrame) Stack() *DataFrame {
+	if df.err != nil {
+		return dataFrameWithError(fmt.Errorf("stacking: %v", df.err))
+	}
+	numLevels := df.numColLevels()
+	if numLevels < 2 {
+		return dataFrameWithError(fmt.Errorf("stacking: must have at least two column levels"))
+	}
+	// isolate the innermost level of each column name
+	var outerNames, innerNames []string
+	outerIndex := make(map[string]int)
+	innerIndex := make(map[string]int)
+	// sources[outer][inner] is the original column with that outer and inner name, or nil if there is none
+	var sources [][]*valueContainer
+	for k := range df.values {
+		levels := splitNameIntoNumLevels(df.values[k].name, numLevels)
+		for len(levels) < numLevels {
+			levels = append(levels, "")
+		}
+		outer := joinLevelsIntoName(levels[:numLevels-1])
+		inner := levels[numLevels-1]
+		if _, ok := outerIndex[outer]; !ok {
+			outerIndex[outer] = len(outerNames)
+			outerNames = append(outerNames, outer)
+			sources = append(sources, nil)
+		}
+		if _, ok := innerIndex[inner]; !ok {
+			innerIndex[inner] = len(innerNames)
+			innerNames = append(innerNames, inner)
+		}
+		row := sources[outerIndex[outer]]
+		for len(row) <= innerIndex[inner] {
+			row = append(row, nil)
+		}
+		if row[innerIndex[inner]] == nil {
+			row[innerIndex[inner]] = df.values[k]
+		}
+		sources[outerIndex[outer]] = row
+	}
+	numInner := len(innerNames)
+	numNewRows := df.Len() * numInner
+	// repeat each original row once per inner name
+	repeatIndex := make([]int, numNewRows)
+	for i := range repeatIndex {
+		repeatIndex[i] = i / numInner
+	}
+	labels := make([]*valueContainer, len(df.labels), len(df.labels)+1)
+	for j := range df.labels {
+		labels[j] = df.labels[j].copy()
+		labels[j].subsetRows(repeatIndex)
+	}
+	newLabel := make([]string, numNewRows)
+	for i := range newLabel {
+		newLabel[i] = innerNames[i%numInner]
+	}
+	labels = append(labels, newValueContainer(newLabel, make([]bool, numNewRows), df.colLevelNames[numLevels-1]))
+
+	values := make([]*valueContainer, len(outerNames))
+	for k := range outerNames {
+		row := sources[k]
+		// use the shared type of every source column, or string if the types differ
+		var sliceType reflect.Type
+		for _, src := range row {
+			if src == nil {
+				continue
+			}
+			if sliceType == nil {
+				sliceType = reflect.TypeOf(src.slice)
+			} else if sliceType != reflect.TypeOf(src.slice) {
+				sliceType = reflect.TypeOf([]string{})
+			}
+		}
+		srcValues := make([]reflect.Value, numInner)
+		for m := range row {
+			if row[m] == nil {
+				continue
+			}
+			if sliceType == reflect.TypeOf(row[m].slice) {
+				srcValues[m] = reflect.ValueOf(row[m].slice)
+			} else {
+				srcValues[m] = reflect.ValueOf(row[m].copy().string().slice)
+			}
+		}
+		newSlice := reflect.MakeSlice(sliceType, numNewRows, numNewRows)
+		isNull := make([]bool, numNewRows)
+		for i := range isNull {
+			m := i % numInner
+			if m >= len(row) || row[m] == nil {
+				isNull[i] = true
+				continue
+			}
+			newSlice.Index(i).Set(srcValues[m].Index(i / numInner))
+			isNull[i] = row[m].isNull[i/numInner]
+		}
+		values[k] = newValueContainer(newSlice.Interface(), isNull, outerNames[k])
+	}
+	return &DataFrame{
+		values:        values,
+		labels:        labels,
+		colLevelNames: append([]string{}, df.colLevelNames[:numLevels-1]...),
+		name:          df.name,
+	}
+}
+
+// Unstack moves the label level name into a new innermost column level (named after the label level),
+// which is the inverse of Stack.
+// Each unique value in the label level (in order of first appearance) is nested below each existing column,
+// and rows that share the same values in the other label levels are combined into a single row.
+// Combinations that do not exist in the original DataFrame are null.
+// The DataFrame must have at least two label levels.
+// Returns a new DataFrame.
+func (df *DataFrame) Unstack(name string) *DataFrame {
+	if df.err != nil {
+		return dataFrameWithError(fmt.Errorf("unstacking: %v", df.err))
+	}
+	if _, err := indexOfContainer(name, df.labels); err != nil {
+		return dataFrameWithError(fmt.Errorf("unstacking: label level %v", err))
+	}
+	promoted := df.PromoteToColLevel(name)
+	if promoted.err != nil {
+		return dataFrameWithError(fmt.Errorf("unstacking: %v", promoted.err))
+	}
+	// PromoteToColLevel adds the outermost column level; rotate it to be the innermost
+	for k := range promoted.values {
+		levels := splitNameIntoNumLevels(promoted.values[k].name, promoted.numColLevels())
+		promoted.values[k].name = joinLevelsIntoName(append(levels[1:], levels[0]))
+	}
+	promoted.colLevelNames = append(promoted.colLevelNames[1:], promoted.colLevelNames[0])
+	return promoted
+}
+
+// Melt unpivots df from wide to long format, which is the inverse of PivotTable.
+// Each container named in idCols (either label levels or columns) becomes a column, and each column named in valueCols
+// is unpivoted into one row per original row, with the column's name in a new varName column
+// and the column's values in a new valueName column.
+// Rows are ordered by value column, then by original row (e.g., every row for the first value column comes first).
+// If valueCols is empty, every column not named in idCols is unpivoted.
+// If varName or valueName is empty, it defaults to "variable" or "value", respectively.
+//
+// If df has multiple column levels, each level of the unpivoted column names becomes its own variable column,
+// named after the column level or (if the column level has a default name) varName plus the level number (e.g., variable_1).
+// If the unpivoted columns have different types, their values are converted to string.
+// The returned DataFrame has a single column level and default labels.
+// Returns a new DataFrame.
+func (df *DataFrame) Melt(idCols []string, valueCols []string, varName string, valueName string) *DataFrame {
+	if df.err != nil {
+		return dataFrameWithError(fmt.Errorf("melting: %v", df.err))
+	}
+	if varName == "" {
+		varName = "variable"
+	}
+	if valueName == "" {
+		valueName = "value"
+	}
+	mergedLabelsAndCols := append(df.labels, df.values...)
+	idIndex, err := indexOfContainers(idCols, mergedLabelsAndCols)
+	if err != nil {
+		return dataFrameWithError(fmt.Errorf("melting: idCols: %v", err))
+	}
+	var valueIndex []int
+	if len(valueCols) == 0 {
+		isID := make(map[int]bool, len(idIndex))
+		for _, j := range idIndex {
+			isID[j] = true
+		}
+		for k := range df.values {
+			if !isID[k+len(df.labels)] {
+				valueIndex = append(valueIndex, k)
+			}
+		}
+	} else {
+		valueIndex, err = indexOfContainers(valueCols, df.values)
+		if err != nil {
+			return dataFrameWithError(fmt.Errorf("melting: valueCols: %v", err))
+		}
+	}
+	if len(valueIndex) == 0 {
+		return dataFrameWithError(fmt.Errorf("melting: must have at least one value column"))
+	}
+	numRows := df.Len()
+	numNewRows := numRows * len(valueIndex)
+	// repeat the original rows once per value column
+	repeatIndex := make([]int, numNewRows)
+	for i := range repeatIndex {
+		repeatIndex[i] = i % numRows
+	}
+	values := make([]*valueContainer, 0, len(idIndex)+df.numColLevels()+1)
+	for _, j := range idIndex {
+		id := mergedLabelsAndCols[j].copy()
+		id.subsetRows(repeatIndex)
+		values = append(values, id)
+	}
+
+	// split each unpivoted column name into one variable column per column level
+	numLevels := df.numColLevels()
+	for l := 0; l < numLevels; l++ {
+		variable := make([]string, numNewRows)
+		for i := range variable {
+			levels := splitNameIntoNumLevels(df.values[valueIndex[i/numRows]].name, numLevels)
+			if l < len(levels) {
+				variable[i] = levels[l]
+			}
+		}
+		name := varName
+		if numLevels > 1 {
+			name = df.colLevelNames[l]
+			if strings.HasPrefix(name, optionPrefix) {
+				name = fmt.Sprintf("%s_%d", varName, l)
+			}
+		}
+		values = append(values, newValueContainer(variable, make([]bool, numNewRows), name))
+	}
+
+	// use the shared type of every value column, or string if the types differ
+	sliceType := reflect.TypeOf(df.values[valueIndex[0]].slice)
+	for _, k := range valueIndex[1:] {
+		if reflect.TypeOf(df.values[k].slice) != sliceType {
+			sliceType = reflect.TypeOf([]string{})
+			break
+		}
+	}
+	newSlice := reflect.MakeSlice(sliceType, 0, numNewRows)
+	isNull := make([]bool, 0, numNewRows)
+	for _, k := range valueIndex {
+		src := reflect.ValueOf(df.values[k].slice)
+		if src.Type() != sliceType {
+			src = reflect.ValueOf(df.values[k].copy().string().slice)
+		}
+		newSlice = reflect.AppendSlice(newSlice, src)
+		isNull = append(isNull, df.values[k].isNull...)
+	}
+	values = append(values, newValueContainer(newSlice.Interface(), isNull, valueName))
+	return &DataFrame{
+		values:        values,
+		labels:        []*valueContainer{makeDefaultLabels(0, numNewRows, true)},
+		colLevelNames: []string{"*0"},
+		name:          df.name,
+	}
+}
+
 // -- FILTERS
 
 // Filter returns a new DataFrame with only rows that satisfy all of the filters,
@@ -1577,8 +1832,16 @@ func (df *DataFrameMutator) SetRows(lambda ApplyFn, container string, rows []int
 
 // -- MERGERS
 
-// JoinOptionHow specifies how to join two Series or DataFrames. Supported options:
-// left (ie left join), right, inner (default: left).
+// JoinOptionHow specifies how to join two Series or DataFrames. Supported options (default: left):
+//
+// left: every row in the left side, with aligned values from the right side (or null if there is no match).
+// right: every row in the right side, with aligned values from the left side.
+// inner: only the rows in the left side whose keys match a row in the right side.
+// outer: every row in the left side, followed by every row in the right side whose keys do not match the left side.
+// In those rows, the left keys are filled in from the right keys and the other left values are null.
+// left_semi: only the rows in the left side whose keys match a row in the right side, without values from the right side.
+// left_anti: only the rows in the left side whose keys do not match any row in the right side, without values from the right side.
+// cross: every combination of a row in the left side with a row in the right side, ignoring keys.
 func JoinOptionHow(how string) func(*joinConfig) {
 	return func(l *joinConfig) {
 		l.how = how
@@ -1603,6 +1866,91 @@ func JoinOptionRightOn(keys []string) func(*joinConfig) {
 	}
 }
 
+// JoinOptionValidate specifies the expected cardinality of the keys in a merge,
+// and causes the merge to return an error if the actual keys violate it. Supported options:
+// 1:1 (keys are unique on both sides), 1:m (keys are unique on the left side),
+// m:1 (keys are unique on the right side), m:m (no check).
+// Default: no validation. Only used by Merge (and not in a fuzzy merge).
+func JoinOptionValidate(validate string) func(*joinConfig) {
+	return func(l *joinConfig) {
+		l.validate = validate
+	}
+}
+
+// JoinOptionBy specifies the key(s) that must match exactly in an as-of merge, in addition to the as-of key.
+// Keys must be existing container names (either label level or column names) in both Series/DataFrames.
+// Default: no exact-match keys. Only used by MergeAsOf.
+func JoinOptionBy(keys []string) func(*joinConfig) {
+	return func(l *joinConfig) {
+		l.by = keys
+	}
+}
+
+// JoinOptionDirection specifies which right row is matched with each left row in an as-of merge. Supported options:
+// backward (the last right row whose key is less than or equal to the left key),
+// forward (the first right row whose key is greater than or equal to the left key),
+// nearest (the right row whose key is closest to the left key, preferring backward if tied).
+// Default: backward. Only used by MergeAsOf.
+func JoinOptionDirection(direction string) func(*joinConfig) {
+	return func(l *joinConfig) {
+		l.direction = direction
+	}
+}
+
+// JoinOptionTolerance specifies the maximum difference between a float key and its match in an as-of merge.
+// MergeAsOf returns an error if it is supplied with DateTime keys (use JoinOptionToleranceDuration instead).
+// Default: no maximum. Only used by MergeAsOf.
+func JoinOptionTolerance(tolerance float64) func(*joinConfig) {
+	return func(l *joinConfig) {
+		l.tolerance = tolerance
+		l.hasTolerance = true
+		l.durationTolerance = false
+	}
+}
+
+// JoinOptionToleranceDuration specifies the maximum difference between a DateTime key and its match in an as-of merge.
+// MergeAsOf returns an error if it is supplied with float keys (use JoinOptionTolerance instead).
+// Default: no maximum. Only used by MergeAsOf.
+func JoinOptionToleranceDuration(tolerance time.Duration) func(*joinConfig) {
+	return func(l *joinConfig) {
+		l.tolerance = float64(tolerance)
+		l.hasTolerance = true
+		l.durationTolerance = true
+	}
+}
+
+// JoinOptionBounds specifies whether the start and end of each interval are included in an interval merge,
+// in interval notation. Supported options: [) (include start, exclude end), [] (include both),
+// (] (exclude start, include end), () (exclude both).
+// Default: [). Only used by MergeInterval.
+func JoinOptionBounds(bounds string) func(*joinConfig) {
+	return func(l *joinConfig) {
+		l.bounds = bounds
+	}
+}
+
+// JoinOptionFuzzy specifies that a merge should match the last pair of keys if the similarity of their stringified values
+// is at least threshold, rather than only identical keys. Similarity ranges from 0 (nothing in common) to 1 (identical).
+// Supported metrics:
+// levenshtein (1 minus the edit distance divided by the length of the longer key),
+// jaro_winkler (Jaro similarity, boosted by a common prefix),
+// token_set (similarity of the sets of lowercase words, ignoring order; a subset of the other key's words scores 1).
+// Default: exact matching. Only used by Merge.
+func JoinOptionFuzzy(metric string, threshold float64) func(*joinConfig) {
+	return func(l *joinConfig) {
+		l.fuzzyMetric = metric
+		l.fuzzyThreshold = threshold
+	}
+}
+
+// JoinOptionTopK specifies the maximum number of matches for each row in a fuzzy merge (see JoinOptionFuzzy).
+// Default: 1 (the best match). Only used by Merge.
+func JoinOptionTopK(k int) func(*joinConfig) {
+	return func(l *joinConfig) {
+		l.topK = k
+	}
+}
+
 // Merge joins other onto df.
 // Performs a left join unless a different join type is specified as an option.
 // If left and right keys are supplied as options, those are used as lookup keys.
@@ -1628,29 +1976,221 @@ func JoinOptionRightOn(keys []string) func(*joinConfig) {
 // bar 0   null
 // baz 1   corge
 //
+// If a row in df is aligned with multiple rows in other, the row in df is repeated once for each aligned row
+// (and likewise for a right merge), so merges may be one-to-many or many-to-many.
+// To return an error if the keys are repeated unexpectedly, supply JoinOptionValidate.
+//
+// To match keys that are similar but not identical (e.g., company names from different systems), supply JoinOptionFuzzy.
+// In a fuzzy merge (which must be left or inner), the last pair of keys is compared by similarity,
+// and any other keys must match exactly. Each row in df is aligned with its most similar row in other
+// (or, with JoinOptionTopK, up to k rows from most to least similar), and the appended columns include
+// the matched keys from other and a float64 similarity column (null if there is no match).
+// JoinOptionValidate cannot be combined with JoinOptionFuzzy.
+//
 // Finally, all container names (columns and label names) are deduplicated after the merge so that they are unique.
 // Returns a new DataFrame.
 func (df *DataFrame) Merge(other *DataFrame, options ...JoinOption) (*DataFrame, error) {
 	config := setJoinConfig(options)
-	if config.how == "inner" {
-		// inner merge should be a left merge with null rows dropped
-		options = append(options, JoinOptionHow("left"))
+	leftKeys, rightKeys, err := df.joinKeys(other, config)
+	if err != nil {
+		return nil, fmt.Errorf("merging data: %v", err)
 	}
-	lookupDF, err := df.Lookup(other, options...)
+	if config.fuzzyMetric != "" {
+		ret, err := df.mergeFuzzy(other, leftKeys, rightKeys, config)
+		if err != nil {
+			return nil, fmt.Errorf("merging data: %v", err)
+		}
+		return ret, nil
+	}
+	if config.validate != "" {
+		subsetLeft, _ := subsetContainers(append(df.labels, df.values...), leftKeys)
+		subsetRight, _ := subsetContainers(append(other.labels, other.values...), rightKeys)
+		err = validateJoinKeys(config.validate, subsetLeft, subsetRight)
+		if err != nil {
+			return nil, fmt.Errorf("merging data: %v", err)
+		}
+	}
+	// a right merge is a left merge of df onto other
+	left, right := df, other
+	how, exclude := config.how, config.rightOn
+	if how == "right" {
+		left, right = other, df
+		leftKeys, rightKeys = rightKeys, leftKeys
+		how, exclude = "left", config.leftOn
+	}
+	labels, values, rightRows, err := joinContainers(how,
+		left.labels, left.values, leftKeys,
+		append(right.labels, right.values...), rightKeys, true)
 	if err != nil {
 		return nil, fmt.Errorf("merging data: %v", err)
 	}
-	var ret *DataFrame
-	if config.how == "right" {
-		ret = other.Copy()
-	} else {
-		ret = df.Copy()
+	// semi and anti joins only filter the rows in df
+	if how != "left_semi" && how != "left_anti" {
+		for k := range right.values {
+			// skip any column whose name is also used in the lookup
+			if !containsString(exclude, right.values[k].name) {
+				values = append(values, takeRows(right.values[k], rightRows))
+			}
+		}
 	}
-	for k := range lookupDF.values {
-		ret.values = append(ret.values, lookupDF.values[k])
+	ret := &DataFrame{
+		labels:        labels,
+		values:        values,
+		name:          left.name,
+		colLevelNames: left.colLevelNames,
 	}
-	if config.how == "inner" {
-		ret.InPlace().DropNull()
+	ret.InPlace().DeduplicateNames()
+	return ret, nil
+}
+
+// MergeAsOf joins other onto df by matching each row in df with the nearest row in other by an as-of key,
+// rather than by an exact match (e.g., to align each trade with the most recent quote).
+// The as-of key is the single container supplied by JoinOptionLeftOn and JoinOptionRightOn or,
+// if none is supplied, the only label name shared by df and other.
+// If either key has a date or time type, both keys are compared as DateTime. Otherwise, both are compared as float64.
+// A key with a date or time type cannot be compared with a numeric key (but may be compared with a string key).
+//
+// By default, each row is matched with the last row in other whose key is less than or equal to its key.
+// Supply JoinOptionDirection to match forward or to the nearest key instead,
+// JoinOptionBy to also require an exact match in one or more other containers (e.g., the same ticker),
+// and JoinOptionTolerance or JoinOptionToleranceDuration to set the maximum difference between matched keys.
+// Rows with a null key are not matched.
+//
+// Every row in df is kept in its original order, and the values from the matched row in other are appended as new columns
+// (or null if there is no match), excluding the right as-of key and by keys.
+// Finally, all container names (columns and label names) are deduplicated after the merge so that they are unique.
+// Returns a new DataFrame.
+func (df *DataFrame) MergeAsOf(other *DataFrame, options ...JoinOption) (*DataFrame, error) {
+	config := setJoinConfig(options)
+	if config.direction != "backward" && config.direction != "forward" && config.direction != "nearest" {
+		return nil, fmt.Errorf("merging as of: direction: must be backward, forward, or nearest (not %v)", config.direction)
+	}
+	if config.how != "left" {
+		return nil, fmt.Errorf("merging as of: how: must be left (not %v)", config.how)
+	}
+	leftKeys, rightKeys, err := df.joinKeys(other, config)
+	if err != nil {
+		return nil, fmt.Errorf("merging as of: %v", err)
+	}
+	if len(leftKeys) != 1 {
+		return nil, fmt.Errorf("merging as of: must have exactly one as-of key (not %d)", len(leftKeys))
+	}
+	mergedLabelsAndCols := append(df.labels, df.values...)
+	otherMergedLabelsAndCols := append(other.labels, other.values...)
+	leftGroups, rightGroups, err := exactMatchGroups(config.by, mergedLabelsAndCols, otherMergedLabelsAndCols)
+	if err != nil {
+		return nil, fmt.Errorf("merging as of: by: %v", err)
+	}
+	leftKey, rightKey := mergedLabelsAndCols[leftKeys[0]], otherMergedLabelsAndCols[rightKeys[0]]
+	if (isDateTimeSlice(leftKey.slice) && isNumericSlice(rightKey.slice)) ||
+		(isNumericSlice(leftKey.slice) && isDateTimeSlice(rightKey.slice)) {
+		return nil, fmt.Errorf("merging as of: cannot compare keys of type %T and %T", leftKey.slice, rightKey.slice)
+	}
+	keys := newOrderedKeys(leftKey, rightKey)
+	if config.hasTolerance {
+		if keys[0].times != nil && !config.durationTolerance {
+			return nil, fmt.Errorf("merging as of: tolerance: DateTime keys require JoinOptionToleranceDuration")
+		}
+		if keys[0].times == nil && config.durationTolerance {
+			return nil, fmt.Errorf("merging as of: tolerance: float keys require JoinOptionTolerance")
+		}
+	}
+	rightRows := matchAsOf(keys[0], leftGroups, keys[1], rightGroups, config)
+
+	ret := df.Copy()
+	exclude := append([]string{otherMergedLabelsAndCols[rightKeys[0]].name}, config.by...)
+	for k := range other.values {
+		if !containsString(exclude, other.values[k].name) {
+			ret.values = append(ret.values, takeRows(other.values[k], rightRows))
+		}
+	}
+	ret.InPlace().DeduplicateNames()
+	return ret, nil
+}
+
+// MergeInterval joins other onto df by matching the key container in df to each row in other
+// whose interval (from the start container to the end container) contains it
+// (e.g., to map each event onto a price list with effective_from and effective_to dates).
+// key, start, and end must be existing container names (either label level or column names).
+// If any of them has a date or time type, all are compared as DateTime. Otherwise, all are compared as float64.
+// Returns an error if key is numeric and start or end has a date or time type (or vice versa).
+//
+// By default, the interval includes its start and excludes its end. Supply JoinOptionBounds to change either bound,
+// and JoinOptionBy to also require an exact match in one or more other containers.
+// Performs a left join (keeping every row in df) unless an inner join (keeping only rows with a match) is specified
+// with JoinOptionHow. If a row in df matches multiple intervals, it is repeated once for each match.
+// Rows with a null key, start, or end are not matched.
+//
+// The rows in df keep their original order, and the values from the matched rows in other
+// (excluding the by keys) are appended as new columns.
+// Finally, all container names (columns and label names) are deduplicated after the merge so that they are unique.
+// Returns a new DataFrame.
+func (df *DataFrame) MergeInterval(other *DataFrame, key string, start string, end string, options ...JoinOption) (*DataFrame, error) {
+	config := setJoinConfig(options)
+	if config.how != "left" && config.how != "inner" {
+		return nil, fmt.Errorf("merging intervals: how: must be left or inner (not %v)", config.how)
+	}
+	if len(config.bounds) != 2 || !strings.ContainsRune("[(", rune(config.bounds[0])) || !strings.ContainsRune("])", rune(config.bounds[1])) {
+		return nil, fmt.Errorf("merging intervals: bounds: must be [), [], (], or () (not %v)", config.bounds)
+	}
+	mergedLabelsAndCols := append(df.labels, df.values...)
+	otherMergedLabelsAndCols := append(other.labels, other.values...)
+	keyIndex, err := indexOfContainer(key, mergedLabelsAndCols)
+	if err != nil {
+		return nil, fmt.Errorf("merging intervals: key: %v", err)
+	}
+	startIndex, err := indexOfContainer(start, otherMergedLabelsAndCols)
+	if err != nil {
+		return nil, fmt.Errorf("merging intervals: start: %v", err)
+	}
+	endIndex, err := indexOfContainer(end, otherMergedLabelsAndCols)
+	if err != nil {
+		return nil, fmt.Errorf("merging intervals: end: %v", err)
+	}
+	keyGroups, intervalGroups, err := exactMatchGroups(config.by, mergedLabelsAndCols, otherMergedLabelsAndCols)
+	if err != nil {
+		return nil, fmt.Errorf("merging intervals: by: %v", err)
+	}
+	containers := []*valueContainer{mergedLabelsAndCols[keyIndex], otherMergedLabelsAndCols[startIndex], otherMergedLabelsAndCols[endIndex]}
+	for _, vc := range containers[1:] {
+		if (isDateTimeSlice(containers[0].slice) && isNumericSlice(vc.slice)) ||
+			(isNumericSlice(containers[0].slice) && isDateTimeSlice(vc.slice)) {
+			return nil, fmt.Errorf("merging intervals: cannot compare key of type %T to interval of type %T", containers[0].slice, vc.slice)
+		}
+	}
+	keys := newOrderedKeys(containers...)
+	matches := matchIntervals(keys[0], keyGroups, keys[1], keys[2], intervalGroups,
+		config.bounds[0] == '[', config.bounds[1] == ']')
+
+	var leftRows, rightRows []int
+	for i := range matches {
+		if len(matches[i]) == 0 && config.how == "left" {
+			leftRows = append(leftRows, i)
+			rightRows = append(rightRows, -1)
+		}
+		for _, j := range matches[i] {
+			leftRows = append(leftRows, i)
+			rightRows = append(rightRows, j)
+		}
+	}
+	labels := make([]*valueContainer, len(df.labels))
+	for j := range df.labels {
+		labels[j] = takeRows(df.labels[j], leftRows)
+	}
+	values := make([]*valueContainer, len(df.values))
+	for k := range df.values {
+		values[k] = takeRows(df.values[k], leftRows)
+	}
+	for k := range other.values {
+		if !containsString(config.by, other.values[k].name) {
+			values = append(values, takeRows(other.values[k], rightRows))
+		}
+	}
+	ret := &DataFrame{
+		labels:        labels,
+		values:        values,
+		name:          df.name,
+		colLevelNames: df.colLevelNames,
 	}
 	ret.InPlace().DeduplicateNames()
 	return ret, nil
@@ -1680,33 +2220,14 @@ func (df *DataFrame) Merge(other *DataFrame, options ...JoinOption) (*DataFrame,
 // bar (null)
 // baz corge
 //
+// For a left_semi or left_anti join, Lookup returns the aligned values for the rows that are kept
+// (which are always null for left_anti).
 // Returns a new DataFrame.
 func (df *DataFrame) Lookup(other *DataFrame, options ...JoinOption) (*DataFrame, error) {
 	config := setJoinConfig(options)
-	mergedLabelsAndCols := append(df.labels, df.values...)
-	otherMergedLabelsAndCols := append(other.labels, other.values...)
-	var leftKeys, rightKeys []int
-	var err error
-	if len(config.leftOn) == 0 || len(config.rightOn) == 0 {
-		if !(len(config.leftOn) == 0 && len(config.rightOn) == 0) {
-			return nil, fmt.Errorf("lookup: if either leftOn or rightOn is empty, both must be empty")
-		}
-	}
-	// no join keys specified? find matching labels
-	if len(config.leftOn) == 0 {
-		leftKeys, rightKeys, err = findMatchingKeysBetweenTwoContainers(df.labels, other.labels)
-		if err != nil {
-			return nil, fmt.Errorf("lookup: %v", err)
-		}
-	} else {
-		leftKeys, err = indexOfContainers(config.leftOn, mergedLabelsAndCols)
-		if err != nil {
-			return nil, fmt.Errorf("lookup: leftOn: %v", err)
-		}
-		rightKeys, err = indexOfContainers(config.rightOn, otherMergedLabelsAndCols)
-		if err != nil {
-			return nil, fmt.Errorf("lookup: rightOn: %v", err)
-		}
+	leftKeys, rightKeys, err := df.joinKeys(other, config)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %v", err)
 	}
 	ret, err := lookupDataFrame(
 		config.how, df.name, df.colLevelNames,
@@ -1718,6 +2239,33 @@ func (df *DataFrame) Lookup(other *DataFrame, options ...JoinOption) (*DataFrame
 	return ret, nil
 }
 
+// joinKeys returns the positions of the key containers in df and other (within their merged labels and columns)
+// for a join configured by config. If no keys are configured, shared label names are used as keys.
+// A cross join does not use keys.
+func (df *DataFrame) joinKeys(other *DataFrame, config *joinConfig) ([]int, []int, error) {
+	if len(config.leftOn) == 0 || len(config.rightOn) == 0 {
+		if !(len(config.leftOn) == 0 && len(config.rightOn) == 0) {
+			return nil, nil, fmt.Errorf("if either leftOn or rightOn is empty, both must be empty")
+		}
+	}
+	if config.how == "cross" {
+		return nil, nil, nil
+	}
+	// no join keys specified? find matching labels
+	if len(config.leftOn) == 0 {
+		return findMatchingKeysBetweenTwoContainers(df.labels, other.labels)
+	}
+	leftKeys, err := indexOfContainers(config.leftOn, append(df.labels, df.values...))
+	if err != nil {
+		return nil, nil, fmt.Errorf("leftOn: %v", err)
+	}
+	rightKeys, err := indexOfContainers(config.rightOn, append(other.labels, other.values...))
+	if err != nil {
+		return nil, nil, fmt.Errorf("rightOn: %v", err)
+	}
+	return leftKeys, rightKeys, nil
+}
+
 // -- SORTERS
 
 // Sort sorts the values by zero or more Sorter specifications.
@@ -1830,9 +2378,10 @@ func (df *DataFrame) PivotTable(labels, columns, values, aggFunc string) (*DataF
 
 // dropColLevel drops a column level inplace by changing the name in every column container
 func (df *DataFrame) dropColLevel(level int) *DataFrame {
+	numLevels := df.numColLevels()
 	df.colLevelNames = append(df.colLevelNames[:level], df.colLevelNames[level+1:]...)
 	for k := range df.values {
-		priorNames := splitNameIntoLevels(df.values[k].name)
+		priorNames := splitNameIntoNumLevels(df.values[k].name, numLevels)
 		newNames := append(priorNames[:level], priorNames[level+1:]...)
 		df.values[k].name = joinLevelsIntoName(newNames)
 	}
@@ -2006,7 +2555,7 @@ func (df *DataFrame) Reduce(name string, lambda ReduceFn) (*Series, error) {
 	// must deduce output type from first result
 	firstResult, _ := lambda(df.values[0].slice, df.values[0].isNull)
 	firstType := reflect.TypeOf(firstResult)
-	sampleLabel := splitNameIntoLevels(df.values[0].name)
+	sampleLabel := splitNameIntoNumLevels(df.values[0].name, df.numColLevels())
 
 	retVals := reflect.MakeSlice(reflect.SliceOf(firstType), df.NumColumns(), df.NumColumns())
 	retNulls := make([]bool, df.NumColumns())
@@ -2026,7 +2575,7 @@ func (df *DataFrame) Reduce(name string, lambda ReduceFn) (*Series, error) {
 		if null {
 			retNulls[i] = null
 		}
-		levels := splitNameIntoLevels(df.values[i].name)
+		levels := splitNameIntoNumLevels(df.values[i].name, df.numColLevels())
 		for j := range levels {
 			stringifiedLevels[j][i] = levels[j]
 		}
@@ -127,6 +127,16 @@ func TestDataFrame_Cast(t *testing.T) {
 			&DataFrame{
 				err: fmt.Errorf("type casting: name (corge) not found")},
 		},
+		{"fail - cannot be represented as int64", fields{
+			values: []*valueContainer{
+				{slice: []float64{1, 1.5}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+			labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+			name:          "qux"},
+			args{map[string]DType{"foo": Int64}},
+			&DataFrame{
+				err: fmt.Errorf("type casting: foo: row 1: 1.5 cannot be represented as int64")},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2320,8 +2330,7 @@ func TestDataFrame_Merge(t *testing.T) {
 					{slice: []string{"", "c"}, isNull: []bool{true, false}, id: mockID, name: "bar"},
 				},
 				labels: []*valueContainer{
-					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0",
-						cache: []string{"0", "1"}},
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"},
 				},
 				name:          "foo",
 				colLevelNames: []string{"*0"}},
@@ -2346,9 +2355,7 @@ func TestDataFrame_Merge(t *testing.T) {
 					{slice: []string{"b"}, isNull: []bool{false}, id: mockID, name: "foo"},
 				},
 				labels: []*valueContainer{
-					{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0",
-						cache: []string{"1"},
-					}},
+					{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0"}},
 				name:          "bar",
 				colLevelNames: []string{"*1"}},
 			false,
@@ -2377,6 +2384,291 @@ func TestDataFrame_Merge(t *testing.T) {
 				colLevelNames: []string{"*0"}},
 			false,
 		},
+		{"inner merge - keeps null values",
+			fields{values: []*valueContainer{
+				{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				name:          "foo",
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values:        []*valueContainer{{slice: []string{""}, isNull: []bool{true}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionHow("inner")},
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"b"}, isNull: []bool{false}, id: mockID, name: "foo"},
+					{slice: []string{""}, isNull: []bool{true}, id: mockID, name: "bar"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				name:          "foo",
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"outer merge - column keys",
+			fields{values: []*valueContainer{
+				{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "key"},
+				{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"b", "c"}, isNull: []bool{false, false}, id: mockID, name: "key"},
+					{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionHow("outer"), JoinOptionLeftOn([]string{"key"}), JoinOptionRightOn([]string{"key"})},
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"a", "b", "c"}, isNull: []bool{false, false, false}, id: mockID, name: "key"},
+					{slice: []float64{1, 2, 0}, isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+					{slice: []string{"", "x", "y"}, isNull: []bool{true, false, false}, id: mockID, name: "bar"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 0}, isNull: []bool{false, false, true}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"left semi merge",
+			fields{values: []*valueContainer{
+				{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "key"},
+				{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"b", "b"}, isNull: []bool{false, false}, id: mockID, name: "key"},
+					{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionHow("left_semi"), JoinOptionLeftOn([]string{"key"}), JoinOptionRightOn([]string{"key"})},
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"b"}, isNull: []bool{false}, id: mockID, name: "key"},
+					{slice: []float64{2}, isNull: []bool{false}, id: mockID, name: "foo"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"left anti merge",
+			fields{values: []*valueContainer{
+				{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "key"},
+				{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"b", "c"}, isNull: []bool{false, false}, id: mockID, name: "key"},
+					{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionHow("left_anti"), JoinOptionLeftOn([]string{"key"}), JoinOptionRightOn([]string{"key"})},
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "key"},
+					{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"cross merge",
+			fields{values: []*valueContainer{
+				{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values:        []*valueContainer{{slice: []string{"c", "d"}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{5, 6}, isNull: []bool{false, false}, id: mockID, name: "corge"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionHow("cross")},
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"a", "a", "b", "b"}, isNull: []bool{false, false, false, false}, id: mockID, name: "foo"},
+					{slice: []string{"c", "d", "c", "d"}, isNull: []bool{false, false, false, false}, id: mockID, name: "bar"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0, 0, 1, 1}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"left merge - one to many",
+			fields{values: []*valueContainer{
+				{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values:        []*valueContainer{{slice: []string{"c", "d"}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{1, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionValidate("1:m")},
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"a", "b", "b"}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+					{slice: []string{"", "c", "d"}, isNull: []bool{true, false, false}, id: mockID, name: "bar"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 1}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"inner merge - many to many",
+			fields{values: []*valueContainer{
+				{slice: []string{"x", "x", "y"}, isNull: []bool{false, false, false}, id: mockID, name: "key"},
+				{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"x", "x"}, isNull: []bool{false, false}, id: mockID, name: "key"},
+					{slice: []string{"c", "d"}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionHow("inner"), JoinOptionLeftOn([]string{"key"}), JoinOptionRightOn([]string{"key"}),
+					JoinOptionValidate("m:m")},
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"x", "x", "x", "x"}, isNull: []bool{false, false, false, false}, id: mockID, name: "key"},
+					{slice: []float64{1, 1, 2, 2}, isNull: []bool{false, false, false, false}, id: mockID, name: "foo"},
+					{slice: []string{"c", "d", "c", "d"}, isNull: []bool{false, false, false, false}, id: mockID, name: "bar"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0, 0, 1, 1}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"fail - validate",
+			fields{values: []*valueContainer{
+				{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values:        []*valueContainer{{slice: []string{"c", "d"}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{1, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionValidate("1:1")}},
+			nil, true,
+		},
+		{"fuzzy merge",
+			fields{values: []*valueContainer{
+				{slice: []string{"Acme Corp", "Globex", "Initech"}, isNull: []bool{false, false, false}, id: mockID, name: "vendor"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"umbrella", "globex corporation", "acme corp inc"}, isNull: []bool{false, false, false}, id: mockID, name: "name"},
+					{slice: []int{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "id"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionLeftOn([]string{"vendor"}), JoinOptionRightOn([]string{"name"}),
+					JoinOptionFuzzy("token_set", 0.8)},
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"Acme Corp", "Globex", "Initech"}, isNull: []bool{false, false, false}, id: mockID, name: "vendor"},
+					{slice: []string{"acme corp inc", "globex corporation", ""}, isNull: []bool{false, false, true}, id: mockID, name: "name"},
+					{slice: []int{3, 2, 0}, isNull: []bool{false, false, true}, id: mockID, name: "id"},
+					{slice: []float64{1, 1, 0}, isNull: []bool{false, false, true}, id: mockID, name: "similarity"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"fuzzy merge - inner, top k, label key",
+			fields{values: []*valueContainer{
+				{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []string{"abc", "xyz"}, isNull: []bool{false, false}, id: mockID, name: "key"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values:        []*valueContainer{{slice: []int{10, 20, 30}, isNull: []bool{false, false, false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []string{"abd", "abc", "qrs"}, isNull: []bool{false, false, false}, id: mockID, name: "key"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionHow("inner"), JoinOptionFuzzy("levenshtein", 0.5), JoinOptionTopK(2)},
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []int{1, 1}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+					{slice: []string{"abc", "abd"}, isNull: []bool{false, false}, id: mockID, name: "key_1"},
+					{slice: []int{20, 10}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+					{slice: []float64{1, levenshteinSimilarity("abc", "abd")}, isNull: []bool{false, false}, id: mockID, name: "similarity"},
+				},
+				labels: []*valueContainer{
+					{slice: []string{"abc", "abc"}, isNull: []bool{false, false}, id: mockID, name: "key"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"fail - fuzzy metric",
+			fields{values: []*valueContainer{
+				{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values:        []*valueContainer{{slice: []string{"c"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionFuzzy("soundex", 0.5)}},
+			nil, true,
+		},
+		{"fail - fuzzy how",
+			fields{values: []*valueContainer{
+				{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values:        []*valueContainer{{slice: []string{"c"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionFuzzy("levenshtein", 0.5), JoinOptionHow("outer")}},
+			nil, true,
+		},
+		{"fail - fuzzy with validate",
+			fields{values: []*valueContainer{
+				{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values:        []*valueContainer{{slice: []string{"c"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionFuzzy("levenshtein", 0.5), JoinOptionValidate("1:1")}},
+			nil, true,
+		},
+		{"fail - fuzzy top k",
+			fields{values: []*valueContainer{
+				{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values:        []*valueContainer{{slice: []string{"c"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionFuzzy("levenshtein", 0.5), JoinOptionTopK(0)}},
+			nil, true,
+		},
+		{"fail - unsupported how",
+			fields{values: []*valueContainer{
+				{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values:        []*valueContainer{{slice: []string{"c"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionHow("other")}},
+			nil, true,
+		},
 		{"fail - no shared merge key ",
 			fields{values: []*valueContainer{
 				{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
@@ -2411,6 +2703,265 @@ func TestDataFrame_Merge(t *testing.T) {
 	}
 }
 
+func TestDataFrame_MergeAsOf(t *testing.T) {
+	left := func() *DataFrame {
+		return &DataFrame{
+			values: []*valueContainer{
+				{slice: []float64{1, 5, 10}, isNull: []bool{false, false, false}, id: mockID, name: "t"},
+				{slice: []string{"a", "a", "b"}, isNull: []bool{false, false, false}, id: mockID, name: "sym"}},
+			labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+			name:          "foo",
+		}
+	}
+	right := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{0, 4, 6, 9}, isNull: []bool{false, false, false, false}, id: mockID, name: "t"},
+			{slice: []string{"q0", "q1", "q2", "q3"}, isNull: []bool{false, false, false, false}, id: mockID, name: "quote"}},
+		labels:        []*valueContainer{{slice: []int{0, 1, 2, 3}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"},
+	}
+	rightBy := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{0, 4, 6, 9}, isNull: []bool{false, false, false, false}, id: mockID, name: "t"},
+			{slice: []string{"a", "b", "a", "b"}, isNull: []bool{false, false, false, false}, id: mockID, name: "sym"},
+			{slice: []string{"q0", "q1", "q2", "q3"}, isNull: []bool{false, false, false, false}, id: mockID, name: "quote"}},
+		labels:        []*valueContainer{{slice: []int{0, 1, 2, 3}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"},
+	}
+	on := []JoinOption{JoinOptionLeftOn([]string{"t"}), JoinOptionRightOn([]string{"t"})}
+	withQuotes := func(quotes []string, isNull []bool) *DataFrame {
+		df := left()
+		df.values = append(df.values, &valueContainer{slice: quotes, isNull: isNull, id: mockID, name: "quote"})
+		return df
+	}
+	type args struct {
+		other   *DataFrame
+		options []JoinOption
+	}
+	tests := []struct {
+		name    string
+		df      *DataFrame
+		args    args
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"backward", left(), args{right, on},
+			withQuotes([]string{"q0", "q1", "q3"}, []bool{false, false, false}), false},
+		{"forward with tolerance", left(), args{right, append(on, JoinOptionDirection("forward"), JoinOptionTolerance(1))},
+			withQuotes([]string{"", "q2", ""}, []bool{true, false, true}), false},
+		{"nearest - ties prefer backward", left(), args{right, append(on, JoinOptionDirection("nearest"))},
+			withQuotes([]string{"q0", "q1", "q3"}, []bool{false, false, false}), false},
+		{"by", left(), args{rightBy, append(on, JoinOptionBy([]string{"sym"}))},
+			withQuotes([]string{"q0", "q0", "q3"}, []bool{false, false, false}), false},
+		{"datetime label with tolerance",
+			&DataFrame{
+				values: []*valueContainer{{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels: []*valueContainer{{slice: []time.Time{
+					time.Date(2020, 1, 1, 10, 30, 0, 0, time.UTC), time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)},
+					isNull: []bool{false, false}, id: mockID, name: "date"}},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values: []*valueContainer{{slice: []string{"c1", "c2"}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{{slice: []string{"2020-01-01T09:00:00Z", "2020-01-01T11:30:00Z"},
+					isNull: []bool{false, false}, id: mockID, name: "date"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionToleranceDuration(time.Hour)}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+					{slice: []string{"", "c2"}, isNull: []bool{true, false}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{{slice: []time.Time{
+					time.Date(2020, 1, 1, 10, 30, 0, 0, time.UTC), time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)},
+					isNull: []bool{false, false}, id: mockID, name: "date"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - float and datetime keys", left(), args{&DataFrame{
+			values: []*valueContainer{
+				{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, isNull: []bool{false}, id: mockID, name: "t"}},
+			labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"}}, on}, nil, true},
+		{"fail - float tolerance with datetime keys", &DataFrame{
+			values: []*valueContainer{{slice: []string{"x"}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels: []*valueContainer{{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+				isNull: []bool{false}, id: mockID, name: "date"}},
+			colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values: []*valueContainer{{slice: []string{"c1"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+					isNull: []bool{false}, id: mockID, name: "date"}},
+				colLevelNames: []string{"*0"}},
+				[]JoinOption{JoinOptionTolerance(3600)}},
+			nil, true},
+		{"fail - duration tolerance with float keys", left(), args{right, append(on, JoinOptionToleranceDuration(time.Second))}, nil, true},
+		{"fail - direction", left(), args{right, append(on, JoinOptionDirection("sideways"))}, nil, true},
+		{"fail - how", left(), args{right, append(on, JoinOptionHow("inner"))}, nil, true},
+		{"fail - multiple keys", left(), args{rightBy,
+			[]JoinOption{JoinOptionLeftOn([]string{"t", "sym"}), JoinOptionRightOn([]string{"t", "sym"})}}, nil, true},
+		{"fail - by", left(), args{right, append(on, JoinOptionBy([]string{"sym"}))}, nil, true},
+		{"fail - no shared key", left(), args{&DataFrame{
+			values:        []*valueContainer{{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "bar"}},
+			labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "corge"}},
+			colLevelNames: []string{"*0"}}, nil}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.df.MergeAsOf(tt.args.other, tt.args.options...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DataFrame.MergeAsOf() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("DataFrame.MergeAsOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataFrame_MergeInterval(t *testing.T) {
+	events := func(t []float64, isNull []bool) *DataFrame {
+		return &DataFrame{
+			values:        []*valueContainer{{slice: t, isNull: isNull, id: mockID, name: "t"}},
+			labels:        []*valueContainer{makeDefaultLabels(0, len(t), true)},
+			colLevelNames: []string{"*0"},
+			name:          "foo",
+		}
+	}
+	prices := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{0, 5, 8}, isNull: []bool{false, false, false}, id: mockID, name: "from"},
+			{slice: []float64{5, 10, 12}, isNull: []bool{false, false, false}, id: mockID, name: "to"},
+			{slice: []string{"p1", "p2", "p3"}, isNull: []bool{false, false, false}, id: mockID, name: "price"}},
+		labels:        []*valueContainer{makeDefaultLabels(0, 3, true)},
+		colLevelNames: []string{"*0"},
+	}
+	type args struct {
+		other   *DataFrame
+		key     string
+		start   string
+		end     string
+		options []JoinOption
+	}
+	tests := []struct {
+		name    string
+		df      *DataFrame
+		args    args
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"default bounds", events([]float64{1, 5, 10}, []bool{false, false, false}),
+			args{prices, "t", "from", "to", nil},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 5, 10}, isNull: []bool{false, false, false}, id: mockID, name: "t"},
+					{slice: []float64{0, 5, 8}, isNull: []bool{false, false, false}, id: mockID, name: "from"},
+					{slice: []float64{5, 10, 12}, isNull: []bool{false, false, false}, id: mockID, name: "to"},
+					{slice: []string{"p1", "p2", "p3"}, isNull: []bool{false, false, false}, id: mockID, name: "price"}},
+				labels:        []*valueContainer{makeDefaultLabels(0, 3, true)},
+				colLevelNames: []string{"*0"},
+				name:          "foo",
+			}, false},
+		{"closed bounds - multiple matches", events([]float64{1, 5, 10}, []bool{false, false, false}),
+			args{prices, "t", "from", "to", []JoinOption{JoinOptionBounds("[]")}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 5, 5, 10, 10}, isNull: []bool{false, false, false, false, false}, id: mockID, name: "t"},
+					{slice: []float64{0, 0, 5, 5, 8}, isNull: []bool{false, false, false, false, false}, id: mockID, name: "from"},
+					{slice: []float64{5, 5, 10, 10, 12}, isNull: []bool{false, false, false, false, false}, id: mockID, name: "to"},
+					{slice: []string{"p1", "p1", "p2", "p2", "p3"}, isNull: []bool{false, false, false, false, false}, id: mockID, name: "price"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 1, 2, 2}, isNull: []bool{false, false, false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"},
+				name:          "foo",
+			}, false},
+		{"left - no match and null key", events([]float64{1, 20, 0}, []bool{false, false, true}),
+			args{prices, "t", "from", "to", []JoinOption{JoinOptionBounds("()")}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 20, 0}, isNull: []bool{false, false, true}, id: mockID, name: "t"},
+					{slice: []float64{0, 0, 0}, isNull: []bool{false, true, true}, id: mockID, name: "from"},
+					{slice: []float64{5, 0, 0}, isNull: []bool{false, true, true}, id: mockID, name: "to"},
+					{slice: []string{"p1", "", ""}, isNull: []bool{false, true, true}, id: mockID, name: "price"}},
+				labels:        []*valueContainer{makeDefaultLabels(0, 3, true)},
+				colLevelNames: []string{"*0"},
+				name:          "foo",
+			}, false},
+		{"inner", events([]float64{20, 11}, []bool{false, false}),
+			args{prices, "t", "from", "to", []JoinOption{JoinOptionHow("inner")}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{11}, isNull: []bool{false}, id: mockID, name: "t"},
+					{slice: []float64{8}, isNull: []bool{false}, id: mockID, name: "from"},
+					{slice: []float64{12}, isNull: []bool{false}, id: mockID, name: "to"},
+					{slice: []string{"p3"}, isNull: []bool{false}, id: mockID, name: "price"}},
+				labels: []*valueContainer{
+					{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"},
+				name:          "foo",
+			}, false},
+		{"by and datetime",
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)},
+						isNull: []bool{false, false}, id: mockID, name: "date"},
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "sku"}},
+				labels:        []*valueContainer{makeDefaultLabels(0, 2, true)},
+				colLevelNames: []string{"*0"}},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"2020-01-01", "2020-01-01"}, isNull: []bool{false, false}, id: mockID, name: "effective_from"},
+					{slice: []string{"2020-02-01", "2020-02-01"}, isNull: []bool{false, false}, id: mockID, name: "effective_to"},
+					{slice: []string{"b", "a"}, isNull: []bool{false, false}, id: mockID, name: "sku"},
+					{slice: []float64{2, 1}, isNull: []bool{false, false}, id: mockID, name: "price"}},
+				labels:        []*valueContainer{makeDefaultLabels(0, 2, true)},
+				colLevelNames: []string{"*0"}},
+				"date", "effective_from", "effective_to", []JoinOption{JoinOptionBy([]string{"sku"})}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)},
+						isNull: []bool{false, false}, id: mockID, name: "date"},
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "sku"},
+					{slice: []string{"2020-01-01", "2020-01-01"}, isNull: []bool{false, false}, id: mockID, name: "effective_from"},
+					{slice: []string{"2020-02-01", "2020-02-01"}, isNull: []bool{false, false}, id: mockID, name: "effective_to"},
+					{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "price"}},
+				labels:        []*valueContainer{makeDefaultLabels(0, 2, true)},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - how", events([]float64{1}, []bool{false}),
+			args{prices, "t", "from", "to", []JoinOption{JoinOptionHow("outer")}}, nil, true},
+		{"fail - bounds", events([]float64{1}, []bool{false}),
+			args{prices, "t", "from", "to", []JoinOption{JoinOptionBounds("[[")}}, nil, true},
+		{"fail - key", events([]float64{1}, []bool{false}),
+			args{prices, "corge", "from", "to", nil}, nil, true},
+		{"fail - start", events([]float64{1}, []bool{false}),
+			args{prices, "t", "corge", "to", nil}, nil, true},
+		{"fail - end", events([]float64{1}, []bool{false}),
+			args{prices, "t", "from", "corge", nil}, nil, true},
+		{"fail - by", events([]float64{1}, []bool{false}),
+			args{prices, "t", "from", "to", []JoinOption{JoinOptionBy([]string{"corge"})}}, nil, true},
+		{"fail - float key and datetime interval", events([]float64{1}, []bool{false}),
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, isNull: []bool{false}, id: mockID, name: "from"},
+					{slice: []time.Time{time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)}, isNull: []bool{false}, id: mockID, name: "to"}},
+				labels:        []*valueContainer{makeDefaultLabels(0, 1, true)},
+				colLevelNames: []string{"*0"}},
+				"t", "from", "to", nil}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.df.MergeInterval(tt.args.other, tt.args.key, tt.args.start, tt.args.end, tt.args.options...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DataFrame.MergeInterval() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("DataFrame.MergeInterval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDataFrame_Lookup(t *testing.T) {
 	type fields struct {
 		labels        []*valueContainer
@@ -2679,8 +3230,7 @@ func TestDataFrame_GroupBy(t *testing.T) {
 				df: &DataFrame{
 					values: []*valueContainer{{slice: []float64{1, 2}, isNull: []bool{false, false}}},
 					labels: []*valueContainer{
-						{slice: []int{0, 0, 1, 2}, isNull: []bool{false, false, false, false}, id: mockID, name: "a",
-							cache: []string{"0", "0", "1", "2"}},
+						{slice: []int{0, 0, 1, 2}, isNull: []bool{false, false, false, false}, id: mockID, name: "a"},
 						{slice: []string{"foo", "foo", "foo", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "b",
 							cache: []string{"foo", "foo", "foo", "bar"}},
 					}},
@@ -2837,6 +3387,283 @@ func TestDataFrame_PromoteToColLevel(t *testing.T) {
 	}
 }
 
+func TestDataFrame_Stack(t *testing.T) {
+	type fields struct {
+		labels        []*valueContainer
+		values        []*valueContainer
+		name          string
+		err           error
+		colLevelNames []string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   *DataFrame
+	}{
+		{"missing combination is null", fields{
+			values: []*valueContainer{
+				{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo|2018"},
+				{slice: []float64{3, 4}, isNull: []bool{false, true}, id: mockID, name: "foo|2019"},
+				{slice: []float64{5, 6}, isNull: []bool{false, false}, id: mockID, name: "bar|2019"}},
+			labels: []*valueContainer{
+				{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0", "year"},
+			name:          "baz",
+		},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 3, 2, 4}, isNull: []bool{false, false, false, true}, id: mockID, name: "foo"},
+					{slice: []float64{0, 5, 0, 6}, isNull: []bool{true, false, true, false}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{
+					{slice: []string{"a", "a", "b", "b"}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"},
+					{slice: []string{"2018", "2019", "2018", "2019"}, isNull: []bool{false, false, false, false}, id: mockID, name: "year"}},
+				colLevelNames: []string{"*0"},
+				name:          "baz",
+			}},
+		{"three levels, mixed types", fields{
+			values: []*valueContainer{
+				{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "a|foo|x"},
+				{slice: []string{"qux"}, isNull: []bool{false}, id: mockID, name: "a|foo|y"}},
+			labels: []*valueContainer{
+				{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0", "*1", "*2"},
+		},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"1", "qux"}, isNull: []bool{false, false}, id: mockID, name: "a|foo"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 0}, isNull: []bool{false, false}, id: mockID, name: "*0"},
+					{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "*2"}},
+				colLevelNames: []string{"*0", "*1"},
+			}},
+		{"fail - one column level", fields{
+			values: []*valueContainer{
+				{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels: []*valueContainer{
+				{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		},
+			&DataFrame{err: errors.New("stacking: must have at least two column levels")}},
+		{"fail - error", fields{err: errors.New("foo")},
+			&DataFrame{err: errors.New("stacking: foo")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := &DataFrame{
+				labels:        tt.fields.labels,
+				values:        tt.fields.values,
+				name:          tt.fields.name,
+				err:           tt.fields.err,
+				colLevelNames: tt.fields.colLevelNames,
+			}
+			if got := df.Stack(); !EqualDataFrames(got, tt.want) {
+				t.Errorf("DataFrame.Stack() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataFrame_Unstack(t *testing.T) {
+	type fields struct {
+		labels        []*valueContainer
+		values        []*valueContainer
+		name          string
+		err           error
+		colLevelNames []string
+	}
+	type args struct {
+		name string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *DataFrame
+	}{
+		{"missing combination is null", fields{
+			values: []*valueContainer{
+				{slice: []float64{1, 3, 2}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+				{slice: []float64{5, 6, 7}, isNull: []bool{false, false, false}, id: mockID, name: "bar"}},
+			labels: []*valueContainer{
+				{slice: []string{"a", "a", "b"}, isNull: []bool{false, false, false}, id: mockID, name: "*0"},
+				{slice: []string{"2018", "2019", "2018"}, isNull: []bool{false, false, false}, id: mockID, name: "year"}},
+			colLevelNames: []string{"*0"},
+			name:          "baz",
+		}, args{"year"},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo|2018"},
+					{slice: []float64{3, 0}, isNull: []bool{false, true}, id: mockID, name: "foo|2019"},
+					{slice: []float64{5, 7}, isNull: []bool{false, false}, id: mockID, name: "bar|2018"},
+					{slice: []float64{6, 0}, isNull: []bool{false, true}, id: mockID, name: "bar|2019"}},
+				labels: []*valueContainer{
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "year"},
+				name:          "baz",
+			}},
+		{"fail - column", fields{
+			values: []*valueContainer{
+				{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"},
+				{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "bar"}},
+			labels: []*valueContainer{
+				{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"},
+				{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*1"}},
+			colLevelNames: []string{"*0"},
+		}, args{"foo"},
+			&DataFrame{err: errors.New("unstacking: label level name (foo) not found")}},
+		{"fail - only label level", fields{
+			values: []*valueContainer{
+				{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels: []*valueContainer{
+				{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		}, args{"*0"},
+			&DataFrame{err: errors.New("unstacking: promoting to column level: cannot stack only label level")}},
+		{"fail - error", fields{err: errors.New("foo")}, args{"*0"},
+			&DataFrame{err: errors.New("unstacking: foo")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := &DataFrame{
+				labels:        tt.fields.labels,
+				values:        tt.fields.values,
+				name:          tt.fields.name,
+				err:           tt.fields.err,
+				colLevelNames: tt.fields.colLevelNames,
+			}
+			got := df.Unstack(tt.args.name)
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("DataFrame.Unstack() = %v, want %v", got, tt.want)
+			}
+			// Stack is the inverse of Unstack (up to row order and the type of the label level)
+			if tt.want.err == nil {
+				if restacked := got.Stack(); restacked.Len() != 4 || restacked.numColLevels() != 1 {
+					t.Errorf("DataFrame.Unstack() -> Stack() = %v", restacked)
+				}
+			}
+		})
+	}
+}
+
+func TestDataFrame_Melt(t *testing.T) {
+	type fields struct {
+		labels        []*valueContainer
+		values        []*valueContainer
+		name          string
+		err           error
+		colLevelNames []string
+	}
+	type args struct {
+		idCols    []string
+		valueCols []string
+		varName   string
+		valueName string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *DataFrame
+	}{
+		{"default value columns", fields{
+			values: []*valueContainer{
+				{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "id"},
+				{slice: []float64{1, 2}, isNull: []bool{false, true}, id: mockID, name: "jan"},
+				{slice: []float64{3, 4}, isNull: []bool{false, false}, id: mockID, name: "feb"}},
+			labels: []*valueContainer{
+				{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+			name:          "baz",
+		}, args{[]string{"id"}, nil, "", ""},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"a", "b", "a", "b"}, isNull: []bool{false, false, false, false}, id: mockID, name: "id"},
+					{slice: []string{"jan", "jan", "feb", "feb"}, isNull: []bool{false, false, false, false}, id: mockID, name: "variable"},
+					{slice: []float64{1, 2, 3, 4}, isNull: []bool{false, true, false, false}, id: mockID, name: "value"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 2, 3}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"},
+				name:          "baz",
+			}},
+		{"label level as id, selected value columns, mixed types", fields{
+			values: []*valueContainer{
+				{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"},
+				{slice: []string{"x"}, isNull: []bool{false}, id: mockID, name: "bar"},
+				{slice: []float64{5}, isNull: []bool{false}, id: mockID, name: "qux"}},
+			labels: []*valueContainer{
+				{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "key"}},
+			colLevelNames: []string{"*0"},
+		}, args{[]string{"key"}, []string{"foo", "bar"}, "month", "amount"},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"a", "a"}, isNull: []bool{false, false}, id: mockID, name: "key"},
+					{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "month"},
+					{slice: []string{"1", "x"}, isNull: []bool{false, false}, id: mockID, name: "amount"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"},
+			}},
+		{"multiple column levels", fields{
+			values: []*valueContainer{
+				{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "2019|jan"},
+				{slice: []float64{2}, isNull: []bool{false}, id: mockID, name: "2020|jan"}},
+			labels: []*valueContainer{
+				{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"year", "*1"},
+		}, args{nil, nil, "", ""},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"2019", "2020"}, isNull: []bool{false, false}, id: mockID, name: "year"},
+					{slice: []string{"jan", "jan"}, isNull: []bool{false, false}, id: mockID, name: "variable_1"},
+					{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "value"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"},
+			}},
+		{"fail - bad id", fields{
+			values: []*valueContainer{
+				{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels: []*valueContainer{
+				{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		}, args{[]string{"corge"}, nil, "", ""},
+			&DataFrame{err: errors.New("melting: idCols: name (corge) not found")}},
+		{"fail - bad value column", fields{
+			values: []*valueContainer{
+				{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels: []*valueContainer{
+				{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		}, args{nil, []string{"*0"}, "", ""},
+			&DataFrame{err: errors.New("melting: valueCols: name (*0) not found")}},
+		{"fail - no value columns", fields{
+			values: []*valueContainer{
+				{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels: []*valueContainer{
+				{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		}, args{[]string{"foo"}, nil, "", ""},
+			&DataFrame{err: errors.New("melting: must have at least one value column")}},
+		{"fail - error", fields{err: errors.New("foo")}, args{nil, nil, "", ""},
+			&DataFrame{err: errors.New("melting: foo")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := &DataFrame{
+				labels:        tt.fields.labels,
+				values:        tt.fields.values,
+				name:          tt.fields.name,
+				err:           tt.fields.err,
+				colLevelNames: tt.fields.colLevelNames,
+			}
+			got := df.Melt(tt.args.idCols, tt.args.valueCols, tt.args.varName, tt.args.valueName)
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("DataFrame.Melt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDataFrame_PivotTable(t *testing.T) {
 	type fields struct {
 		labels        []*valueContainer
@@ -2999,6 +3826,26 @@ func TestDataFrame_PivotTable(t *testing.T) {
 				name:          "max_foo"},
 			false,
 		},
+		{"values contain level separator", fields{
+			values: []*valueContainer{
+				{slice: []float64{1, 2, 3, 4}, isNull: []bool{false, false, false, false}, id: mockID, name: "amount"},
+				{slice: []string{"a|b", "a|b", `c\`, `c\`}, isNull: []bool{false, false, false, false}, id: mockID, name: "kind"},
+				{slice: []string{"A", "B", "B", "B"}, isNull: []bool{false, false, false, false}, id: mockID, name: "type"}},
+			labels: []*valueContainer{
+				{slice: []int{0, 1, 2, 3}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+			name:          "foo"},
+			args{labels: "type", columns: "kind", values: "amount", aggFn: "sum"},
+			&DataFrame{values: []*valueContainer{
+				{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "a|b"},
+				{slice: []float64{0, 7}, isNull: []bool{true, false}, id: mockID, name: `c\`},
+			},
+				labels: []*valueContainer{
+					{slice: []string{"A", "B"}, isNull: []bool{false, false}, id: mockID, name: "type"}},
+				colLevelNames: []string{"kind"},
+				name:          "sum_foo"},
+			false,
+		},
 		{"fail - no matching index level", fields{
 			values: []*valueContainer{
 				{slice: []float64{1, 2, 3, 4}, isNull: []bool{false, false, false, false}, id: mockID, name: "amount"},
@@ -3061,6 +3908,27 @@ func TestDataFrame_PivotTable(t *testing.T) {
 	}
 }
 
+func TestDataFrame_PivotTable_levelSeparator(t *testing.T) {
+	df := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "v"},
+			{slice: []string{"a|b", "c"}, isNull: []bool{false, false}, id: mockID, name: "kind"}},
+		labels: []*valueContainer{
+			{slice: []string{"foo", "foo"}, isNull: []bool{false, false}, id: mockID, name: "type"}},
+		colLevelNames: []string{"*0"},
+	}
+	got, err := df.PivotTable("type", "kind", "v", "sum")
+	if err != nil {
+		t.Fatalf("DataFrame.PivotTable() error = %v", err)
+	}
+	if want := []string{"a|b", "c"}; !reflect.DeepEqual(got.ListColNames(), want) {
+		t.Errorf("DataFrame.PivotTable() column names = %v, want %v", got.ListColNames(), want)
+	}
+	if s := got.String(); !strings.Contains(s, "a|b") {
+		t.Errorf("DataFrame.PivotTable().String() = %v, want header a|b", s)
+	}
+}
+
 func TestDataFrame_dropColLevel(t *testing.T) {
 	type fields struct {
 		labels        []*valueContainer
@@ -0,0 +1,196 @@
+package tada
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// -- fixed-width text
+
+// FixedWidthReader reads fixed-width text into a DataFrame.
+type FixedWidthReader struct {
+	Columns []FixedWidthColumn
+	// SkipRows is the number of lines at the start of the text (e.g., headers) that are not read.
+	SkipRows int
+	// TrimSpace removes leading and trailing white space from each value.
+	TrimSpace   bool
+	LabelLevels int
+	Name        string
+	r           io.Reader
+}
+
+// NewFixedWidthReader returns a default FixedWidthReader for the columns specified in columns.
+// By default, white space is trimmed from each value.
+func NewFixedWidthReader(r io.Reader, columns []FixedWidthColumn) FixedWidthReader {
+	return FixedWidthReader{
+		Columns:     columns,
+		TrimSpace:   true,
+		LabelLevels: 0,
+		r:           r,
+	}
+}
+
+// Read reads each line of fixed-width text into one row.
+// The value of each column is the text from its Start to Start + Width (in characters, not bytes).
+// A line that ends before a column's Start has an empty value in that column,
+// and empty lines (e.g., at the end of the text) are skipped.
+//
+// Each column is read as []string, unless its Schema is declared, and then it is parsed as in RecordReader.Schema.
+// The first r.LabelLevels columns are read as label levels.
+func (r FixedWidthReader) Read() (*DataFrame, error) {
+	if len(r.Columns) == 0 {
+		return nil, fmt.Errorf("reading fixed-width text: must supply at least one column")
+	}
+	if r.LabelLevels >= len(r.Columns) {
+		return nil, fmt.Errorf("reading fixed-width text: number of label levels (%d) must be less than number of columns (%d)",
+			r.LabelLevels, len(r.Columns))
+	}
+	schema := make(map[string]ColumnSchema)
+	for _, col := range r.Columns {
+		if col.Start < 0 || col.Width <= 0 {
+			return nil, fmt.Errorf("reading fixed-width text: column (%s): start (%d) must be >= 0 and width (%d) must be > 0",
+				col.Name, col.Start, col.Width)
+		}
+		if col.Schema != nil {
+			schema[col.Name] = *col.Schema
+		}
+	}
+	slices := make([][]string, len(r.Columns))
+	scanner := bufio.NewScanner(r.r)
+	scanner.Buffer(nil, 64*1024*1024)
+	var line int
+	for scanner.Scan() {
+		line++
+		if line <= r.SkipRows {
+			continue
+		}
+		text := []rune(strings.TrimSuffix(scanner.Text(), "\r"))
+		if len(text) == 0 {
+			continue
+		}
+		for k, col := range r.Columns {
+			var value string
+			if col.Start < len(text) {
+				end := col.Start + col.Width
+				if end > len(text) {
+					end = len(text)
+				}
+				value = string(text[col.Start:end])
+			}
+			if r.TrimSpace {
+				value = strings.TrimSpace(value)
+			}
+			slices[k] = append(slices[k], value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading fixed-width text: %v", err)
+	}
+	containers := make([]*valueContainer, len(r.Columns))
+	for k, col := range r.Columns {
+		if slices[k] == nil {
+			slices[k] = []string{}
+		}
+		isNull, _ := setNullsFromInterface(slices[k])
+		containers[k] = newValueContainer(slices[k], isNull, col.Name)
+	}
+	_, _, err := applySchema(containers, schema, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("reading fixed-width text: %v", err)
+	}
+	return containersToDF(containers, 1, r.LabelLevels, r.Name), nil
+}
+
+// FixedWidthWriter writes a DataFrame as fixed-width text.
+type FixedWidthWriter struct {
+	Columns []FixedWidthColumn
+	// IncludeHeader writes a first line with the name of each column, padded and truncated like the values.
+	IncludeHeader bool
+	w             io.Writer
+}
+
+// NewFixedWidthWriter returns a *FixedWidthWriter with default settings that writes the containers specified in columns.
+// By default, no header line is written.
+func NewFixedWidthWriter(w io.Writer, columns []FixedWidthColumn) *FixedWidthWriter {
+	return &FixedWidthWriter{
+		Columns: columns,
+		w:       w,
+	}
+}
+
+// Write writes one line for each row of df. Each value in w.Columns (either a label level or a column) is written
+// at its Start, truncated to its Width, and padded with spaces to its Width according to its Align.
+// Columns may be specified in any order, but must not overlap. Gaps between columns are filled with spaces.
+// Null values are written as blanks.
+func (w *FixedWidthWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing fixed-width text: %v", df.err)
+	}
+	if len(w.Columns) == 0 {
+		return fmt.Errorf("writing fixed-width text: must supply at least one column")
+	}
+	columns := make([]FixedWidthColumn, len(w.Columns))
+	copy(columns, w.Columns)
+	sort.SliceStable(columns, func(i, j int) bool { return columns[i].Start < columns[j].Start })
+	mergedLabelsAndCols := append(df.labels, df.values...)
+	values := make([][]string, len(columns))
+	for k, col := range columns {
+		if col.Start < 0 || col.Width <= 0 {
+			return fmt.Errorf("writing fixed-width text: column (%s): start (%d) must be >= 0 and width (%d) must be > 0",
+				col.Name, col.Start, col.Width)
+		}
+		if k > 0 && col.Start < columns[k-1].Start+columns[k-1].Width {
+			return fmt.Errorf("writing fixed-width text: column (%s) overlaps column (%s)", col.Name, columns[k-1].Name)
+		}
+		index, err := indexOfContainer(col.Name, mergedLabelsAndCols)
+		if err != nil {
+			return fmt.Errorf("writing fixed-width text: %v", err)
+		}
+		vc := mergedLabelsAndCols[index]
+		values[k] = make([]string, df.Len())
+		copy(values[k], vc.string().slice)
+		for i := range values[k] {
+			if vc.isNull[i] {
+				values[k][i] = ""
+			}
+		}
+	}
+	bw := bufio.NewWriter(w.w)
+	writeLine := func(row func(k int) string) {
+		var pos int
+		for k, col := range columns {
+			bw.WriteString(strings.Repeat(" ", col.Start-pos))
+			bw.WriteString(padFixedWidth(row(k), col.Width, col.Align))
+			pos = col.Start + col.Width
+		}
+		bw.WriteByte('\n')
+	}
+	if w.IncludeHeader {
+		writeLine(func(k int) string { return columns[k].Name })
+	}
+	for i := 0; i < df.Len(); i++ {
+		writeLine(func(k int) string { return values[k][i] })
+	}
+	err := bw.Flush()
+	if err != nil {
+		return fmt.Errorf("writing fixed-width text: %v", err)
+	}
+	return nil
+}
+
+// padFixedWidth truncates s to width characters, then pads it with spaces to width according to align.
+func padFixedWidth(s string, width int, align Alignment) string {
+	n := utf8.RuneCountInString(s)
+	if n > width {
+		return string([]rune(s)[:width])
+	}
+	padding := strings.Repeat(" ", width-n)
+	if align == AlignRight {
+		return padding + s
+	}
+	return s + padding
+}
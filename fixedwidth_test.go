@@ -0,0 +1,134 @@
+package tada
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFixedWidthReader_Read(t *testing.T) {
+	columns := []FixedWidthColumn{
+		{Name: "id", Start: 0, Width: 4},
+		{Name: "name", Start: 4, Width: 8},
+		{Name: "amount", Start: 12, Width: 7, Schema: &ColumnSchema{DType: Float64}},
+	}
+	type fields struct {
+		Columns     []FixedWidthColumn
+		SkipRows    int
+		TrimSpace   bool
+		LabelLevels int
+		Name        string
+		data        string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"trim, schema, short lines, crlf", fields{Columns: columns, TrimSpace: true,
+			data: "0001café      12.50\r\n0002bar        -3\r\n0003\r\n\r\n"},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"0001", "0002", "0003"}, isNull: []bool{false, false, false}, id: mockID, name: "id"},
+				{slice: []string{"café", "bar", ""}, isNull: []bool{false, false, false}, id: mockID, name: "name"},
+				{slice: []float64{12.5, -3, 0}, isNull: []bool{false, false, true}, id: mockID, name: "amount"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"skip rows, labels, no trim, name", fields{Columns: columns[:2], SkipRows: 1, LabelLevels: 1, Name: "baz",
+			data: "id  name\n0001 foo"},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{" foo"}, isNull: []bool{false}, id: mockID, name: "name"}},
+				labels:        []*valueContainer{{slice: []string{"0001"}, isNull: []bool{false}, id: mockID, name: "id"}},
+				colLevelNames: []string{"*0"}, name: "baz"},
+			false},
+		{"datetime schema", fields{Columns: []FixedWidthColumn{
+			{Name: "date", Start: 0, Width: 8, Schema: &ColumnSchema{DType: DateTime, Layout: "20060102"}}},
+			data: "20201001"},
+			&DataFrame{values: []*valueContainer{
+				{slice: []time.Time{time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)}, isNull: []bool{false}, id: mockID, name: "date"}},
+				labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"no rows", fields{Columns: columns[:1], data: ""},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{}, isNull: nil, id: mockID, name: "id"}},
+				labels:        []*valueContainer{{slice: []int{}, isNull: []bool{}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - no columns", fields{data: "foo"}, nil, true},
+		{"fail - too many label levels", fields{Columns: columns[:1], LabelLevels: 1, data: "foo"}, nil, true},
+		{"fail - bad width", fields{Columns: []FixedWidthColumn{{Name: "foo", Width: 0}}, data: "foo"}, nil, true},
+		{"fail - schema", fields{Columns: columns, TrimSpace: true, data: "0001foo     bar"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := FixedWidthReader{
+				Columns:     tt.fields.Columns,
+				SkipRows:    tt.fields.SkipRows,
+				TrimSpace:   tt.fields.TrimSpace,
+				LabelLevels: tt.fields.LabelLevels,
+				Name:        tt.fields.Name,
+				r:           strings.NewReader(tt.fields.data),
+			}
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FixedWidthReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("FixedWidthReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixedWidthWriter_Write(t *testing.T) {
+	newDF := func() *DataFrame {
+		return &DataFrame{values: []*valueContainer{
+			{slice: []string{"café", "barbazqux"}, isNull: []bool{false, false}, id: mockID, name: "name"},
+			{slice: []float64{12.5, 0}, isNull: []bool{false, true}, id: mockID, name: "amount"}},
+			labels:        []*valueContainer{{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "id"}},
+			colLevelNames: []string{"*0"}}
+	}
+	tests := []struct {
+		name          string
+		columns       []FixedWidthColumn
+		includeHeader bool
+		df            *DataFrame
+		want          string
+		wantErr       bool
+	}{
+		{"pad, truncate, align, null", []FixedWidthColumn{
+			{Name: "id", Start: 0, Width: 3, Align: AlignRight},
+			{Name: "name", Start: 3, Width: 6},
+			{Name: "amount", Start: 9, Width: 5, Align: AlignRight}}, false, newDF(),
+			"  1café   12.5\n  2barbaz     \n", false},
+		{"header, gaps, any order", []FixedWidthColumn{
+			{Name: "amount", Start: 8, Width: 6},
+			{Name: "name", Start: 1, Width: 4}}, true, newDF(),
+			" name   amount\n café   12.5  \n barb         \n", false},
+		{"fail - overlap", []FixedWidthColumn{
+			{Name: "id", Start: 0, Width: 3}, {Name: "name", Start: 2, Width: 3}}, false, newDF(), "", true},
+		{"fail - missing container", []FixedWidthColumn{{Name: "corge", Start: 0, Width: 3}}, false, newDF(), "", true},
+		{"fail - bad width", []FixedWidthColumn{{Name: "id", Start: 0, Width: -1}}, false, newDF(), "", true},
+		{"fail - no columns", nil, false, newDF(), "", true},
+		{"fail - df error", []FixedWidthColumn{{Name: "id", Start: 0, Width: 3}}, false, dataFrameWithError(errors.New("foo")), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := NewFixedWidthWriter(buf, tt.columns)
+			w.IncludeHeader = tt.includeHeader
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("FixedWidthWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("FixedWidthWriter.Write() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package tada
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// -- fuzzy matching
+
+// similarityFuncs are the string similarity metrics supported by JoinOptionFuzzy.
+// Each returns a score from 0 (nothing in common) to 1 (identical).
+var similarityFuncs = map[string]func(a, b string) float64{
+	"levenshtein":  levenshteinSimilarity,
+	"jaro_winkler": jaroWinklerSimilarity,
+	"token_set":    tokenSetSimilarity,
+}
+
+// mergeFuzzy performs a Merge in which the last pair of keys matches if the similarity of their stringified values
+// is at least the threshold in config (and any other keys match exactly, as in an exact Merge).
+// Each row in df is aligned with up to config.topK rows in other, from most to least similar.
+func (df *DataFrame) mergeFuzzy(other *DataFrame, leftKeys []int, rightKeys []int, config *joinConfig) (*DataFrame, error) {
+	similarity, ok := similarityFuncs[config.fuzzyMetric]
+	if !ok {
+		return nil, fmt.Errorf("fuzzy: metric: must be levenshtein, jaro_winkler, or token_set (not %v)", config.fuzzyMetric)
+	}
+	if config.how != "left" && config.how != "inner" {
+		return nil, fmt.Errorf("fuzzy: how: must be left or inner (not %v)", config.how)
+	}
+	if config.topK < 1 {
+		return nil, fmt.Errorf("fuzzy: top k: must be at least 1 (not %d)", config.topK)
+	}
+	if config.validate != "" {
+		return nil, fmt.Errorf("fuzzy: validate: cannot be combined with a fuzzy merge")
+	}
+	otherMergedLabelsAndCols := append(other.labels, other.values...)
+	subsetLeft, _ := subsetContainers(append(df.labels, df.values...), leftKeys)
+	subsetRight, _ := subsetContainers(otherMergedLabelsAndCols, rightKeys)
+	matches := matchFuzzy(subsetLeft, subsetRight, similarity, config.fuzzyThreshold, config.topK)
+	leftRows, rightRows, err := alignRows(config.how, df.Len(), other.Len(), func(i int) []int {
+		rows := make([]int, len(matches[i]))
+		for n := range matches[i] {
+			rows[n] = matches[i][n].row
+		}
+		return rows
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+	labels, values := takeJoinedRows(config.how, df.labels, df.values, leftKeys,
+		otherMergedLabelsAndCols, rightKeys, leftRows, rightRows)
+	// include the matched keys, even if they are label levels
+	for _, j := range rightKeys {
+		if j < len(other.labels) {
+			values = append(values, takeRows(other.labels[j], rightRows))
+		}
+	}
+	for k := range other.values {
+		values = append(values, takeRows(other.values[k], rightRows))
+	}
+	values = append(values, fuzzyScores(matches, leftRows, rightRows))
+	ret := &DataFrame{
+		labels:        labels,
+		values:        values,
+		name:          df.name,
+		colLevelNames: df.colLevelNames,
+	}
+	ret.InPlace().DeduplicateNames()
+	return ret, nil
+}
+
+// A fuzzyMatch is a right row matched with a left row in a fuzzy merge, and the similarity of their keys.
+type fuzzyMatch struct {
+	row   int
+	score float64
+}
+
+// matchFuzzy returns up to k matches in rightKeys for each row in leftKeys, ordered by descending similarity
+// (and then by right row position). The last left and right keys match if the similarity of their stringified values
+// is at least threshold, and every other pair of keys must match exactly (see newJoinKeys).
+// A null fuzzy key does not match.
+func matchFuzzy(leftKeys []*valueContainer, rightKeys []*valueContainer,
+	similarity func(a, b string) float64, threshold float64, k int) [][]fuzzyMatch {
+	last := len(leftKeys) - 1
+	// the fuzzy keys are always compared as strings, as in an exact join of mismatched key types
+	// (copy the keys to avoid caching their stringified values)
+	left := newRowKeysOfKinds(copyContainers(leftKeys[last:]), []string{"string"}).levels[0].strings
+	right := newRowKeysOfKinds(copyContainers(rightKeys[last:]), []string{"string"}).levels[0].strings
+	leftNull, rightNull := leftKeys[last].isNull, rightKeys[last].isNull
+
+	// the right rows that match each left row on the exact keys (every right row, if there are none)
+	var candidates func(i int) (int, []int)
+	if last > 0 {
+		leftExact, rightExact := newJoinKeys(leftKeys[:last], rightKeys[:last])
+		rightIndex, rightGroups := groupRows(rightExact)
+		candidates = func(i int) (int, []int) {
+			group := rightIndex.find(leftExact, i)
+			if group == -1 {
+				return -1, nil
+			}
+			return group, rightGroups[group]
+		}
+	} else {
+		allRows := makeIntRange(0, len(right))
+		candidates = func(i int) (int, []int) { return 0, allRows }
+	}
+
+	// the same left key always has the same matches within the same group of exact keys
+	type memoKey struct {
+		group int
+		key   string
+	}
+	memo := make(map[memoKey][]fuzzyMatch)
+	ret := make([][]fuzzyMatch, len(left))
+	for i := range left {
+		if leftNull[i] {
+			continue
+		}
+		group, rows := candidates(i)
+		if group == -1 {
+			continue
+		}
+		matches, ok := memo[memoKey{group, left[i]}]
+		if !ok {
+			for _, j := range rows {
+				if rightNull[j] {
+					continue
+				}
+				if score := similarity(left[i], right[j]); score >= threshold {
+					matches = append(matches, fuzzyMatch{j, score})
+				}
+			}
+			sort.SliceStable(matches, func(a, b int) bool { return matches[a].score > matches[b].score })
+			if len(matches) > k {
+				matches = matches[:k]
+			}
+			memo[memoKey{group, left[i]}] = matches
+		}
+		ret[i] = matches
+	}
+	return ret
+}
+
+// fuzzyScores returns the similarity of each pair of aligned rows in a fuzzy merge (null if there is no right row).
+func fuzzyScores(matches [][]fuzzyMatch, leftRows []int, rightRows []int) *valueContainer {
+	scores := make([]float64, len(leftRows))
+	isNull := make([]bool, len(leftRows))
+	for n := range leftRows {
+		if rightRows[n] == -1 {
+			isNull[n] = true
+			continue
+		}
+		for _, m := range matches[leftRows[n]] {
+			if m.row == rightRows[n] {
+				scores[n] = m.score
+				break
+			}
+		}
+	}
+	return newValueContainer(scores, isNull, "similarity")
+}
+
+// levenshteinSimilarity returns 1 minus the Levenshtein edit distance between a and b,
+// divided by the length of the longer string (in characters).
+func levenshteinSimilarity(a, b string) float64 {
+	maxLen := utf8.RuneCountInString(a)
+	if n := utf8.RuneCountInString(b); n > maxLen {
+		maxLen = n
+	}
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(levenshteinDistance([]rune(a), []rune(b)))/float64(maxLen)
+}
+
+// levenshteinDistance returns the minimum number of single-character insertions, deletions, and substitutions
+// that change a into b.
+func levenshteinDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// jaroWinklerSimilarity returns the Jaro similarity of a and b,
+// boosted by up to 4 characters of common prefix (with the standard scaling factor of 0.1).
+func jaroWinklerSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	jaro := jaroSimilarity(ra, rb)
+	var prefix int
+	for prefix < len(ra) && prefix < len(rb) && prefix < 4 && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// jaroSimilarity returns the Jaro similarity of a and b, based on the number of matching characters
+// (within half the length of the longer string) and the number of transpositions between them.
+func jaroSimilarity(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	window := len(a)
+	if len(b) > window {
+		window = len(b)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+	var matches int
+	for i := range a {
+		start, end := i-window, i+window+1
+		if start < 0 {
+			start = 0
+		}
+		if end > len(b) {
+			end = len(b)
+		}
+		for j := start; j < end; j++ {
+			if !matchedB[j] && a[i] == b[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	var transpositions, j int
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+	m := float64(matches)
+	return (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+// tokenSetSimilarity compares the sets of lowercase words in a and b, ignoring their order and repetition.
+// The words shared by both strings are compared (by levenshteinSimilarity) with the shared words
+// plus the remaining words in each string, and the highest score is returned.
+// As a result, a string whose words are a subset of the other's words has a similarity of 1.
+func tokenSetSimilarity(a, b string) float64 {
+	tokensA := tokenSet(a)
+	tokensB := tokenSet(b)
+	var shared, onlyA, onlyB []string
+	for token := range tokensA {
+		if tokensB[token] {
+			shared = append(shared, token)
+		} else {
+			onlyA = append(onlyA, token)
+		}
+	}
+	for token := range tokensB {
+		if !tokensA[token] {
+			onlyB = append(onlyB, token)
+		}
+	}
+	sort.Strings(shared)
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+	sharedString := strings.Join(shared, " ")
+	combinedA := strings.TrimSpace(sharedString + " " + strings.Join(onlyA, " "))
+	combinedB := strings.TrimSpace(sharedString + " " + strings.Join(onlyB, " "))
+	best := levenshteinSimilarity(combinedA, combinedB)
+	if sharedString != "" {
+		if score := levenshteinSimilarity(sharedString, combinedA); score > best {
+			best = score
+		}
+		if score := levenshteinSimilarity(sharedString, combinedB); score > best {
+			best = score
+		}
+	}
+	return best
+}
+
+// tokenSet returns the unique lowercase words in s.
+func tokenSet(s string) map[string]bool {
+	ret := make(map[string]bool)
+	for _, token := range strings.Fields(strings.ToLower(s)) {
+		ret[token] = true
+	}
+	return ret
+}
@@ -0,0 +1,97 @@
+package tada
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func Test_similarityFuncs(t *testing.T) {
+	type args struct {
+		metric string
+		a      string
+		b      string
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{"levenshtein", args{"levenshtein", "kitten", "sitting"}, 4.0 / 7},
+		{"levenshtein - identical", args{"levenshtein", "foo", "foo"}, 1},
+		{"levenshtein - empty", args{"levenshtein", "", ""}, 1},
+		{"levenshtein - unicode", args{"levenshtein", "café", "cafe"}, 0.75},
+		{"jaro_winkler", args{"jaro_winkler", "MARTHA", "MARHTA"}, 0.9611111111111111},
+		{"jaro_winkler - different lengths", args{"jaro_winkler", "DIXON", "DICKSONX"}, 0.8133333333333332},
+		{"jaro_winkler - no matches", args{"jaro_winkler", "abc", "xyz"}, 0},
+		{"jaro_winkler - empty", args{"jaro_winkler", "", "abc"}, 0},
+		{"token_set - reordered", args{"token_set", "Acme Corp", "corp ACME"}, 1},
+		{"token_set - subset", args{"token_set", "Acme Corp", "acme corp inc"}, 1},
+		{"token_set - no shared words", args{"token_set", "foo bar", "baz qux"}, 3.0 / 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := similarityFuncs[tt.args.metric](tt.args.a, tt.args.b)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("similarityFuncs[%v]() = %v, want %v", tt.args.metric, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_matchFuzzy(t *testing.T) {
+	type args struct {
+		leftKeys  []*valueContainer
+		rightKeys []*valueContainer
+		threshold float64
+		k         int
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][]fuzzyMatch
+	}{
+		{"best match",
+			args{
+				[]*valueContainer{{slice: []string{"abc", "xyz"}, isNull: []bool{false, false}}},
+				[]*valueContainer{{slice: []string{"abd", "abc"}, isNull: []bool{false, false}}},
+				0.5, 1},
+			[][]fuzzyMatch{{{1, 1}}, nil}},
+		{"top k",
+			args{
+				[]*valueContainer{{slice: []string{"abc"}, isNull: []bool{false}}},
+				[]*valueContainer{{slice: []string{"abd", "abc", "xyz"}, isNull: []bool{false, false, false}}},
+				0.5, 2},
+			[][]fuzzyMatch{{{1, 1}, {0, levenshteinSimilarity("abc", "abd")}}}},
+		{"nulls do not match",
+			args{
+				[]*valueContainer{{slice: []string{"abc", "abc"}, isNull: []bool{true, false}}},
+				[]*valueContainer{{slice: []string{"abc", "abc"}, isNull: []bool{true, false}}},
+				0.5, 1},
+			[][]fuzzyMatch{nil, {{1, 1}}}},
+		{"non-string key",
+			args{
+				[]*valueContainer{{slice: []int{10}, isNull: []bool{false}}},
+				[]*valueContainer{{slice: []string{"1", "10"}, isNull: []bool{false, false}}},
+				0.5, 1},
+			[][]fuzzyMatch{{{1, 1}}}},
+		{"exact keys before the fuzzy key",
+			args{
+				[]*valueContainer{
+					{slice: []string{"a", "b", "c"}, isNull: []bool{false, false, false}},
+					{slice: []string{"abc", "abc", "abc"}, isNull: []bool{false, false, false}}},
+				[]*valueContainer{
+					{slice: []string{"b", "a", "b"}, isNull: []bool{false, false, false}},
+					{slice: []string{"abc", "abd", "abd"}, isNull: []bool{false, false, false}}},
+				0.5, 1},
+			[][]fuzzyMatch{{{1, levenshteinSimilarity("abc", "abd")}}, {{0, 1}}, nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchFuzzy(tt.args.leftKeys, tt.args.rightKeys, levenshteinSimilarity, tt.args.threshold, tt.args.k)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matchFuzzy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
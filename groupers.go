@@ -22,6 +22,7 @@ func (g *GroupedSeries) String() string {
 }
 
 // GetGroup returns the grouped rows sharing the same group key as a new Series.
+// group is the name of the group (as in ListGroups). To find a group by its values, use GetGroupByKey.
 func (g *GroupedSeries) GetGroup(group string) *Series {
 	for i, key := range g.orderedKeys {
 		if key == group {
@@ -31,6 +32,16 @@ func (g *GroupedSeries) GetGroup(group string) *Series {
 	return seriesWithError(fmt.Errorf("getting group: group (%v) not in groups", group))
 }
 
+// GetGroupByKey returns the grouped rows whose group key has the values in key (one per group level) as a new Series.
+// Each value is compared with its group level as in a Lookup, rather than by the group's name.
+func (g *GroupedSeries) GetGroupByKey(key ...interface{}) *Series {
+	group, err := groupOfKey(g.labels, key)
+	if err != nil {
+		return seriesWithError(fmt.Errorf("getting group: %v", err))
+	}
+	return g.series.Subset(g.rowIndices[group])
+}
+
 // Apply applies lambda to every group.
 // Each lambda input will be a slice of grouped values (including values considered null).
 // Each lambda output must be a slice that is the same length as the input.
@@ -97,6 +108,33 @@ func (g *GroupedSeries) indexReduceFunc(name string, index int) *Series {
 	}
 }
 
+// reduces int64 and bool values natively (see nativeReduceFunc), and all other values as float64
+func (g *GroupedSeries) nativeOrFloat64ReduceFunc(name string, fn func([]float64, []bool, []int) (float64, bool)) *Series {
+	seriesName := name
+	if g.series.values.name != "" {
+		seriesName = fmt.Sprintf("%v_%v", name, g.series.values.name)
+	}
+	retVals, err := nativeReduceFunc(g.series.values, name, seriesName, g.aligned, g.rowIndices)
+	if err != nil {
+		return seriesWithError(fmt.Errorf("reducing grouped Series: %v: %v", name, err))
+	}
+	if retVals == nil {
+		return g.float64ReduceFunc(name, fn)
+	}
+	var sharedData bool
+	retLabels := g.labels
+	if g.aligned {
+		// if aligned: all labels
+		retLabels = g.series.labels
+		sharedData = true
+	}
+	return &Series{
+		values:     retVals,
+		labels:     retLabels,
+		sharedData: sharedData,
+	}
+}
+
 // for each group, returns a count
 func (g *GroupedSeries) countReduceFunc(name string, fn func(interface{}, []bool, []int) (int, bool)) *Series {
 	var sharedData bool
@@ -135,8 +173,10 @@ func (g *GroupedSeries) Reduce(name string, lambda ReduceFn) *Series {
 }
 
 // Sum coerces values to float64 and calculates the sum of each group.
+// int64 values are summed without coercion and keep their type, so the result is []int64 (not []float64).
+// Returns an error if an int64 sum overflows.
 func (g *GroupedSeries) Sum() *Series {
-	return g.float64ReduceFunc("sum", sum)
+	return g.nativeOrFloat64ReduceFunc("sum", sum)
 }
 
 // Mean coerces values to float64 and calculates the mean of each group.
@@ -165,13 +205,17 @@ func (g *GroupedSeries) NUnique() *Series {
 }
 
 // Min coerces values to float64 and calculates the minimum of each group.
+// int64 and bool values keep their type, so the result is []int64 or []bool (not []float64).
+// The minimum of bool values is true only if every value is true.
 func (g *GroupedSeries) Min() *Series {
-	return g.float64ReduceFunc("min", min)
+	return g.nativeOrFloat64ReduceFunc("min", min)
 }
 
 // Max coerces values to float64 and calculates the maximum of each group.
+// int64 and bool values keep their type, so the result is []int64 or []bool (not []float64).
+// The maximum of bool values is true if any value is true.
 func (g *GroupedSeries) Max() *Series {
-	return g.float64ReduceFunc("max", max)
+	return g.nativeOrFloat64ReduceFunc("max", max)
 }
 
 // Earliest coerces the Series values to time.Time and calculates the earliest timestamp in each group.
@@ -370,6 +414,8 @@ func (g *GroupedSeries) Len() int {
 }
 
 // ListGroups returns a list of group keys in the order in which they originally appeared.
+// In a multi-level group key, each level is joined by the level separator,
+// and any separator or backslash within a level is escaped with a backslash.
 func (g *GroupedSeries) ListGroups() []string {
 	return g.orderedKeys
 }
@@ -452,6 +498,40 @@ func (g *GroupedDataFrame) interfaceReduceFunc(
 	}
 }
 
+// reduces int64 and bool columns natively (see nativeReduceFunc), and all other columns as float64
+func (g *GroupedDataFrame) nativeOrFloat64ReduceFunc(
+	name string, cols []string, fn func([]float64, []bool, []int) (float64, bool)) *DataFrame {
+	if len(cols) == 0 {
+		cols = g.df.ListColNames()
+	}
+	retVals := make([]*valueContainer, len(cols))
+	for k, colName := range cols {
+		index, err := indexOfContainer(colName, g.df.values)
+		if err != nil {
+			return dataFrameWithError(fmt.Errorf("reducing grouped DataFrame: %v", err))
+		}
+		vc := g.df.values[index]
+		adjustedColName := fmt.Sprintf("%v_%v", name, colName)
+		retVals[k], err = nativeReduceFunc(vc, name, adjustedColName, g.aligned, g.rowIndices)
+		if err != nil {
+			return dataFrameWithError(fmt.Errorf("reducing grouped DataFrame: %v: column %s: %v", name, colName, err))
+		}
+		if retVals[k] == nil {
+			retVals[k] = groupedFloat64ReduceFunc(vc.float64().slice, vc.isNull, adjustedColName, g.aligned, g.rowIndices, fn)
+		}
+	}
+	if g.df.name != "" {
+		name = fmt.Sprintf("%v_%v", name, g.df.name)
+	}
+
+	return &DataFrame{
+		values:        retVals,
+		labels:        g.labels,
+		colLevelNames: []string{"*0"},
+		name:          name,
+	}
+}
+
 func (g *GroupedDataFrame) countReduceFunc(name string, cols []string, fn func(interface{}, []bool, []int) (int, bool)) *DataFrame {
 	if len(cols) == 0 {
 		cols = g.df.ListColNames()
@@ -479,6 +559,7 @@ func (g *GroupedDataFrame) countReduceFunc(name string, cols []string, fn func(i
 }
 
 // GetGroup returns the grouped rows sharing the same group key as a new DataFrame.
+// group is the name of the group (as in ListGroups). To find a group by its values, use GetGroupByKey.
 func (g *GroupedDataFrame) GetGroup(group string) *DataFrame {
 	for i, key := range g.orderedKeys {
 		if key == group {
@@ -488,9 +569,21 @@ func (g *GroupedDataFrame) GetGroup(group string) *DataFrame {
 	return dataFrameWithError(fmt.Errorf("getting group: group (%v) not in groups", group))
 }
 
+// GetGroupByKey returns the grouped rows whose group key has the values in key (one per group level) as a new DataFrame.
+// Each value is compared with its group level as in a Lookup, rather than by the group's name.
+func (g *GroupedDataFrame) GetGroupByKey(key ...interface{}) *DataFrame {
+	group, err := groupOfKey(g.labels, key)
+	if err != nil {
+		return dataFrameWithError(fmt.Errorf("getting group: %v", err))
+	}
+	return g.df.Subset(g.rowIndices[group])
+}
+
 // Sum coerces the column values in colNames to float64 and calculates the sum of each group.
+// int64 columns are summed without coercion and keep their type, so the result is []int64 (not []float64).
+// Returns an error if an int64 sum overflows.
 func (g *GroupedDataFrame) Sum(colNames ...string) *DataFrame {
-	return g.float64ReduceFunc("sum", colNames, sum)
+	return g.nativeOrFloat64ReduceFunc("sum", colNames, sum)
 }
 
 // Mean coerces the column values in colNames to float64 and calculates the mean of each group.
@@ -509,13 +602,17 @@ func (g *GroupedDataFrame) StdDev(colNames ...string) *DataFrame {
 }
 
 // Min coerces the column values in colNames to float64 and calculates the minimum of each group.
+// int64 and bool columns keep their type, so the result is []int64 or []bool (not []float64).
+// The minimum of bool values is true only if every value is true.
 func (g *GroupedDataFrame) Min(colNames ...string) *DataFrame {
-	return g.float64ReduceFunc("min", colNames, min)
+	return g.nativeOrFloat64ReduceFunc("min", colNames, min)
 }
 
 // Max coerces the column values in colNames to float64 and calculates the maximum of each group.
+// int64 and bool columns keep their type, so the result is []int64 or []bool (not []float64).
+// The maximum of bool values is true if any value is true.
 func (g *GroupedDataFrame) Max(colNames ...string) *DataFrame {
-	return g.float64ReduceFunc("max", colNames, max)
+	return g.nativeOrFloat64ReduceFunc("max", colNames, max)
 }
 
 // Count returns the number of non-null values in each group for the columns in colNames.
@@ -710,6 +807,8 @@ func (g *GroupedDataFrame) Iterator() *GroupedDataFrameIterator {
 }
 
 // ListGroups returns a list of group keys in the order in which they originally appeared.
+// In a multi-level group key, each level is joined by the level separator,
+// and any separator or backslash within a level is escaped with a backslash.
 func (g *GroupedDataFrame) ListGroups() []string {
 	return g.orderedKeys
 }
@@ -892,3 +991,111 @@ func groupedCountReduceFunc(slice interface{}, nulls []bool, name string, aligne
 	}
 	return newValueContainer(retVals, retNulls, name)
 }
+
+// int64ReduceFuncs and boolReduceFuncs are the native versions of the float64 reductions (by name)
+// that are applied to int64 and bool values, respectively.
+var int64ReduceFuncs = map[string]func([]int64, []bool, []int) (int64, bool, error){
+	"sum": sumInt64,
+	"min": minInt64,
+	"max": maxInt64,
+}
+
+var boolReduceFuncs = map[string]func([]bool, []bool, []int) (bool, bool){
+	"min": allTrue,
+	"max": anyTrue,
+}
+
+// nativeReduceFunc reduces each group in vc with the native version of the float64 reduction named op,
+// if vc holds int64 or bool values and such a version exists. The result has the same type as vc.
+// Otherwise, returns nil.
+func nativeReduceFunc(vc *valueContainer, op string, name string, aligned bool, rowIndices [][]int) (*valueContainer, error) {
+	switch arr := vc.slice.(type) {
+	case []int64:
+		if fn, ok := int64ReduceFuncs[op]; ok {
+			return groupedInt64ReduceFunc(arr, vc.isNull, name, aligned, rowIndices, fn)
+		}
+	case []bool:
+		if fn, ok := boolReduceFuncs[op]; ok {
+			return groupedBoolReduceFunc(arr, vc.isNull, name, aligned, rowIndices, fn), nil
+		}
+	}
+	return nil, nil
+}
+
+func groupedInt64ReduceFunc(slice []int64, nulls []bool, name string, aligned bool, rowIndices [][]int,
+	fn func([]int64, []bool, []int) (int64, bool, error)) (*valueContainer, error) {
+	retLength := len(rowIndices)
+	if aligned {
+		// if aligned: return length is overwritten to equal the length of original data
+		retLength = len(slice)
+	}
+	retVals := make([]int64, retLength)
+	retNulls := make([]bool, retLength)
+	for i, rowIndex := range rowIndices {
+		output, isNull, err := fn(slice, nulls, rowIndex)
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %v", i, err)
+		}
+		if !aligned {
+			// default: write each output once and in sequential order into retVals
+			retVals[i] = output
+			retNulls[i] = isNull
+		} else {
+			// if aligned: write each output multiple times and out of order into retVals
+			for _, index := range rowIndex {
+				retVals[index] = output
+				retNulls[index] = isNull
+			}
+		}
+	}
+	return newValueContainer(retVals, retNulls, name), nil
+}
+
+func groupedBoolReduceFunc(slice []bool, nulls []bool, name string, aligned bool, rowIndices [][]int,
+	fn func([]bool, []bool, []int) (bool, bool)) *valueContainer {
+	retLength := len(rowIndices)
+	if aligned {
+		// if aligned: return length is overwritten to equal the length of original data
+		retLength = len(slice)
+	}
+	retVals := make([]bool, retLength)
+	retNulls := make([]bool, retLength)
+	for i, rowIndex := range rowIndices {
+		output, isNull := fn(slice, nulls, rowIndex)
+		if !aligned {
+			// default: write each output once and in sequential order into retVals
+			retVals[i] = output
+			retNulls[i] = isNull
+		} else {
+			// if aligned: write each output multiple times and out of order into retVals
+			for _, index := range rowIndex {
+				retVals[index] = output
+				retNulls[index] = isNull
+			}
+		}
+	}
+	return newValueContainer(retVals, retNulls, name)
+}
+
+// groupOfKey returns the position of the group in labels (one row per group) whose key has the values in key.
+// A value with the same type as its label level is compared by value. Otherwise, both are compared as strings.
+func groupOfKey(labels []*valueContainer, key []interface{}) (int, error) {
+	if len(key) != len(labels) {
+		return 0, fmt.Errorf("key must have one value per group level (%d, not %d)", len(labels), len(key))
+	}
+	keyContainers := make([]*valueContainer, len(key))
+	for j := range key {
+		slice := reflect.MakeSlice(reflect.TypeOf(labels[j].slice), 1, 1)
+		if key[j] != nil && reflect.TypeOf(key[j]).AssignableTo(slice.Type().Elem()) {
+			slice.Index(0).Set(reflect.ValueOf(key[j]))
+			keyContainers[j] = newValueContainer(slice.Interface(), []bool{false}, labels[j].name)
+		} else {
+			keyContainers[j] = newValueContainer([]interface{}{key[j]}, []bool{false}, labels[j].name)
+		}
+	}
+	group := matchKeys(keyContainers, labels)[0]
+	if group == -1 {
+		return 0, fmt.Errorf("key (%v) not in groups", key)
+	}
+	return group, nil
+}
@@ -3,6 +3,7 @@ package tada
 import (
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
 	"testing"
@@ -587,6 +588,38 @@ func TestGroupedSeries_Sum(t *testing.T) {
 						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}}}},
 			want: &Series{values: &valueContainer{slice: []float64{3, 7}, isNull: []bool{false, false}, id: mockID, name: "sum"},
 				labels: []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}},
+		{
+			name: "int64 - summed natively",
+			fields: fields{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 1}, {2, 3}},
+				labels:      []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				series: &Series{values: &valueContainer{slice: []int64{1 << 53, 1, 3, 1}, isNull: []bool{false, false, false, true}},
+					labels: []*valueContainer{
+						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}}}},
+			want: &Series{values: &valueContainer{slice: []int64{1<<53 + 1, 3}, isNull: []bool{false, false}, id: mockID, name: "sum"},
+				labels: []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}},
+		{
+			name: "int - summed as float64",
+			fields: fields{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 1}, {2, 3}},
+				labels:      []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				series: &Series{values: &valueContainer{slice: []int{1, 2, 3, 4}, isNull: []bool{false, false, false, false}},
+					labels: []*valueContainer{
+						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}}}},
+			want: &Series{values: &valueContainer{slice: []float64{3, 7}, isNull: []bool{false, false}, id: mockID, name: "sum"},
+				labels: []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}},
+		{
+			name: "fail - int64 sum overflows",
+			fields: fields{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 1}, {2, 3}},
+				labels:      []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				series: &Series{values: &valueContainer{slice: []int64{1, 2, math.MaxInt64, 1}, isNull: []bool{false, false, false, false}},
+					labels: []*valueContainer{
+						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}}}},
+			want: &Series{err: fmt.Errorf("reducing grouped Series: sum: group 1: sum overflows int64")}},
 		{
 			name: "single level - aligned",
 			fields: fields{
@@ -1786,6 +1819,48 @@ func TestGroupedDataFrame_Sum(t *testing.T) {
 				colLevelNames: []string{"*0"},
 				name:          "sum_qux",
 			}},
+		{
+			name: "int64 columns keep their type and int columns are summed as float64",
+			fields: fields{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 1}, {2, 3}},
+				labels:      []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				df: &DataFrame{
+					values: []*valueContainer{
+						{slice: []int{1, 2, 3, 4}, isNull: []bool{false, false, false, false}, id: mockID, name: "corge"},
+						{slice: []int64{1 << 53, 1, 7, 8}, isNull: []bool{false, false, false, false}, id: mockID, name: "waldo"},
+					},
+					labels: []*valueContainer{
+						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "baz"}},
+					colLevelNames: []string{"*0"},
+					name:          "qux"}},
+			args: args{nil},
+			want: &DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{3, 7}, isNull: []bool{false, false}, id: mockID, name: "sum_corge"},
+					{slice: []int64{1<<53 + 1, 15}, isNull: []bool{false, false}, id: mockID, name: "sum_waldo"},
+				},
+				labels: []*valueContainer{
+					{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				colLevelNames: []string{"*0"},
+				name:          "sum_qux",
+			}},
+		{
+			name: "fail - int64 sum overflows",
+			fields: fields{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 1}, {2, 3}},
+				labels:      []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				df: &DataFrame{
+					values: []*valueContainer{
+						{slice: []int64{math.MinInt64, -1, 7, 8}, isNull: []bool{false, false, false, false}, id: mockID, name: "corge"},
+					},
+					labels: []*valueContainer{
+						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "baz"}},
+					colLevelNames: []string{"*0"},
+					name:          "qux"}},
+			args: args{nil},
+			want: &DataFrame{err: fmt.Errorf("reducing grouped DataFrame: sum: column corge: group 0: sum overflows int64")}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2171,6 +2246,32 @@ func TestGroupedDataFrame_Max(t *testing.T) {
 				colLevelNames: []string{"*0"},
 				name:          "max_qux",
 			}},
+		{
+			name: "int64 and bool columns keep their type",
+			fields: fields{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 1}, {2, 3}},
+				labels:      []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				df: &DataFrame{
+					values: []*valueContainer{
+						{slice: []int64{1, 2, 3, 4}, isNull: []bool{false, false, false, true}, id: mockID, name: "corge"},
+						{slice: []bool{false, true, false, false}, isNull: []bool{false, false, false, false}, id: mockID, name: "waldo"},
+					},
+					labels: []*valueContainer{
+						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "baz"}},
+					colLevelNames: []string{"*0"},
+					name:          "qux"}},
+			args: args{nil},
+			want: &DataFrame{
+				values: []*valueContainer{
+					{slice: []int64{2, 3}, isNull: []bool{false, false}, id: mockID, name: "max_corge"},
+					{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "max_waldo"},
+				},
+				labels: []*valueContainer{
+					{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				colLevelNames: []string{"*0"},
+				name:          "max_qux",
+			}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3083,6 +3184,27 @@ func TestGroupedDataFrame_GetGroup(t *testing.T) {
 				labels:        []*valueContainer{{slice: []string{"bar", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
 				colLevelNames: []string{"*0"}},
 		},
+		{
+			name: "multi level - separator in values",
+			fields: fields{
+				rowIndices:  [][]int{{0, 2}, {1}},
+				orderedKeys: []string{`a\|b|c`, `a|b\|c`},
+				labels: []*valueContainer{
+					{slice: []string{"a|b", "a"}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+					{slice: []string{"c", "b|c"}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				df: &DataFrame{values: []*valueContainer{
+					{slice: []int{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "baz"}},
+					labels: []*valueContainer{
+						{slice: []string{"a|b", "a", "a|b"}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+						{slice: []string{"c", "b|c", "c"}, isNull: []bool{false, false, false}, id: mockID, name: "bar"}},
+					colLevelNames: []string{"*0"}}},
+			args: args{`a|b\|c`},
+			want: &DataFrame{values: []*valueContainer{{slice: []int{2}, isNull: []bool{false}, id: mockID, name: "baz"}},
+				labels: []*valueContainer{
+					{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "foo"},
+					{slice: []string{"b|c"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+				colLevelNames: []string{"*0"}},
+		},
 		{name: "fail",
 			fields: fields{
 				rowIndices:  [][]int{{0, 1}, {2, 3}},
@@ -3114,6 +3236,83 @@ func TestGroupedDataFrame_GetGroup(t *testing.T) {
 	}
 }
 
+func TestGroupedSeries_GetGroupByKey(t *testing.T) {
+	s := &Series{
+		values: &valueContainer{slice: []float64{10, 20, 30}, isNull: []bool{false, false, false}, id: mockID, name: "baz"},
+		labels: []*valueContainer{
+			{slice: []string{"a|b", "a", "a|b"}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+			{slice: []string{"c", "b|c", "c"}, isNull: []bool{false, false, false}, id: mockID, name: "bar"}},
+	}
+	tests := []struct {
+		name string
+		key  []interface{}
+		want []float64
+		err  bool
+	}{
+		{"separator in values", []interface{}{"a", "b|c"}, []float64{20}, false},
+		{"fail - not in groups", []interface{}{"a|b", "b|c"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.GroupBy().GetGroupByKey(tt.key...)
+			if (got.Err() != nil) != tt.err {
+				t.Errorf("GroupedSeries.GetGroupByKey() error = %v, want %v", got.Err(), tt.err)
+				return
+			}
+			if got.Err() != nil {
+				return
+			}
+			if vals := got.GetValuesAsFloat64(); !reflect.DeepEqual(vals, tt.want) {
+				t.Errorf("GroupedSeries.GetGroupByKey() = %v, want %v", vals, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupedDataFrame_GetGroupByKey(t *testing.T) {
+	df := &DataFrame{
+		values: []*valueContainer{
+			{slice: []string{"a|b", "a", "a|b"}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+			{slice: []string{"c", "b|c", "c"}, isNull: []bool{false, false, false}, id: mockID, name: "bar"},
+			{slice: []int{1, 2, 1}, isNull: []bool{false, false, false}, id: mockID, name: "qux"},
+			{slice: []float64{10, 20, 30}, isNull: []bool{false, false, false}, id: mockID, name: "baz"}},
+		labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"},
+	}
+	type args struct {
+		names []string
+		key   []interface{}
+	}
+	tests := []struct {
+		name string
+		args args
+		want []float64
+		err  bool
+	}{
+		{"separator in values", args{[]string{"foo", "bar"}, []interface{}{"a", "b|c"}}, []float64{20}, false},
+		{"separator in values - other group", args{[]string{"foo", "bar"}, []interface{}{"a|b", "c"}}, []float64{10, 30}, false},
+		{"typed value", args{[]string{"qux"}, []interface{}{1}}, []float64{10, 30}, false},
+		{"value of another type", args{[]string{"qux"}, []interface{}{"2"}}, []float64{20}, false},
+		{"fail - not in groups", args{[]string{"foo", "bar"}, []interface{}{"a|b", "b|c"}}, nil, true},
+		{"fail - wrong number of levels", args{[]string{"foo", "bar"}, []interface{}{"a"}}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := df.GroupBy(tt.args.names...).GetGroupByKey(tt.args.key...)
+			if (got.Err() != nil) != tt.err {
+				t.Errorf("GroupedDataFrame.GetGroupByKey() error = %v, want %v", got.Err(), tt.err)
+				return
+			}
+			if got.Err() != nil {
+				return
+			}
+			if vals := got.Col("baz").GetValuesAsFloat64(); !reflect.DeepEqual(vals, tt.want) {
+				t.Errorf("GroupedDataFrame.GetGroupByKey() = %v, want %v", vals, tt.want)
+			}
+		})
+	}
+}
+
 func TestGroupedSeries_Iterator(t *testing.T) {
 	type fields struct {
 		orderedKeys []string
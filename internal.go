@@ -182,6 +182,19 @@ func makeIntRange(min, max int) []int {
 	return ret
 }
 
+// isIdentityIndex returns true if index selects every row of a container of length n, in order
+func isIdentityIndex(index []int, n int) bool {
+	if len(index) != n {
+		return false
+	}
+	for i := range index {
+		if index[i] != i {
+			return false
+		}
+	}
+	return true
+}
+
 // search for a name only once.
 // if it is found multiple times, return only the first
 func findMatchingKeysBetweenTwoContainers(container1 []*valueContainer, container2 []*valueContainer) ([]int, []int, error) {
@@ -478,7 +491,7 @@ func (df *DataFrame) toCSVByRows(includeLabels bool) ([][]string, error) {
 			offset = df.NumLevels()
 		}
 		// if number of col levels is only one, return the name as a single-item slice
-		multiColHeaders := splitNameIntoLevels(df.values[k].name)
+		multiColHeaders := splitNameIntoNumLevels(df.values[k].name, df.numColLevels())
 		for l := 0; l < df.numColLevels(); l++ {
 			// write multi column headers, offset by label levels
 			ret[l][k+offset] = multiColHeaders[l]
@@ -495,7 +508,10 @@ func (df *DataFrame) toCSVByRows(includeLabels bool) ([][]string, error) {
 func setJoinConfig(options []JoinOption) *joinConfig {
 	// default config
 	config := &joinConfig{
-		how: "left",
+		how:       "left",
+		direction: "backward",
+		bounds:    "[)",
+		topK:      1,
 	}
 	for _, option := range options {
 		option(config)
@@ -673,11 +689,35 @@ func writeStructSlice(containers []*valueContainer, slice interface{}, noUnmatch
 	}
 
 	// check for type match
+	// int64 and bool fields also accept any container whose non-null values convert without overflow or loss
+	slices := make(map[int]reflect.Value, len(m))
 	for key, value := range m {
-		if reflect.TypeOf(containers[value].slice).Elem() != protoStruct.Field(key).Type {
+		fieldType := protoStruct.Field(key).Type
+		vc := containers[value]
+		if reflect.TypeOf(vc.slice).Elem() == fieldType {
+			slices[key] = reflect.ValueOf(vc.slice)
+			continue
+		}
+		var converted interface{}
+		var convertedIsNull []bool
+		switch fieldType {
+		case reflect.TypeOf(int64(0)):
+			c := vc.copy().int64()
+			converted, convertedIsNull = c.slice, c.isNull
+		case reflect.TypeOf(false):
+			c := vc.copy().bool()
+			converted, convertedIsNull = c.slice, c.isNull
+		default:
 			return nil, fmt.Errorf("writing to slice of structs: container[%d] (%s) must be same type as matching field (%v != %v)",
-				value, nameOfContainer(containers, value), reflect.TypeOf(containers[value].slice).Elem(), protoStruct.Field(key).Type)
+				value, nameOfContainer(containers, value), reflect.TypeOf(vc.slice).Elem(), fieldType)
+		}
+		for i := range convertedIsNull {
+			if convertedIsNull[i] && !vc.isNull[i] {
+				return nil, fmt.Errorf("writing to slice of structs: container[%d] (%s): cannot convert row %d (%v) to %v",
+					value, nameOfContainer(containers, value), i, reflect.ValueOf(vc.slice).Index(i).Interface(), fieldType)
+			}
 		}
+		slices[key] = reflect.ValueOf(converted)
 	}
 
 	if noUnmatchedCols {
@@ -692,9 +732,9 @@ func writeStructSlice(containers []*valueContainer, slice interface{}, noUnmatch
 	v.Elem().Set(reflect.MakeSlice(reflect.SliceOf(protoStruct), numRows, numRows))
 	for i := 0; i < numRows; i++ {
 		s := reflect.New(protoStruct)
-		for key, value := range m {
+		for key := range m {
 			dst := s.Elem().Field(key)
-			dst.Set(reflect.ValueOf(containers[value].slice).Index(i))
+			dst.Set(slices[key].Index(i))
 		}
 		v.Elem().Index(i).Set(s.Elem())
 	}
@@ -842,9 +882,15 @@ func transposeNestedNulls(isNull [][]bool) ([][]bool, error) {
 }
 
 func inferType(input string) DType {
+	if _, err := strconv.ParseInt(input, 10, 64); err == nil {
+		return Int64
+	}
 	if _, err := strconv.ParseFloat(input, 64); err == nil {
 		return Float64
 	}
+	if strings.EqualFold(input, "true") || strings.EqualFold(input, "false") {
+		return Bool
+	}
 	if t, null := convertStringToDateTime(input); !null {
 		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
 			return Date
@@ -861,11 +907,187 @@ func inferType(input string) DType {
 func castToInferredTypes(containers []*valueContainer) {
 	for k := range containers {
 		dtype := containers[k].inferType()
-		containers[k].cast(dtype)
+		// the type is inferred from a sample, so later values may not be integers
+		if err := containers[k].cast(dtype); err != nil {
+			containers[k].cast(Float64)
+		}
 	}
 	return
 }
 
+var dtypeNames = map[DType]string{
+	String:   "String",
+	Float64:  "Float64",
+	DateTime: "DateTime",
+	Time:     "Time",
+	Date:     "Date",
+	Int64:    "Int64",
+	Bool:     "Bool",
+}
+
+// parseStringAs parses s as dtype, using layout (if supplied) to parse DateTime, Date, and Time values.
+func parseStringAs(s string, dtype DType, layout string) (interface{}, error) {
+	parseDateTime := func() (time.Time, error) {
+		if layout != "" {
+			return time.Parse(layout, s)
+		}
+		t, isNull := convertStringToDateTime(s)
+		if isNull {
+			return time.Time{}, fmt.Errorf("does not match any datetime format")
+		}
+		return t, nil
+	}
+	switch dtype {
+	case String:
+		return s, nil
+	case Float64:
+		return strconv.ParseFloat(s, 64)
+	case Int64:
+		return strconv.ParseInt(s, 10, 64)
+	case Bool:
+		return strconv.ParseBool(s)
+	case DateTime:
+		return parseDateTime()
+	case Date:
+		if layout == "" {
+			if d, err := civil.ParseDate(s); err == nil {
+				return d, nil
+			}
+		}
+		t, err := parseDateTime()
+		return civil.DateOf(t), err
+	case Time:
+		if layout == "" {
+			if t, err := civil.ParseTime(s); err == nil {
+				return t, nil
+			}
+		}
+		t, err := parseDateTime()
+		return civil.TimeOf(t), err
+	default:
+		return nil, fmt.Errorf("unsupported DType (%d)", dtype)
+	}
+}
+
+// applySchema parses the []string values of each container in schema in place.
+// Containers are identified by name, or by position if hasHeaders is false.
+// Returns whether each container was parsed.
+// If rejectBadRows is true, a value that cannot be parsed is set to null and its row is returned in rejects
+// (which maps row position to the first parse error in that row). Otherwise, the first such value returns an error.
+func applySchema(containers []*valueContainer, schema map[string]ColumnSchema, hasHeaders bool, rejectBadRows bool) (
+	parsed []bool, rejects map[int]string, err error) {
+	parsed = make([]bool, len(containers))
+	rejects = make(map[int]string)
+	names := make(map[string]int, len(containers))
+	for k := range containers {
+		if hasHeaders {
+			names[containers[k].name] = k
+		} else {
+			names[strconv.Itoa(k)] = k
+		}
+	}
+	// iterate over columns in order so that the first parse error is deterministic
+	keys := make([]string, 0, len(schema))
+	for name := range schema {
+		if _, ok := names[name]; !ok {
+			return nil, nil, fmt.Errorf("schema: column (%s) not found", name)
+		}
+		keys = append(keys, name)
+	}
+	sort.Slice(keys, func(i, j int) bool { return names[keys[i]] < names[keys[j]] })
+	for _, name := range keys {
+		k := names[name]
+		col := schema[name]
+		nullTokens := make(map[string]bool, len(col.NullTokens))
+		for _, token := range col.NullTokens {
+			nullTokens[token] = true
+		}
+		vals := containers[k].slice.([]string)
+		isNull := make([]bool, len(vals))
+		var slice reflect.Value
+		switch col.DType {
+		case String:
+			slice = reflect.ValueOf(make([]string, len(vals)))
+		case Float64:
+			slice = reflect.ValueOf(make([]float64, len(vals)))
+		case DateTime:
+			slice = reflect.ValueOf(make([]time.Time, len(vals)))
+		case Date:
+			slice = reflect.ValueOf(make([]civil.Date, len(vals)))
+		case Time:
+			slice = reflect.ValueOf(make([]civil.Time, len(vals)))
+		case Int64:
+			slice = reflect.ValueOf(make([]int64, len(vals)))
+		case Bool:
+			slice = reflect.ValueOf(make([]bool, len(vals)))
+		default:
+			return nil, nil, fmt.Errorf("schema: column (%s): unsupported DType (%d)", name, col.DType)
+		}
+		for i := range vals {
+			if containers[k].isNull[i] || nullTokens[vals[i]] || (vals[i] == "" && col.DType != String) {
+				isNull[i] = true
+				continue
+			}
+			v, err := parseStringAs(vals[i], col.DType, col.Layout)
+			if err != nil {
+				msg := fmt.Sprintf("column %s: cannot parse %q as %s", name, vals[i], dtypeNames[col.DType])
+				if !rejectBadRows {
+					return nil, nil, fmt.Errorf("schema: row %d, %s", i, msg)
+				}
+				if _, ok := rejects[i]; !ok {
+					rejects[i] = msg
+				}
+				isNull[i] = true
+				continue
+			}
+			slice.Index(i).Set(reflect.ValueOf(v))
+		}
+		containers[k].slice = slice.Interface()
+		containers[k].isNull = isNull
+		containers[k].resetCache()
+		parsed[k] = true
+	}
+	return parsed, rejects, nil
+}
+
+// rejectRows removes the rows in rejects from containers (in place)
+// and returns a DataFrame with the original values of those rows, labeled by row position, and an error column.
+func rejectRows(containers []*valueContainer, original [][]string, rejects map[int]string, numHeaders int, name string) *DataFrame {
+	var keep, reject []int
+	for i := 0; i < containers[0].len(); i++ {
+		if _, ok := rejects[i]; ok {
+			reject = append(reject, i)
+		} else {
+			keep = append(keep, i)
+		}
+	}
+	if keep == nil {
+		keep = []int{}
+	}
+	for k := range containers {
+		// duck error because index is guaranteed to be in range
+		containers[k].subsetRows(keep)
+	}
+	ret := make([]*valueContainer, 0, len(containers)+2)
+	ret = append(ret, newValueContainer(reject, make([]bool, len(reject)), "row"))
+	for k := range original {
+		vals := subsetInterfaceSlice(original[k], reject).([]string)
+		isNull, _ := setNullsFromInterface(vals)
+		ret = append(ret, newValueContainer(vals, isNull, containers[k].name))
+	}
+	errorLevels := make([]string, numHeaders)
+	if numHeaders == 0 {
+		errorLevels = make([]string, 1)
+	}
+	errorLevels[0] = "error"
+	msgs := make([]string, len(reject))
+	for i, row := range reject {
+		msgs[i] = rejects[row]
+	}
+	ret = append(ret, newValueContainer(msgs, make([]bool, len(msgs)), joinLevelsIntoName(errorLevels)))
+	return containersToDF(ret, numHeaders, 1, name)
+}
+
 // expects vc.slice to be []string
 func (vc *valueContainer) inferType() DType {
 	s := vc.slice.([]string)
@@ -879,6 +1101,11 @@ func (vc *valueContainer) inferType() DType {
 		dtype := inferType(sample[i])
 		inferredTypes[dtype]++
 	}
+	// a mix of integers and other numbers is inferred as Float64
+	if inferredTypes[Float64] > 0 {
+		inferredTypes[Float64] += inferredTypes[Int64]
+		delete(inferredTypes, Int64)
+	}
 	var highestCount int
 	var dtype DType
 	for key, v := range inferredTypes {
@@ -922,6 +1149,10 @@ func mockString(dtype DType, nullPct float64) (string, bool) {
 		options = []string{"2019-12-31", "2020-01-01", "2020-01-02", "2020-02-01", "2020-02-02"}
 	case Time:
 		options = []string{"10:00am", "11:00am", "1:00pm", "2:00pm", "3:30pm"}
+	case Int64:
+		options = []string{"1", "2", "5", "10", "100"}
+	case Bool:
+		options = []string{"true", "false"}
 	}
 	rand.Seed(clock.now().UnixNano())
 	f := rand.Float64()
@@ -1293,6 +1524,28 @@ func (vc *valueContainer) sort(dtype DType, ascending bool, index []int) []int {
 		sort.Stable(srt)
 		sortedIsNull = d.isNull
 		sortedIndex = d.index
+
+	case Int64:
+		d := vc.int64()
+		d.index = index
+		srt = d
+		if !ascending {
+			srt = sort.Reverse(srt)
+		}
+		sort.Stable(srt)
+		sortedIsNull = d.isNull
+		sortedIndex = d.index
+
+	case Bool:
+		d := vc.bool()
+		d.index = index
+		srt = d
+		if !ascending {
+			srt = sort.Reverse(srt)
+		}
+		sort.Stable(srt)
+		sortedIsNull = d.isNull
+		sortedIndex = d.index
 	}
 	// iterate over each sorted row and check whether it is null or not
 	var nullCounter, validCounter int
@@ -1343,38 +1596,6 @@ func indexOfContainers(names []string, containers []*valueContainer) ([]int, err
 	return ret, nil
 }
 
-// concatenateLabelsToStringsBytes reduces all container rows to a single slice of concatenated strings, one per row
-func concatenateLabelsToStringsBytes(labels []*valueContainer) []string {
-	for j := range labels {
-		labels[j].setCache()
-	}
-	// is only label?
-	if len(labels) == 1 {
-		numRows := labels[0].len()
-		ret := make([]string, numRows)
-		for i := 0; i < numRows; i++ {
-			ret[i] = labels[0].cache[i]
-		}
-		return ret
-	}
-	b := strings.Builder{}
-	numRows := labels[0].len()
-	ret := make([]string, numRows)
-
-	for i := 0; i < numRows; i++ {
-		b.Reset()
-		for j := range labels {
-			b.WriteString(labels[j].cache[i])
-			if j != len(labels)-1 {
-				b.WriteString(optionLevelSeparator)
-			}
-		}
-		ret[i] = b.String()
-	}
-	// return a single slice of strings
-	return ret
-}
-
 // reduceContainers reduces the containers referenced in the index
 // to 1) a new []*valueContainer with slices with one unique combination of labels per row (same type as original labels),
 // 2) an [][]int that maps each new row back to the rows in the original containers with the matching label combo
@@ -1383,49 +1604,22 @@ func reduceContainers(containers []*valueContainer) (
 	newContainers []*valueContainer,
 	originalRowIndices [][]int,
 	orderedKeys []string) {
-	// coerce all label levels to string for use as map keys
-	stringifiedLabels := concatenateLabelsToStringsBytes(containers)
-	// create receiver for unique labels of same type as original levels
+	// group rows by their hashed label combos
+	idx, originalRowIndices := groupRows(newRowKeys(containers))
+	// write the first appearance of each label combo to new containers of same type as original levels
 	newContainers = make([]*valueContainer, len(containers))
 	for j := range containers {
 		newContainers[j] = newValueContainer(
-			reflect.MakeSlice(reflect.TypeOf(containers[j].slice), 0, 0).Interface(),
-			make([]bool, 0),
+			subsetInterfaceSlice(containers[j].slice, idx.firstRows),
+			subsetNulls(containers[j].isNull, idx.firstRows),
 			containers[j].name,
 			containers[j].id,
 		)
 	}
-	// create receiver for the original row indexes for each unique label combo
-	uniqueLabelRows := make(map[string][]int)
-	// create receiver for the unique label combos in order
-	orderedKeys = make([]string, 0)
-	// iterate over rows in original containers
-	for i, key := range stringifiedLabels {
-		// check if label combo already exists in set
-		if _, ok := uniqueLabelRows[key]; !ok {
-			// if label combo does not exist:
-			// add int position of the original row to the map
-			uniqueLabelRows[key] = []int{i}
-
-			// write label values to new containers, in order of appearance
-			for j := range containers {
-				src := reflect.ValueOf(containers[j].slice).Index(i)
-				dst := reflect.ValueOf(newContainers[j].slice)
-				newContainers[j].slice = reflect.Append(dst, src).Interface()
-				newContainers[j].isNull = append(newContainers[j].isNull, containers[j].isNull[i])
-			}
-			// add key to list to maintain order in which unique label combos appear
-			orderedKeys = append(orderedKeys, key)
-
-		} else {
-			// if so: add int position of this row
-			uniqueLabelRows[key] = append(uniqueLabelRows[key], i)
-		}
-	}
-	// transfer row indexes in order of new unique label combos
-	originalRowIndices = make([][]int, len(orderedKeys))
-	for i, key := range orderedKeys {
-		originalRowIndices[i] = uniqueLabelRows[key]
+	// stringify only the unique label combos, in the order in which they appear
+	orderedKeys = make([]string, len(idx.firstRows))
+	for i, row := range idx.firstRows {
+		orderedKeys[i] = rowString(containers, row)
 	}
 	return
 }
@@ -1434,86 +1628,94 @@ func reduceContainers(containers []*valueContainer) (
 // 2) a map[int]int that maps each original row index to its row index in the new containers
 func reduceContainersForPromote(containers []*valueContainer) (
 	newContainers []*valueContainer, oldToNewRowMapping map[int]int) {
-	// coerce all label levels to string for use as map keys
-	stringifiedLabels := concatenateLabelsToStringsBytes(containers)
-	// create receiver for unique labels of same type as original levels
+	keys := newRowKeys(containers)
+	idx := newKeyIndex(keys)
+	// key: original row index, value: new row index for the same unique label combo found at original row index
+	// there will be one key for each row in the original data
+	oldToNewRowMapping = make(map[int]int, len(keys.hashes))
+	for i := range keys.hashes {
+		// relate each row index in the old containers to a row in the new containers with deduplicated labels
+		oldToNewRowMapping[i], _ = idx.add(i)
+	}
+	// write the first appearance of each label combo to new containers of same type as original levels
 	newContainers = make([]*valueContainer, len(containers))
 	for j := range containers {
 		newContainers[j] = newValueContainer(
-			reflect.MakeSlice(reflect.TypeOf(containers[j].slice), 0, 0).Interface(),
-			make([]bool, 0),
+			subsetInterfaceSlice(containers[j].slice, idx.firstRows),
+			subsetNulls(containers[j].isNull, idx.firstRows),
 			containers[j].name,
 			containers[j].id,
 		)
 	}
-	// create receiver for the original row indexes for each unique label combo
-	uniqueLabelRows := make(map[string][]int)
-	// create receiver for the unique label combos in order
-	var numKeys int
-	// key: original row index, value: new row index for the same unique label combo found at original row index
-	// there will be one key for each row in the original data
-	oldToNewRowMapping = make(map[int]int, len(stringifiedLabels))
-	// helper map for oldToNewRowMapping - key: unique label combo, value: new row index for that unique label combo
-	orderedKeyIndex := make(map[string]int)
-	// iterate over rows in original containers
-	for i, key := range stringifiedLabels {
-		// check if label combo already exists in set
-		if _, ok := uniqueLabelRows[key]; !ok {
-			// if label combo does not exist:
-			// add int position of the original row to the map
-			uniqueLabelRows[key] = []int{i}
-
-			// write label values to new containers, in order of appearance
-			for j := range containers {
-				src := reflect.ValueOf(containers[j].slice).Index(i)
-				dst := reflect.ValueOf(newContainers[j].slice)
-				newContainers[j].slice = reflect.Append(dst, src).Interface()
-				newContainers[j].isNull = append(newContainers[j].isNull, containers[j].isNull[i])
-			}
-			// count the number of existing ordered keys to identify the new row index of this unique label combo
-			orderedKeyIndex[key] = numKeys
-			// add key to list to maintain order in which unique label combos appear
-			numKeys++
-
-		}
-		// relate each row index in the old containers to a row in the new containers with deduplicated labels
-		oldToNewRowMapping[i] = orderedKeyIndex[key]
-	}
 	return
 }
 
-// similar to reduceContainers, but only returns map of unique label combos and the row index where they first appear
-func reduceContainersForLookup(containers []*valueContainer) map[string]int {
-	ret := make(map[string]int)
-	stringifiedLabels := concatenateLabelsToStringsBytes(containers)
-	for i, key := range stringifiedLabels {
-		if _, ok := ret[key]; !ok {
-			ret[key] = i
+// matchKeys returns the position of the first row in containers2 with the same key as each row in containers1,
+// or -1 if there is no match.
+func matchKeys(containers1 []*valueContainer, containers2 []*valueContainer) []int {
+	keys1, keys2 := newJoinKeys(containers1, containers2)
+	idx := newKeyIndex(keys2)
+	for j := range keys2.hashes {
+		idx.add(j)
+	}
+	ret := make([]int, len(keys1.hashes))
+	for i := range ret {
+		ret[i] = -1
+		if group := idx.find(keys1, i); group != -1 {
+			ret[i] = idx.firstRows[group]
 		}
 	}
 	return ret
 }
 
+// splitNameIntoLevels splits name on every optionLevelSeparator that is not escaped by joinLevelsIntoName,
+// and unescapes each level.
 func splitNameIntoLevels(name string) []string {
-	return strings.Split(name, optionLevelSeparator)
-}
-
+	if !strings.Contains(name, `\`) {
+		return strings.Split(name, optionLevelSeparator)
+	}
+	var levels []string
+	var level strings.Builder
+	for i := 0; i < len(name); {
+		switch {
+		case strings.HasPrefix(name[i:], `\\`):
+			level.WriteByte('\\')
+			i += 2
+		case strings.HasPrefix(name[i:], `\`+optionLevelSeparator):
+			level.WriteString(optionLevelSeparator)
+			i += 1 + len(optionLevelSeparator)
+		case strings.HasPrefix(name[i:], optionLevelSeparator):
+			levels = append(levels, level.String())
+			level.Reset()
+			i += len(optionLevelSeparator)
+		default:
+			level.WriteByte(name[i])
+			i++
+		}
+	}
+	return append(levels, level.String())
+}
+
+// splitNameIntoNumLevels splits name into levels, unless numLevels is 1
+// (so that the name of a column in a DataFrame with a single column level may contain optionLevelSeparator).
+func splitNameIntoNumLevels(name string, numLevels int) []string {
+	if numLevels == 1 {
+		return []string{name}
+	}
+	return splitNameIntoLevels(name)
+}
+
+// joinLevelsIntoName joins levels with optionLevelSeparator.
+// If there are multiple levels, each is escaped (see escapeLevel) so that the name can be split back into the same levels.
 func joinLevelsIntoName(levels []string) string {
-	return strings.Join(levels, optionLevelSeparator)
-}
-
-// if labels1[i] is in labels2, ret[i] = the row position in labels2 that first matches labels[i].
-// if no match, ret[i] = -1
-func matchLabelPositions(labels1 []string, labels2 map[string]int) []int {
-	ret := make([]int, len(labels1))
-	for i, key := range labels1 {
-		if val, ok := labels2[key]; ok {
-			ret[i] = val
-		} else {
-			ret[i] = -1
-		}
+	if len(levels) == 1 {
+		return levels[0]
 	}
-	return ret
+	escaped := make([]string, len(levels))
+	for l := range levels {
+		escaped[l] = escapeLevel(levels[l])
+	}
+	return strings.Join(escaped, optionLevelSeparator)
 }
 
 func (s *Series) combineMath(other *Series, ignoreNulls bool, fn func(v1 float64, v2 float64) float64) *Series {
@@ -1565,12 +1767,17 @@ func lookup(how string,
 		return lookupWithAnchor(values1.name, labels1, leftOn, values2, labels2, rightOn), nil
 	case "right":
 		return lookupWithAnchor(values2.name, labels2, rightOn, values1, labels1, leftOn), nil
-	case "inner":
-		s := lookupWithAnchor(values1.name, labels1, leftOn, values2, labels2, rightOn)
-		s = s.DropNull()
-		return s, nil
 	default:
-		return nil, fmt.Errorf("how: must be left, right, or inner")
+		labels, _, rightRows, err := joinContainers(how, labels1, nil, leftOn, labels2, rightOn, false)
+		if err != nil {
+			return nil, err
+		}
+		values := takeRows(values2, rightRows)
+		values.name = values1.name
+		return &Series{
+			values: values,
+			labels: labels,
+		}, nil
 	}
 }
 
@@ -1592,16 +1799,441 @@ func lookupDataFrame(how string,
 			mergedLabelsCols2, rightOn,
 			mergedLabelsCols1, leftOn,
 			values1, excludeLeft), nil
-	case "inner":
-		df := lookupDataFrameWithAnchor(name, colLevelNames, labels1,
-			mergedLabelsCols1, leftOn,
-			mergedLabelsCols2, rightOn,
-			values2, excludeRight)
-		df = df.DropNull()
-		return df, nil
 	default:
-		return nil, fmt.Errorf("how: must be left, right, or inner")
+		labels, _, rightRows, err := joinContainers(how, labels1, values1, leftOn, mergedLabelsCols2, rightOn, false)
+		if err != nil {
+			return nil, err
+		}
+		var retVals []*valueContainer
+		for k := range values2 {
+			// skip any column whose name is also used in the lookup
+			if containsString(excludeRight, values2[k].name) {
+				continue
+			}
+			retVals = append(retVals, takeRows(values2[k], rightRows))
+		}
+		return &DataFrame{
+			values:        retVals,
+			labels:        labels,
+			name:          name,
+			colLevelNames: colLevelNames,
+		}, nil
+	}
+}
+
+// joinContainers joins the rows of labels1 and values1 with the rows of containers2 by a join of type how
+// (see joinRows), using the containers at leftOn (within labels1 and values1) and rightOn (within containers2) as keys.
+// Returns the aligned rows of labels1 and values1 and the positions of the aligned rows in containers2.
+func joinContainers(how string,
+	labels1 []*valueContainer, values1 []*valueContainer, leftOn []int,
+	containers2 []*valueContainer, rightOn []int, expand bool) (labels []*valueContainer, values []*valueContainer, rightRows []int, err error) {
+	leftKeys, _ := subsetContainers(append(labels1, values1...), leftOn)
+	rightKeys, _ := subsetContainers(containers2, rightOn)
+	leftRows, rightRows, err := joinRows(how, leftKeys, rightKeys, labels1[0].len(), containers2[0].len(), expand)
+	if err != nil {
+		return nil, nil, nil, err
 	}
+	labels, values = takeJoinedRows(how, labels1, values1, leftOn, containers2, rightOn, leftRows, rightRows)
+	return labels, values, rightRows, nil
+}
+
+// takeJoinedRows returns the rows of labels1 and values1 at leftRows (the left side of a join of type how).
+// In a right-only row of an outer join, each left key is filled in from the matching right key.
+func takeJoinedRows(how string,
+	labels1 []*valueContainer, values1 []*valueContainer, leftOn []int,
+	containers2 []*valueContainer, rightOn []int, leftRows []int, rightRows []int) (labels []*valueContainer, values []*valueContainer) {
+	mergedLabelsCols1 := append(labels1, values1...)
+	merged := make([]*valueContainer, len(mergedLabelsCols1))
+	for k := range mergedLabelsCols1 {
+		merged[k] = takeRows(mergedLabelsCols1[k], leftRows)
+	}
+	if how == "outer" {
+		for m := range leftOn {
+			merged[leftOn[m]] = coalesceRows(merged[leftOn[m]], containers2[rightOn[m]], leftRows, rightRows)
+		}
+	}
+	return merged[:len(labels1):len(labels1)], merged[len(labels1):]
+}
+
+// joinRows aligns the rows of two sets of key containers by matching their values (see newJoinKeys), and
+// returns the aligned row positions on each side. A position of -1 means that there is no row on that side.
+// Supported join types:
+// left (all left rows), inner (rows with a matching key on both sides),
+// outer (all left rows, then every right row without a match on the left),
+// left_semi (left rows with a match), left_anti (left rows without a match), and cross (every pair of rows, ignoring keys).
+// If expand is true, a left row is repeated once for every right row with the same key (except in left_semi joins).
+// Otherwise, each left row is aligned with the first right row that has the same key.
+func joinRows(how string, leftKeys []*valueContainer, rightKeys []*valueContainer, leftLen int, rightLen int, expand bool) ([]int, []int, error) {
+	if how == "cross" {
+		var leftRows, rightRows []int
+		for i := 0; i < leftLen; i++ {
+			for j := 0; j < rightLen; j++ {
+				leftRows = append(leftRows, i)
+				rightRows = append(rightRows, j)
+			}
+		}
+		return leftRows, rightRows, nil
+	}
+	leftHashes, rightHashes := newJoinKeys(leftKeys, rightKeys)
+	// every right row position for each key, in order
+	rightIndex, rightGroups := groupRows(rightHashes)
+	return alignRows(how, leftLen, rightLen, func(i int) []int {
+		if group := rightIndex.find(leftHashes, i); group != -1 {
+			return rightGroups[group]
+		}
+		return nil
+	}, expand)
+}
+
+// alignRows aligns each left row with the right rows returned by matches (in order) in a join of type how
+// (any type supported by joinRows except cross), and returns the aligned row positions on each side.
+// A position of -1 means that there is no row on that side.
+// If expand is false, each left row is aligned with only its first match.
+func alignRows(how string, leftLen int, rightLen int, matches func(i int) []int, expand bool) ([]int, []int, error) {
+	var leftRows, rightRows []int
+	switch how {
+	case "left", "inner", "outer":
+		// right rows that match at least one left row
+		matched := make([]bool, rightLen)
+		for i := 0; i < leftLen; i++ {
+			rows := matches(i)
+			if len(rows) == 0 {
+				if how != "inner" {
+					leftRows = append(leftRows, i)
+					rightRows = append(rightRows, -1)
+				}
+				continue
+			}
+			for _, j := range rows {
+				matched[j] = true
+			}
+			if !expand {
+				rows = rows[:1]
+			}
+			for _, j := range rows {
+				leftRows = append(leftRows, i)
+				rightRows = append(rightRows, j)
+			}
+		}
+		if how == "outer" {
+			for j := 0; j < rightLen; j++ {
+				if !matched[j] {
+					leftRows = append(leftRows, -1)
+					rightRows = append(rightRows, j)
+				}
+			}
+		}
+	case "left_semi":
+		for i := 0; i < leftLen; i++ {
+			if rows := matches(i); len(rows) > 0 {
+				leftRows = append(leftRows, i)
+				rightRows = append(rightRows, rows[0])
+			}
+		}
+	case "left_anti":
+		for i := 0; i < leftLen; i++ {
+			if len(matches(i)) == 0 {
+				leftRows = append(leftRows, i)
+				rightRows = append(rightRows, -1)
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("how: must be left, right, inner, outer, left_semi, left_anti, or cross")
+	}
+	return leftRows, rightRows, nil
+}
+
+// validateJoinKeys returns an error if the cardinality of the keys on either side violates validate
+// (one of: 1:1, 1:m, m:1, m:m). A side declared as 1 must not have any repeated keys.
+// If there are no keys (e.g., in a cross join), only validate itself is checked.
+func validateJoinKeys(validate string, leftKeys []*valueContainer, rightKeys []*valueContainer) error {
+	var leftUnique, rightUnique bool
+	switch validate {
+	case "1:1":
+		leftUnique, rightUnique = true, true
+	case "1:m":
+		leftUnique = true
+	case "m:1":
+		rightUnique = true
+	case "m:m":
+	default:
+		return fmt.Errorf("validate: must be 1:1, 1:m, m:1, or m:m (not %v)", validate)
+	}
+	if leftUnique && len(leftKeys) > 0 {
+		if key, ok := findRepeatedKey(leftKeys); ok {
+			return fmt.Errorf("validate (%v): key (%v) is repeated in left side", validate, key)
+		}
+	}
+	if rightUnique && len(rightKeys) > 0 {
+		if key, ok := findRepeatedKey(rightKeys); ok {
+			return fmt.Errorf("validate (%v): key (%v) is repeated in right side", validate, key)
+		}
+	}
+	return nil
+}
+
+// findRepeatedKey returns the first stringified key that appears in more than one row of keys.
+func findRepeatedKey(keys []*valueContainer) (string, bool) {
+	idx := newKeyIndex(newRowKeys(keys))
+	for i := 0; i < keys[0].len(); i++ {
+		if _, isNew := idx.add(i); !isNew {
+			return rowString(keys, i), true
+		}
+	}
+	return "", false
+}
+
+// exactMatchGroups returns the group of each row in containers1 and containers2 (by the values of the containers named in by),
+// so that rows may be matched only within the same group. A row in containers1 whose values do not appear in containers2
+// is in group -1. If by is empty, returns nil groups.
+func exactMatchGroups(by []string, containers1 []*valueContainer, containers2 []*valueContainer) ([]int, []int, error) {
+	if len(by) == 0 {
+		return nil, nil, nil
+	}
+	index1, err := indexOfContainers(by, containers1)
+	if err != nil {
+		return nil, nil, err
+	}
+	index2, err := indexOfContainers(by, containers2)
+	if err != nil {
+		return nil, nil, err
+	}
+	// copy the containers to avoid caching their stringified values
+	subset1, _ := subsetContainers(containers1, index1)
+	subset2, _ := subsetContainers(containers2, index2)
+	keys1, keys2 := newJoinKeys(copyContainers(subset1), copyContainers(subset2))
+	idx := newKeyIndex(keys2)
+	groups2 := make([]int, len(keys2.hashes))
+	for j := range groups2 {
+		groups2[j], _ = idx.add(j)
+	}
+	groups1 := make([]int, len(keys1.hashes))
+	for i := range groups1 {
+		groups1[i] = idx.find(keys1, i)
+	}
+	return groups1, groups2, nil
+}
+
+// orderedKey holds the values of a merge key that is compared by order (e.g., in an as-of merge)
+// as either DateTime or float64 values.
+type orderedKey struct {
+	times  []time.Time
+	floats []float64
+	isNull []bool
+}
+
+// newOrderedKeys converts containers to comparable keys:
+// DateTime if any container has a date or time type, and float64 otherwise.
+func newOrderedKeys(containers ...*valueContainer) []orderedKey {
+	var isDateTime bool
+	for _, vc := range containers {
+		if isDateTimeSlice(vc.slice) {
+			isDateTime = true
+		}
+	}
+	ret := make([]orderedKey, len(containers))
+	for k, vc := range containers {
+		if isDateTime {
+			times := vc.copy().dateTime()
+			ret[k] = orderedKey{times: times.slice, isNull: times.isNull}
+		} else {
+			floats := vc.copy().float64()
+			ret[k] = orderedKey{floats: floats.slice, isNull: floats.isNull}
+		}
+	}
+	return ret
+}
+
+// isDateTimeSlice returns true if slice is a slice of a date or time type.
+func isDateTimeSlice(slice interface{}) bool {
+	switch slice.(type) {
+	case []time.Time, []civil.DateTime, []civil.Date, []civil.Time:
+		return true
+	}
+	return false
+}
+
+// diff returns the value of k at row i minus the value of other at row j (in nanoseconds for DateTime values).
+func (k orderedKey) diff(i int, other orderedKey, j int) float64 {
+	if k.times != nil {
+		return float64(k.times[i].Sub(other.times[j]))
+	}
+	return k.floats[i] - other.floats[j]
+}
+
+// matchAsOf returns the position of the right row matched with each left row in an as-of merge configured by config
+// (see DataFrame.MergeAsOf), or -1 if there is no match.
+// If leftGroups and rightGroups are not nil, a left row may only match a right row in the same group.
+func matchAsOf(leftKey orderedKey, leftGroups []int, rightKey orderedKey, rightGroups []int, config *joinConfig) []int {
+	// sort the non-null right rows in each group by key (and then by original position)
+	groups := make(map[int][]int)
+	for j := range rightKey.isNull {
+		if rightKey.isNull[j] {
+			continue
+		}
+		var group int
+		if rightGroups != nil {
+			group = rightGroups[j]
+		}
+		groups[group] = append(groups[group], j)
+	}
+	for _, rows := range groups {
+		sort.SliceStable(rows, func(a, b int) bool {
+			return rightKey.diff(rows[a], rightKey, rows[b]) < 0
+		})
+	}
+	ret := make([]int, len(leftKey.isNull))
+	for i := range ret {
+		ret[i] = -1
+		if leftKey.isNull[i] {
+			continue
+		}
+		var group int
+		if leftGroups != nil {
+			group = leftGroups[i]
+		}
+		rows := groups[group]
+		// backward: the last row <= the left key; forward: the first row >= the left key
+		backward := sort.Search(len(rows), func(n int) bool { return rightKey.diff(rows[n], leftKey, i) > 0 }) - 1
+		forward := sort.Search(len(rows), func(n int) bool { return rightKey.diff(rows[n], leftKey, i) >= 0 })
+		match := -1
+		switch config.direction {
+		case "backward":
+			if backward >= 0 {
+				match = rows[backward]
+			}
+		case "forward":
+			if forward < len(rows) {
+				match = rows[forward]
+			}
+		case "nearest":
+			if backward >= 0 {
+				match = rows[backward]
+			}
+			if forward < len(rows) {
+				if match == -1 || rightKey.diff(rows[forward], leftKey, i) < leftKey.diff(i, rightKey, match) {
+					match = rows[forward]
+				}
+			}
+		}
+		if match != -1 && config.hasTolerance && math.Abs(leftKey.diff(i, rightKey, match)) > config.tolerance {
+			continue
+		}
+		ret[i] = match
+	}
+	return ret
+}
+
+// matchIntervals returns the positions of the right rows whose interval (from start to end) contains the key of each left row,
+// in their original order. The start and end are included if closedStart and closedEnd are true, respectively.
+// If keyGroups and intervalGroups are not nil, a left row may only match a right row in the same group.
+// Rows with a null key, start, or end are not matched.
+//
+// Within each group, the intervals are sorted by start and the keys are sorted, and then both are swept in order
+// (keeping a list of the intervals that have started but not ended), so inputs that are already sorted are not re-sorted.
+func matchIntervals(key orderedKey, keyGroups []int, start orderedKey, end orderedKey, intervalGroups []int,
+	closedStart bool, closedEnd bool) [][]int {
+	group := func(groups []int, i int) int {
+		if groups == nil {
+			return 0
+		}
+		return groups[i]
+	}
+	intervals := make(map[int][]int)
+	for j := range start.isNull {
+		if !start.isNull[j] && !end.isNull[j] {
+			intervals[group(intervalGroups, j)] = append(intervals[group(intervalGroups, j)], j)
+		}
+	}
+	keys := make(map[int][]int)
+	for i := range key.isNull {
+		if !key.isNull[i] {
+			keys[group(keyGroups, i)] = append(keys[group(keyGroups, i)], i)
+		}
+	}
+	ret := make([][]int, len(key.isNull))
+	for g, rows := range keys {
+		candidates := intervals[g]
+		sortRows(rows, func(a, b int) bool { return key.diff(rows[a], key, rows[b]) < 0 })
+		sortRows(candidates, func(a, b int) bool { return start.diff(candidates[a], start, candidates[b]) < 0 })
+		var next int
+		var active []int
+		for _, i := range rows {
+			for next < len(candidates) && start.diff(candidates[next], key, i) <= 0 {
+				active = append(active, candidates[next])
+				next++
+			}
+			// keys are ascending, so an interval that ends before this key cannot contain any later key
+			remaining := active[:0]
+			for _, j := range active {
+				fromEnd := end.diff(j, key, i)
+				if fromEnd > 0 || (fromEnd == 0 && closedEnd) {
+					remaining = append(remaining, j)
+				}
+			}
+			active = remaining
+			for _, j := range active {
+				if closedStart || start.diff(j, key, i) < 0 {
+					ret[i] = append(ret[i], j)
+				}
+			}
+			sort.Ints(ret[i])
+		}
+	}
+	return ret
+}
+
+// sortRows sorts rows stably by less, unless they are already sorted.
+func sortRows(rows []int, less func(a, b int) bool) {
+	if !sort.SliceIsSorted(rows, less) {
+		sort.SliceStable(rows, less)
+	}
+}
+
+// takeRows returns a new container with the rows of vc at the positions in index.
+// A position of -1 is a null row with the zero value of the container's type.
+func takeRows(vc *valueContainer, index []int) *valueContainer {
+	// as in subsetRows, keep the cache if the rows are unchanged
+	if isIdentityIndex(index, vc.len()) {
+		return vc.copy()
+	}
+	v := reflect.ValueOf(vc.slice)
+	vals := reflect.MakeSlice(v.Type(), len(index), len(index))
+	isNull := make([]bool, len(index))
+	for i, position := range index {
+		if position == -1 {
+			isNull[i] = true
+			continue
+		}
+		vals.Index(i).Set(v.Index(position))
+		isNull[i] = vc.isNull[position]
+	}
+	return &valueContainer{
+		slice:  vals.Interface(),
+		isNull: isNull,
+		name:   vc.name,
+		id:     vc.id,
+	}
+}
+
+// coalesceRows fills every row i in dst for which dstRows[i] is -1 with the row srcRows[i] in src.
+// If dst and src have different types, dst is converted to string.
+func coalesceRows(dst *valueContainer, src *valueContainer, dstRows []int, srcRows []int) *valueContainer {
+	v := reflect.ValueOf(dst.slice)
+	srcValues := reflect.ValueOf(src.slice)
+	if v.Type() != srcValues.Type() {
+		v = reflect.ValueOf(dst.string().slice)
+		srcValues = reflect.ValueOf(src.string().slice)
+	}
+	for i := range dstRows {
+		if dstRows[i] == -1 && srcRows[i] != -1 {
+			v.Index(i).Set(srcValues.Index(srcRows[i]))
+			dst.isNull[i] = src.isNull[srcRows[i]]
+		}
+	}
+	dst.slice = v.Interface()
+	dst.resetCache()
+	return dst
 }
 
 // lookupWithAnchor subsets sourceLabels by leftOn and lookupLabels by rightOn,
@@ -1621,9 +2253,7 @@ func lookupWithAnchor(
 		}
 	}
 
-	toLookup := concatenateLabelsToStringsBytes(subsetLeft)
-	lookupSource := reduceContainersForLookup(subsetRight)
-	matches := matchLabelPositions(toLookup, lookupSource)
+	matches := matchKeys(subsetLeft, subsetRight)
 	reflectLookup := reflect.ValueOf(lookupValues.slice)
 	isNull := make([]bool, len(matches))
 	// return type is set to same type as within lookupSource
@@ -1667,10 +2297,8 @@ func lookupDataFrameWithAnchor(
 			colLevelNames: colLevelNames,
 		}
 	}
-	toLookup := concatenateLabelsToStringsBytes(subsetLeft)
-	lookupSource := reduceContainersForLookup(subsetRight)
 	// list of aligned rows
-	matches := matchLabelPositions(toLookup, lookupSource)
+	matches := matchKeys(subsetLeft, subsetRight)
 	// slice of slices
 	var retVals []*valueContainer
 	for k := range lookupColumns {
@@ -2131,6 +2759,98 @@ func max(vals []float64, isNull []bool, index []int) (float64, bool) {
 	return max, false
 }
 
+// sumInt64 sums the non-null values at the index positions in vals.
+// If all values are null, the final result is null. Returns an error if the sum overflows int64.
+func sumInt64(vals []int64, isNull []bool, index []int) (int64, bool, error) {
+	var sum int64
+	var atLeastOneValid bool
+	for _, i := range index {
+		if !isNull[i] {
+			if (vals[i] > 0 && sum > math.MaxInt64-vals[i]) || (vals[i] < 0 && sum < math.MinInt64-vals[i]) {
+				return 0, false, fmt.Errorf("sum overflows int64")
+			}
+			sum += vals[i]
+			atLeastOneValid = true
+		}
+	}
+	if !atLeastOneValid {
+		return 0, true, nil
+	}
+	return sum, false, nil
+}
+
+// minInt64 returns the min of the non-null values at the index positions in vals.
+// If all values are null, the final result is null.
+func minInt64(vals []int64, isNull []bool, index []int) (int64, bool, error) {
+	var min int64 = math.MaxInt64
+	var atLeastOneValid bool
+	for _, i := range index {
+		if !isNull[i] {
+			if vals[i] < min {
+				min = vals[i]
+			}
+			atLeastOneValid = true
+		}
+	}
+	if !atLeastOneValid {
+		return 0, true, nil
+	}
+	return min, false, nil
+}
+
+// maxInt64 returns the max of the non-null values at the index positions in vals.
+// If all values are null, the final result is null.
+func maxInt64(vals []int64, isNull []bool, index []int) (int64, bool, error) {
+	var max int64 = math.MinInt64
+	var atLeastOneValid bool
+	for _, i := range index {
+		if !isNull[i] {
+			if vals[i] > max {
+				max = vals[i]
+			}
+			atLeastOneValid = true
+		}
+	}
+	if !atLeastOneValid {
+		return 0, true, nil
+	}
+	return max, false, nil
+}
+
+// allTrue returns true if every non-null value at the index positions in vals is true.
+// If all values are null, the final result is null.
+func allTrue(vals []bool, isNull []bool, index []int) (bool, bool) {
+	ret := true
+	var atLeastOneValid bool
+	for _, i := range index {
+		if !isNull[i] {
+			ret = ret && vals[i]
+			atLeastOneValid = true
+		}
+	}
+	if !atLeastOneValid {
+		return false, true
+	}
+	return ret, false
+}
+
+// anyTrue returns true if any non-null value at the index positions in vals is true.
+// If all values are null, the final result is null.
+func anyTrue(vals []bool, isNull []bool, index []int) (bool, bool) {
+	var ret bool
+	var atLeastOneValid bool
+	for _, i := range index {
+		if !isNull[i] {
+			ret = ret || vals[i]
+			atLeastOneValid = true
+		}
+	}
+	if !atLeastOneValid {
+		return false, true
+	}
+	return ret, false
+}
+
 // earliest returns the earliest of the non-null values at the index positions in vals.
 // Compatible with Grouped calculations as well as Series
 func earliest(vals []time.Time, isNull []bool, index []int) (time.Time, bool) {
@@ -2510,26 +3230,16 @@ func deduplicateContainerNames(containers []*valueContainer) {
 
 // returns the first row position each value appears
 func (vc *valueContainer) uniqueIndex() []int {
-	m := make(map[string]bool)
-	ret := make([]int, 0)
-	vc.setCache()
-	for i, value := range vc.cache {
-		if _, ok := m[string(value)]; !ok {
-			m[string(value)] = true
-			ret = append(ret, i)
-		}
-	}
-	return ret
+	return multiUniqueIndex([]*valueContainer{vc})
 }
 
 // returns the first row position each combination of values appears (accounting for all container values)
 func multiUniqueIndex(containers []*valueContainer) []int {
-	stringifiedRows := concatenateLabelsToStringsBytes(containers)
-	m := make(map[string]bool)
+	keys := newRowKeys(containers)
+	idx := newKeyIndex(keys)
 	ret := make([]int, 0)
-	for i, value := range stringifiedRows {
-		if _, ok := m[value]; !ok {
-			m[value] = true
+	for i := range keys.hashes {
+		if _, isNew := idx.add(i); isNew {
 			ret = append(ret, i)
 		}
 	}
@@ -2869,7 +3579,7 @@ func writeRecords(containers []*valueContainer, byColumn bool, numColLevels int)
 		headerSlots := make([]string, numColLevels)
 		// len(headers) should never be > numColLevels()
 		// if len(headers) < numColLevels(), excess header rows will remain blank
-		headers := splitNameIntoLevels(containers[k].name)
+		headers := splitNameIntoNumLevels(containers[k].name, numColLevels)
 		for l := range headers {
 			headerSlots[l] = headers[l]
 		}
@@ -2895,7 +3605,7 @@ func writeInterfaceRecords(containers []*valueContainer, byColumn bool, numColLe
 		headerSlots := make([]interface{}, numColLevels)
 		// len(headers) should never be > numColLevels()
 		// if len(headers) < numColLevels(), excess header rows will remain blank
-		headers := splitNameIntoLevels(containers[k].name)
+		headers := splitNameIntoNumLevels(containers[k].name, numColLevels)
 		for l := range headers {
 			headerSlots[l] = headers[l]
 		}
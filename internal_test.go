@@ -194,6 +194,31 @@ func Test_makeValueContainersFromInterfaces(t *testing.T) {
 	}
 }
 
+func Test_isIdentityIndex(t *testing.T) {
+	type args struct {
+		index []int
+		n     int
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{"identity", args{[]int{0, 1, 2}, 3}, true},
+		{"empty", args{[]int{}, 0}, true},
+		{"reordered", args{[]int{0, 2, 1}, 3}, false},
+		{"missing row", args{[]int{0, -1, 2}, 3}, false},
+		{"different length", args{[]int{0, 1}, 3}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isIdentityIndex(tt.args.index, tt.args.n); got != tt.want {
+				t.Errorf("isIdentityIndex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_findMatchingKeysBetweenTwoContainers(t *testing.T) {
 	type args struct {
 		labels1 []*valueContainer
@@ -498,7 +523,7 @@ func Test_lookup(t *testing.T) {
 			want: &Series{
 				values: &valueContainer{slice: []int{10, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"},
 				labels: []*valueContainer{
-					{slice: []int{0, 1}, isNull: []bool{false, false}, cache: []string{"0", "1"}},
+					{slice: []int{0, 1}, isNull: []bool{false, false}},
 				}}, wantErr: false,
 		},
 		{name: "right", args: args{
@@ -509,7 +534,7 @@ func Test_lookup(t *testing.T) {
 			want: &Series{
 				values: &valueContainer{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID},
 				labels: []*valueContainer{
-					{slice: []int{0, 10}, isNull: []bool{false, false}, cache: []string{"0", "10"}},
+					{slice: []int{0, 10}, isNull: []bool{false, false}},
 				}}, wantErr: false,
 		},
 		{name: "inner", args: args{
@@ -521,6 +546,31 @@ func Test_lookup(t *testing.T) {
 				values: &valueContainer{slice: []int{10}, isNull: []bool{false}, id: mockID},
 				labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}}}}, wantErr: false,
 		},
+		{name: "outer", args: args{
+			how: "outer", values1: &valueContainer{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+			labels1: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}}}, leftOn: []int{0},
+			values2: &valueContainer{slice: []int{10, 20}, isNull: []bool{false, false}, id: mockID},
+			labels2: []*valueContainer{{slice: []int{0, 10}, isNull: []bool{false, false}}}, rightOn: []int{0}},
+			want: &Series{
+				values: &valueContainer{slice: []int{10, 0, 20}, isNull: []bool{false, true, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 10}, isNull: []bool{false, false, false}}}}, wantErr: false,
+		},
+		{name: "cross", args: args{
+			how: "cross", values1: &valueContainer{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+			labels1: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}}},
+			values2: &valueContainer{slice: []int{10, 20}, isNull: []bool{false, false}, id: mockID},
+			labels2: []*valueContainer{{slice: []int{0, 10}, isNull: []bool{false, false}}}},
+			want: &Series{
+				values: &valueContainer{slice: []int{10, 20, 10, 20}, isNull: []bool{false, false, false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 0, 1, 1}, isNull: []bool{false, false, false, false}}}}, wantErr: false,
+		},
+		{name: "fail - unsupported", args: args{
+			how: "other", values1: &valueContainer{slice: []float64{1}, isNull: []bool{false}, id: mockID},
+			labels1: []*valueContainer{{slice: []int{0}, isNull: []bool{false}}}, leftOn: []int{0},
+			values2: &valueContainer{slice: []int{10}, isNull: []bool{false}, id: mockID},
+			labels2: []*valueContainer{{slice: []int{0}, isNull: []bool{false}}}, rightOn: []int{0}},
+			want: nil, wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -564,9 +614,8 @@ func Test_lookupDataFrame(t *testing.T) {
 			labels2: []*valueContainer{{slice: []int{0, 10}, isNull: []bool{false, false}, id: mockID, name: "quux"}}, rightOn: []int{0}},
 			want: &DataFrame{
 				values: []*valueContainer{{slice: []int{10, 0}, isNull: []bool{false, true}, id: mockID, name: "bar"}},
-				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "qux",
-					cache: []string{"0", "1"}}},
-				name: "baz", colLevelNames: []string{"*0"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+				name:   "baz", colLevelNames: []string{"*0"},
 			},
 			wantErr: false,
 		},
@@ -578,9 +627,8 @@ func Test_lookupDataFrame(t *testing.T) {
 			labels2: []*valueContainer{{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "quux"}}, rightOn: []int{0}},
 			want: &DataFrame{
 				values: []*valueContainer{{slice: []string{"", "c"}, isNull: []bool{true, false}, id: mockID, name: "bar"}},
-				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "qux",
-					cache: []string{"0", "1"}}},
-				name: "baz", colLevelNames: []string{"*0"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+				name:   "baz", colLevelNames: []string{"*0"},
 			},
 			wantErr: false,
 		},
@@ -592,9 +640,8 @@ func Test_lookupDataFrame(t *testing.T) {
 			labels2: []*valueContainer{{slice: []int{1, 1}, isNull: []bool{false, false}, id: mockID, name: "quux"}}, rightOn: []int{0}},
 			want: &DataFrame{
 				values: []*valueContainer{{slice: []string{"", "c"}, isNull: []bool{true, false}, id: mockID, name: "bar"}},
-				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "qux",
-					cache: []string{"0", "1"}}},
-				name: "baz", colLevelNames: []string{"*0"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+				name:   "baz", colLevelNames: []string{"*0"},
 			},
 			wantErr: false,
 		},
@@ -609,9 +656,8 @@ func Test_lookupDataFrame(t *testing.T) {
 			excludeRight: []string{"baz"}},
 			want: &DataFrame{
 				values: []*valueContainer{{slice: []string{"", "c"}, isNull: []bool{true, false}, id: mockID, name: "bar"}},
-				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "qux",
-					cache: []string{"0", "1"}}},
-				name: "baz", colLevelNames: []string{"*0"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+				name:   "baz", colLevelNames: []string{"*0"},
 			},
 			wantErr: false,
 		},
@@ -623,9 +669,8 @@ func Test_lookupDataFrame(t *testing.T) {
 			labels2: []*valueContainer{{slice: []int{0, 10}, isNull: []bool{false, false}, id: mockID, name: "quux"}}, rightOn: []int{0}},
 			want: &DataFrame{
 				values: []*valueContainer{{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"}},
-				labels: []*valueContainer{{slice: []int{0, 10}, isNull: []bool{false, false}, id: mockID, name: "quux",
-					cache: []string{"0", "10"}}},
-				name: "baz", colLevelNames: []string{"*0"},
+				labels: []*valueContainer{{slice: []int{0, 10}, isNull: []bool{false, false}, id: mockID, name: "quux"}},
+				name:   "baz", colLevelNames: []string{"*0"},
 			},
 			wantErr: false,
 		},
@@ -642,6 +687,28 @@ func Test_lookupDataFrame(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{name: "left_anti", args: args{
+			how: "left_anti", name: "baz", colLevelNames: []string{"*0"},
+			values1: []*valueContainer{{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+			labels1: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "qux"}}, leftOn: []int{0},
+			values2: []*valueContainer{{slice: []int{10, 20}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+			labels2: []*valueContainer{{slice: []int{0, 10}, isNull: []bool{false, false}, id: mockID, name: "quux"}}, rightOn: []int{0}},
+			want: &DataFrame{
+				values: []*valueContainer{{slice: []int{0}, isNull: []bool{true}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "qux"}},
+				name:   "baz", colLevelNames: []string{"*0"},
+			},
+			wantErr: false,
+		},
+		{name: "fail - unsupported", args: args{
+			how: "other", name: "baz", colLevelNames: []string{"*0"},
+			values1: []*valueContainer{{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+			labels1: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "qux"}}, leftOn: []int{0},
+			values2: []*valueContainer{{slice: []int{10, 20}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+			labels2: []*valueContainer{{slice: []int{0, 10}, isNull: []bool{false, false}, id: mockID, name: "quux"}}, rightOn: []int{0}},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -657,6 +724,131 @@ func Test_lookupDataFrame(t *testing.T) {
 	}
 }
 
+func Test_joinRows(t *testing.T) {
+	type args struct {
+		how       string
+		leftKeys  []*valueContainer
+		rightKeys []*valueContainer
+		leftLen   int
+		rightLen  int
+		expand    bool
+	}
+	left := []*valueContainer{{slice: []string{"a", "b", "c"}, isNull: []bool{false, false, false}}}
+	right := []*valueContainer{{slice: []string{"c", "d", "b"}, isNull: []bool{false, false, false}}}
+	repeated := []*valueContainer{{slice: []string{"b", "c", "b"}, isNull: []bool{false, false, false}}}
+	tests := []struct {
+		name      string
+		args      args
+		wantLeft  []int
+		wantRight []int
+		wantErr   bool
+	}{
+		{"left", args{"left", left, right, 3, 3, false}, []int{0, 1, 2}, []int{-1, 2, 0}, false},
+		{"inner", args{"inner", left, right, 3, 3, false}, []int{1, 2}, []int{2, 0}, false},
+		{"outer", args{"outer", left, right, 3, 3, false}, []int{0, 1, 2, -1}, []int{-1, 2, 0, 1}, false},
+		{"left_semi", args{"left_semi", left, right, 3, 3, false}, []int{1, 2}, []int{2, 0}, false},
+		{"left_anti", args{"left_anti", left, right, 3, 3, false}, []int{0}, []int{-1}, false},
+		{"cross", args{"cross", nil, nil, 2, 2, false}, []int{0, 0, 1, 1}, []int{0, 1, 0, 1}, false},
+		{"left - first match", args{"left", left, repeated, 3, 3, false}, []int{0, 1, 2}, []int{-1, 0, 1}, false},
+		{"left - expand", args{"left", left, repeated, 3, 3, true}, []int{0, 1, 1, 2}, []int{-1, 0, 2, 1}, false},
+		{"inner - expand many to many", args{"inner", repeated, repeated, 3, 3, true},
+			[]int{0, 0, 1, 2, 2}, []int{0, 2, 1, 0, 2}, false},
+		{"left_semi - no expand", args{"left_semi", left, repeated, 3, 3, true}, []int{1, 2}, []int{0, 1}, false},
+		{"fail - unsupported", args{"other", left, right, 3, 3, false}, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotLeft, gotRight, err := joinRows(tt.args.how, tt.args.leftKeys, tt.args.rightKeys, tt.args.leftLen, tt.args.rightLen, tt.args.expand)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("joinRows() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotLeft, tt.wantLeft) {
+				t.Errorf("joinRows() gotLeft = %v, want %v", gotLeft, tt.wantLeft)
+			}
+			if !reflect.DeepEqual(gotRight, tt.wantRight) {
+				t.Errorf("joinRows() gotRight = %v, want %v", gotRight, tt.wantRight)
+			}
+		})
+	}
+}
+
+func Test_validateJoinKeys(t *testing.T) {
+	unique := []*valueContainer{{slice: []string{"a", "b"}, isNull: []bool{false, false}}}
+	repeated := []*valueContainer{{slice: []string{"a", "a"}, isNull: []bool{false, false}}}
+	type args struct {
+		validate  string
+		leftKeys  []*valueContainer
+		rightKeys []*valueContainer
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{"1:1", args{"1:1", unique, unique}, nil},
+		{"1:m", args{"1:m", unique, repeated}, nil},
+		{"m:1", args{"m:1", repeated, unique}, nil},
+		{"m:m", args{"m:m", repeated, repeated}, nil},
+		{"no keys", args{"1:1", nil, nil}, nil},
+		{"fail - 1:1 left", args{"1:1", repeated, unique}, errors.New("validate (1:1): key (a) is repeated in left side")},
+		{"fail - 1:1 right", args{"1:1", unique, repeated}, errors.New("validate (1:1): key (a) is repeated in right side")},
+		{"fail - 1:m", args{"1:m", repeated, unique}, errors.New("validate (1:m): key (a) is repeated in left side")},
+		{"fail - m:1", args{"m:1", unique, repeated}, errors.New("validate (m:1): key (a) is repeated in right side")},
+		{"fail - unsupported", args{"1:2", unique, unique}, errors.New("validate: must be 1:1, 1:m, m:1, or m:m (not 1:2)")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateJoinKeys(tt.args.validate, tt.args.leftKeys, tt.args.rightKeys)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("validateJoinKeys() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_matchIntervals(t *testing.T) {
+	floats := func(vals ...float64) orderedKey {
+		return orderedKey{floats: vals, isNull: make([]bool, len(vals))}
+	}
+	type args struct {
+		key            orderedKey
+		keyGroups      []int
+		start          orderedKey
+		end            orderedKey
+		intervalGroups []int
+		closedStart    bool
+		closedEnd      bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][]int
+	}{
+		{"unsorted and overlapping",
+			args{floats(7, 1, 4), nil, floats(5, 0, 3), floats(9, 4, 6), nil, true, false},
+			[][]int{{0}, {1}, {2}}},
+		{"nested intervals in original order",
+			args{floats(5), nil, floats(4, 0, 5), floats(6, 10, 5), nil, true, true},
+			[][]int{{0, 1, 2}}},
+		{"open start",
+			args{floats(5), nil, floats(4, 0, 5), floats(6, 10, 5), nil, false, true},
+			[][]int{{0, 1}}},
+		{"groups",
+			args{floats(1, 1), []int{-1, 0}, floats(0, 0), floats(2, 2), []int{0, 1}, true, false},
+			[][]int{nil, {0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchIntervals(tt.args.key, tt.args.keyGroups, tt.args.start, tt.args.end, tt.args.intervalGroups,
+				tt.args.closedStart, tt.args.closedEnd)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matchIntervals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_difference(t *testing.T) {
 	type args struct {
 		slice1 []int
@@ -719,6 +911,17 @@ func Test_reduceContainers(t *testing.T) {
 			},
 			wantOriginalRowIndexes: [][]int{{0, 2}, {1}},
 			wantOrderedKeys:        []string{"bar", ""}},
+		{name: "multi level - separator in values",
+			args: args{containers: []*valueContainer{
+				{slice: []string{"a|b", "a", "a|b"}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+				{slice: []string{"c", "b|c", "c"}, isNull: []bool{false, false, false}, id: mockID, name: "baz"},
+			}},
+			wantNewContainers: []*valueContainer{
+				{slice: []string{"a|b", "a"}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				{slice: []string{"c", "b|c"}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+			},
+			wantOriginalRowIndexes: [][]int{{0, 2}, {1}},
+			wantOrderedKeys:        []string{`a\|b|c`, `a|b\|c`}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -736,26 +939,52 @@ func Test_reduceContainers(t *testing.T) {
 	}
 }
 
-func Test_reduceContainersForLookup(t *testing.T) {
+func Test_matchKeys(t *testing.T) {
 	type args struct {
-		containers []*valueContainer
+		containers1 []*valueContainer
+		containers2 []*valueContainer
 	}
 	tests := []struct {
 		name string
 		args args
-		want map[string]int
+		want []int
 	}{
-		{name: "single level",
-			args: args{containers: []*valueContainer{
-				{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
-				{slice: []string{"bar", "qux", "bar"}, isNull: []bool{false, false, false}, id: mockID, name: "baz"},
-			}},
-			want: map[string]int{"1|bar": 0, "2|qux": 1, "3|bar": 2}},
+		{name: "multiple levels",
+			args: args{
+				containers1: []*valueContainer{
+					{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+					{slice: []string{"bar", "qux", "bar"}, isNull: []bool{false, false, false}, id: mockID, name: "baz"},
+				},
+				containers2: []*valueContainer{
+					{slice: []float64{3, 1, 1}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+					{slice: []string{"bar", "qux", "bar"}, isNull: []bool{false, false, false}, id: mockID, name: "baz"},
+				}},
+			want: []int{2, -1, 0}},
+		{name: "separator in values",
+			args: args{
+				containers1: []*valueContainer{
+					{slice: []string{"a|b", "a"}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+					{slice: []string{"c", "b|c"}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+				},
+				containers2: []*valueContainer{
+					{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "foo"},
+					{slice: []string{"b|c"}, isNull: []bool{false}, id: mockID, name: "bar"},
+				}},
+			want: []int{-1, 0}},
+		{name: "different types are compared as strings",
+			args: args{
+				containers1: []*valueContainer{
+					{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				},
+				containers2: []*valueContainer{
+					{slice: []string{"2", "1"}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				}},
+			want: []int{1, 0}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := reduceContainersForLookup(tt.args.containers); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("reduceContainersForLookup() = %v, want %v", got, tt.want)
+			if got := matchKeys(tt.args.containers1, tt.args.containers2); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("matchKeys() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -1454,6 +1683,16 @@ func Test_sortContainers(t *testing.T) {
 				{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "bar"},
 			}, []Sorter{{Name: "foo", DType: DateTime}, {Name: "bar", DType: String}}},
 			[]int{2, 0, 3, 1}, false},
+		{"int64 - beyond float64 precision",
+			args{[]*valueContainer{
+				{slice: []int64{1<<53 + 1, 1 << 53, -1}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+			}, []Sorter{{Name: "foo", DType: Int64}}},
+			[]int{2, 1, 0}, false},
+		{"bool",
+			args{[]*valueContainer{
+				{slice: []bool{true, false, true}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+			}, []Sorter{{Name: "foo", DType: Bool}}},
+			[]int{1, 0, 2}, false},
 		{"fail - bad container",
 			args{[]*valueContainer{
 				{slice: []float64{2, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
@@ -1892,6 +2131,73 @@ func Test_sum(t *testing.T) {
 	}
 }
 
+func Test_joinLevelsIntoName(t *testing.T) {
+	tests := []struct {
+		name   string
+		levels []string
+		want   string
+	}{
+		{"single level", []string{"a|b"}, "a|b"},
+		{"multiple levels", []string{"a", "b"}, "a|b"},
+		{"separator in level", []string{"a|b", "c"}, `a\|b|c`},
+		{"backslash in level", []string{`a\`, "b"}, `a\\|b`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := joinLevelsIntoName(tt.levels)
+			if got != tt.want {
+				t.Errorf("joinLevelsIntoName() = %v, want %v", got, tt.want)
+			}
+			if split := splitNameIntoNumLevels(got, len(tt.levels)); !reflect.DeepEqual(split, tt.levels) {
+				t.Errorf("splitNameIntoNumLevels() = %v, want %v", split, tt.levels)
+			}
+		})
+	}
+}
+
+func Test_sumInt64(t *testing.T) {
+	type args struct {
+		vals   []int64
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int64
+		want1   bool
+		wantErr bool
+	}{
+		{"beyond float64 precision", args{
+			[]int64{1 << 53, 1, 3}, []bool{false, false, true}, []int{0, 1, 2}},
+			1<<53 + 1, false, false},
+		{"all null", args{
+			[]int64{1, 2, 3}, []bool{true, true, true}, []int{0, 1, 2}},
+			0, true, false},
+		{"fail - overflow", args{
+			[]int64{math.MaxInt64, 1}, []bool{false, false}, []int{0, 1}},
+			0, false, true},
+		{"fail - underflow", args{
+			[]int64{math.MinInt64, -1}, []bool{false, false}, []int{0, 1}},
+			0, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, err := sumInt64(tt.args.vals, tt.args.isNull, tt.args.index)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sumInt64() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("sumInt64() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("sumInt64() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
 func Test_mean(t *testing.T) {
 	type args struct {
 		vals   []float64
@@ -2452,9 +2758,7 @@ func Test_lookupWithAnchor(t *testing.T) {
 			&Series{
 				values: &valueContainer{slice: []string{"", "foo"}, isNull: []bool{true, false}, id: mockID, name: "waldo"},
 				labels: []*valueContainer{
-					{slice: []float64{0, 1}, isNull: []bool{false, false}, id: mockID, name: "foo",
-						cache: []string{"0", "1"},
-					}},
+					{slice: []float64{0, 1}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
 			}},
 	}
 	for _, tt := range tests {
@@ -2604,6 +2908,10 @@ func Test_multiUniqueIndex(t *testing.T) {
 			{slice: []float64{1, 1, 2, 1}, isNull: []bool{false, false, false, false}, id: mockID, name: "foo"},
 			{slice: []int{0, 0, 2, 3}, isNull: []bool{false, false, false, false}, id: mockID, name: "qux"},
 		}}, []int{0, 2, 3}},
+		{"separator in values", args{[]*valueContainer{
+			{slice: []string{"a|b", "a", "a"}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+			{slice: []string{"c", "b|c", "b|c"}, isNull: []bool{false, false, false}, id: mockID, name: "qux"},
+		}}, []int{0, 1}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3475,7 +3783,7 @@ func TestSeries_combineMath(t *testing.T) {
 			&Series{
 				values: &valueContainer{slice: []float64{3}, isNull: []bool{false}, id: mockID, name: "foo"},
 				labels: []*valueContainer{
-					{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "bar", cache: []string{"0"}},
+					{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "bar"},
 					{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "qux"}},
 			},
 		},
@@ -3748,36 +4056,6 @@ func Test_makeByteMatrix(t *testing.T) {
 	}
 }
 
-func Test_concatenateLabelsToStringsBytes(t *testing.T) {
-	type args struct {
-		labels []*valueContainer
-	}
-	tests := []struct {
-		name string
-		args args
-		want []string
-	}{
-		{"one level", args{labels: []*valueContainer{
-			{slice: []string{"foo", "bar"}}}},
-			[]string{"foo", "bar"}},
-		{"two levels, two index", args{labels: []*valueContainer{
-			{slice: []string{"foo", "bar"}},
-			{slice: []int{0, 1}}}},
-			[]string{"foo|0", "bar|1"}},
-		{"two levels, two index, cache", args{labels: []*valueContainer{
-			{slice: []string{"foo", "bar"}},
-			{slice: []int{0, 1}, cache: []string{"0", "1"}}}},
-			[]string{"foo|0", "bar|1"}},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := concatenateLabelsToStringsBytes(tt.args.labels); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("concatenateLabelsToStringsBytes() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func Test_filter(t *testing.T) {
 	type args struct {
 		containers []*valueContainer
@@ -4898,6 +5176,11 @@ type testStructNoTags struct {
 type testStructNoFields struct {
 }
 
+type testStructInt64 struct {
+	Count  int64 `json:"count"`
+	Active bool  `json:"active"`
+}
+
 func Test_readStructSlice(t *testing.T) {
 	type args struct {
 		slice  interface{}
@@ -4918,6 +5201,15 @@ func Test_readStructSlice(t *testing.T) {
 				{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "name"},
 				{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "age"}},
 			false},
+		{"pass - int64 and bool fields",
+			args{
+				[]testStructInt64{{1<<53 + 1, true}, {2, false}},
+				nil,
+			},
+			[]*valueContainer{
+				{slice: []int64{1<<53 + 1, 2}, isNull: []bool{false, false}, id: mockID, name: "count"},
+				{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "active"}},
+			false},
 		{"pass - missing field",
 			args{
 				[]testStruct{{Name: "foo"}, {Name: "bar"}},
@@ -5160,6 +5452,35 @@ func Test_writeStructSlice(t *testing.T) {
 			},
 			false,
 		},
+		{"pass - int64 and bool fields converted",
+			args{
+				[]*valueContainer{
+					{slice: []float64{1, 2}, isNull: []bool{false, false}, name: "count", id: mockID},
+					{slice: []string{"true", "false"}, isNull: []bool{false, false}, name: "active", id: mockID},
+				}, &[]testStructInt64{},
+				true,
+			},
+			&[]testStructInt64{
+				{1, true},
+				{2, false},
+			},
+			[][]bool{
+				{false, false},
+				{false, false},
+			},
+			false,
+		},
+		{"fail - int64 field cannot hold value",
+			args{
+				[]*valueContainer{
+					{slice: []float64{1, 2.5}, isNull: []bool{false, false}, name: "count", id: mockID},
+				}, &[]testStructInt64{},
+				false,
+			},
+			nil,
+			nil,
+			true,
+		},
 		{"fail - wrong type",
 			args{
 				[]*valueContainer{
@@ -5301,6 +5622,14 @@ func Test_castToInferredTypes(t *testing.T) {
 				{slice: []float64{.5}, isNull: []bool{false}, name: "bar", id: mockID, cache: []string{".5"}},
 			},
 		},
+		{"integers in sample, then a fraction", args{[]*valueContainer{
+			{slice: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "10.5"}, isNull: make([]bool, 11), name: "foo", id: mockID},
+		}},
+			[]*valueContainer{
+				{slice: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 10.5}, isNull: make([]bool, 11), name: "foo", id: mockID,
+					cache: []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "10.5"}},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -5312,6 +5641,44 @@ func Test_castToInferredTypes(t *testing.T) {
 	}
 }
 
+func Test_parseStringAs(t *testing.T) {
+	type args struct {
+		s      string
+		dtype  DType
+		layout string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    interface{}
+		wantErr bool
+	}{
+		{"float", args{"1.5", Float64, ""}, 1.5, false},
+		{"string", args{"foo", String, ""}, "foo", false},
+		{"datetime - default formats", args{"1/1/2020", DateTime, ""}, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"datetime - layout", args{"2020/01/02", DateTime, "2006/01/02"}, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), false},
+		{"date - civil", args{"2020-01-02", Date, ""}, civil.Date{Year: 2020, Month: 1, Day: 2}, false},
+		{"time - civil", args{"12:30:00", Time, ""}, civil.Time{Hour: 12, Minute: 30}, false},
+		{"time - layout", args{"1:30PM", Time, "3:04PM"}, civil.Time{Hour: 13, Minute: 30}, false},
+		{"fail - float", args{"foo", Float64, ""}, nil, true},
+		{"fail - datetime", args{"foo", DateTime, ""}, nil, true},
+		{"fail - layout", args{"2020-01-02", Date, "2006/01/02"}, nil, true},
+		{"fail - unsupported", args{"foo", DType(100), ""}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStringAs(tt.args.s, tt.args.dtype, tt.args.layout)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseStringAs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseStringAs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_readNestedInterfaceByCols(t *testing.T) {
 	type args struct {
 		columns         [][]interface{}
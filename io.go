@@ -1,11 +1,15 @@
 package tada
 
 import (
+	"bufio"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"reflect"
+	"sort"
+	"strings"
+	"unicode"
 
 	"github.com/d4l3k/messagediff"
 	"github.com/ptiger10/tablediff"
@@ -217,7 +221,13 @@ type RecordReader struct {
 	Name              string
 	InferTypes        bool
 	BlankStringAsNull bool
-	records           [][]string
+	// Schema maps column names (or positions, e.g. "0", if there are no header rows) to the way their values are parsed.
+	// Columns that are not in Schema are read as usual.
+	Schema map[string]ColumnSchema
+	// RejectBadRows removes rows with any value that cannot be parsed according to Schema,
+	// instead of returning an error for the first such value.
+	RejectBadRows bool
+	records       [][]string
 }
 
 // NewRecordReader returns a default RecordReader.
@@ -233,13 +243,30 @@ func NewRecordReader(records [][]string) RecordReader {
 }
 
 // Read reads [][]string records to a DataFrame.
-// All columns will be read as []string.
+// All columns will be read as []string, unless they are declared in r.Schema or r.InferTypes = true.
+// If r.InferTypes is true, each remaining column is cast to the type of most of its first 10 values
+// (integers are read as []int64 unless they are mixed with other numbers, and true/false values as []bool).
 // Records are read with row as the major dimension, unless r.ByColumn = true.
 //
+// Each column in r.Schema is parsed exactly as declared, and empty values in non-String columns are read as null.
+// If a value cannot be parsed, returns an error that identifies its row (zero-indexed, excluding headers) and column,
+// unless r.RejectBadRows = true (see ReadWithRejects).
+//
 // If no label levels are supplied, a default label level is inserted ([]int incrementing from 0).
 // If no headers are supplied, a default level of sequential column names (e.g., 0, 1, etc) is used. Default column names are displayed on printing.
 // Label levels are named *i (e.g., *0, *1, etc) by default when first created. Default label names are hidden on printing.
 func (r RecordReader) Read() (*DataFrame, error) {
+	df, _, err := r.ReadWithRejects()
+	return df, err
+}
+
+// ReadWithRejects reads [][]string records to a DataFrame, and also returns the rows that were removed
+// because r.RejectBadRows = true and at least one of their values could not be parsed according to r.Schema.
+// The rejected DataFrame contains the original text of every column in the rejected rows
+// and an "error" column that describes the first parse failure in each row.
+// Its label level ("row") is the position of each rejected row (zero-indexed, excluding headers).
+// If no rows were rejected, rejected is nil.
+func (r RecordReader) ReadWithRejects() (df *DataFrame, rejected *DataFrame, err error) {
 	if r.BlankStringAsNull {
 		_, ok := optionNullStrings.Read()[""]
 		if !ok {
@@ -251,20 +278,36 @@ func (r RecordReader) Read() (*DataFrame, error) {
 	}
 
 	if len(r.records) == 0 {
-		return nil, fmt.Errorf("reading csv from records: must have at least one record")
+		return nil, nil, fmt.Errorf("reading csv from records: must have at least one record")
 	}
 	if len(r.records[0]) == 0 {
-		return nil, fmt.Errorf("reading csv from records: first record cannot be empty")
+		return nil, nil, fmt.Errorf("reading csv from records: first record cannot be empty")
 	}
 	vc, err := readRecords(r.records, r.ByColumn, r.HeaderRows)
 	if err != nil {
-		return nil, fmt.Errorf("reading csv from records: %v", err)
+		return nil, nil, fmt.Errorf("reading csv from records: %v", err)
+	}
+	// parsing and casting replace (but do not modify) the original []string values
+	original := make([][]string, len(vc))
+	for k := range vc {
+		original[k] = vc[k].slice.([]string)
+	}
+	parsed, rejects, err := applySchema(vc, r.Schema, r.HeaderRows > 0, r.RejectBadRows)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading csv from records: %v", err)
 	}
 	if r.InferTypes {
-		castToInferredTypes(vc)
+		for k := range vc {
+			if !parsed[k] {
+				castToInferredTypes(vc[k : k+1])
+			}
+		}
 	}
-	df := containersToDF(vc, r.HeaderRows, r.LabelLevels, r.Name)
-	return df, nil
+	if len(rejects) > 0 {
+		rejected = rejectRows(vc, original, rejects, r.HeaderRows, r.Name)
+	}
+	df = containersToDF(vc, r.HeaderRows, r.LabelLevels, r.Name)
+	return df, rejected, nil
 }
 
 // RecordWriter writes [][]string records from a DataFrame.
@@ -304,6 +347,26 @@ func (w *RecordWriter) Write(df *DataFrame) error {
 type CSVReader struct {
 	RecordReader
 	*csv.Reader
+	// Lenient skips records that are malformed (e.g., with a bare quote or the wrong number of fields)
+	// or that have a value that cannot be parsed according to r.Schema, instead of returning an error.
+	// Skipped records are reported with their line numbers and original text (see Rejected).
+	// Lenient must be set before the first read, and requires a CSVReader created by NewCSVReader
+	// (which retains the underlying io.Reader so that the original text of each record can be reported).
+	Lenient   bool
+	headers   [][]string
+	rowsRead  int
+	src       io.Reader
+	raw       *bufio.Reader
+	line      int
+	numFields int
+	rejected  []csvReject
+}
+
+// a csvReject is a record that was skipped in lenient mode.
+type csvReject struct {
+	line int
+	text string
+	err  string
 }
 
 // Records returns the [][]string records after they have been read.
@@ -319,20 +382,291 @@ func NewCSVReader(r io.Reader) *CSVReader {
 			LabelLevels: 0,
 		},
 		Reader: csv.NewReader(r),
+		src:    r,
 	}
 }
 
 // Read reads a DataFrame from a encoding/csv.Reader
 func (r *CSVReader) Read() (*DataFrame, error) {
-	records, err := r.ReadAll()
+	df, _, err := r.ReadWithRejects()
+	return df, err
+}
+
+// ReadWithRejects reads a DataFrame from a encoding/csv.Reader,
+// and also returns the rows that were rejected according to r.Schema (see RecordReader.ReadWithRejects).
+// If r.Lenient = true, the rejected DataFrame is instead the same report returned by Rejected(),
+// limited to the records skipped during this read.
+func (r *CSVReader) ReadWithRejects() (*DataFrame, *DataFrame, error) {
+	if !r.Lenient {
+		records, err := r.ReadAll()
+		if err != nil {
+			return nil, nil, fmt.Errorf("CSVReader: %v", err)
+		}
+		r.records = records
+		df, rejected, err := r.RecordReader.ReadWithRejects()
+		if err != nil {
+			return nil, nil, fmt.Errorf("CSVReader: %v", err)
+		}
+		return df, rejected, nil
+	}
+	if r.ByColumn {
+		return nil, nil, fmt.Errorf("CSVReader: cannot read leniently when ByColumn is true")
+	}
+	start := len(r.rejected)
+	var records [][]string
+	var lines []int
+	var texts []string
+	for {
+		record, line, text, err := r.nextRecord()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("CSVReader: %v", err)
+		}
+		records = append(records, record)
+		lines = append(lines, line)
+		texts = append(texts, text)
+	}
+	r.records = records
+	df, err := r.readLenient(records, lines, texts, start)
 	if err != nil {
-		return nil, fmt.Errorf("CSVReader: %v", err)
+		return nil, nil, fmt.Errorf("CSVReader: %v", err)
+	}
+	return df, csvRejectsToDF(r.rejected[start:]), nil
+}
+
+// Rejected returns a report of every record skipped so far in lenient mode (by Read, ReadWithRejects, or ReadChunk),
+// in the order in which they appear in the input. The report has a "line" label level
+// (the line on which the record starts, beginning at 1), a "text" column (the original text of the record),
+// and an "error" column (the reason the record was skipped). If no records have been skipped, returns nil.
+func (r *CSVReader) Rejected() *DataFrame {
+	return csvRejectsToDF(r.rejected)
+}
+
+// nextRecord returns the next record, the line on which it starts, and its original text.
+// In lenient mode, malformed records are skipped and retained in r.rejected.
+// Otherwise, line numbers and text are not tracked.
+func (r *CSVReader) nextRecord() ([]string, int, string, error) {
+	if !r.Lenient {
+		record, err := r.Reader.Read()
+		return record, 0, "", err
+	}
+	if r.raw == nil {
+		if r.src == nil {
+			return nil, 0, "", fmt.Errorf("cannot read leniently without an underlying io.Reader (use NewCSVReader)")
+		}
+		r.raw = bufio.NewReader(r.src)
+		if r.FieldsPerRecord > 0 {
+			r.numFields = r.FieldsPerRecord
+		}
+	}
+	for {
+		line := r.line + 1
+		var text string
+		for {
+			s, err := r.raw.ReadString('\n')
+			if s != "" {
+				r.line++
+				text += s
+			}
+			if err == io.EOF {
+				if text == "" {
+					return nil, 0, "", io.EOF
+				}
+				break
+			}
+			if err != nil {
+				return nil, 0, "", err
+			}
+			// a record continues onto the next line if a quoted field contains a newline
+			if !r.inQuotedField(text) {
+				break
+			}
+		}
+		text = strings.TrimRight(text, "\r\n")
+		if text == "" {
+			continue
+		}
+		record, err := r.parseRecord(text)
+		if err == io.EOF {
+			// comment
+			continue
+		}
+		if err == nil && r.numFields > 0 && len(record) != r.numFields {
+			err = fmt.Errorf("wrong number of fields (%d, want %d)", len(record), r.numFields)
+		}
+		if err != nil {
+			// without a well-formed first record, there is no way to tell what the data should look like
+			if r.numFields == 0 {
+				return nil, 0, "", fmt.Errorf("line %d: %v", line, err)
+			}
+			r.rejected = append(r.rejected, csvReject{line: line, text: text, err: err.Error()})
+			continue
+		}
+		if r.numFields == 0 {
+			r.numFields = len(record)
+		}
+		return record, line, text, nil
+	}
+}
+
+// inQuotedField reports whether text ends within a quoted field (quotes within unquoted fields are literal).
+func (r *CSVReader) inQuotedField(text string) bool {
+	var quoted, fieldStart bool
+	fieldStart = true
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case quoted:
+			if c == '"' {
+				if i+1 < len(runes) && runes[i+1] == '"' {
+					i++
+				} else {
+					quoted = false
+				}
+			}
+		case c == r.Comma || c == '\n':
+			fieldStart = true
+			continue
+		case fieldStart && c == '"':
+			quoted = true
+		case fieldStart && r.TrimLeadingSpace && unicode.IsSpace(c):
+			continue
+		}
+		fieldStart = false
+	}
+	return quoted
+}
+
+// parseRecord parses the text of one record with the same settings as the embedded encoding/csv.Reader.
+func (r *CSVReader) parseRecord(text string) ([]string, error) {
+	cr := csv.NewReader(strings.NewReader(text))
+	cr.Comma = r.Comma
+	cr.Comment = r.Comment
+	cr.LazyQuotes = r.LazyQuotes
+	cr.TrimLeadingSpace = r.TrimLeadingSpace
+	cr.FieldsPerRecord = -1
+	record, err := cr.Read()
+	if pe, ok := err.(*csv.ParseError); ok {
+		return nil, fmt.Errorf("column %d: %v", pe.Column, pe.Err)
+	}
+	return record, err
+}
+
+// readLenient reads records into a DataFrame, rejecting rows that cannot be parsed according to r.Schema.
+// lines and texts are the line numbers and original text of each record.
+// Rejected rows are added to r.rejected, and r.rejected[start:] is sorted by line.
+func (r *CSVReader) readLenient(records [][]string, lines []int, texts []string, start int) (*DataFrame, error) {
+	rr := r.RecordReader
+	rr.RejectBadRows = true
+	rr.records = records
+	df, rejected, err := rr.ReadWithRejects()
+	if err != nil {
+		return nil, err
+	}
+	if rejected != nil {
+		rows := rejected.labels[0].slice.([]int)
+		msgs := rejected.values[len(rejected.values)-1].slice.([]string)
+		for i, row := range rows {
+			index := r.HeaderRows + row
+			r.rejected = append(r.rejected, csvReject{line: lines[index], text: texts[index], err: msgs[i]})
+		}
+	}
+	rejects := r.rejected[start:]
+	sort.SliceStable(rejects, func(i, j int) bool {
+		return rejects[i].line < rejects[j].line
+	})
+	return df, nil
+}
+
+func csvRejectsToDF(rejects []csvReject) *DataFrame {
+	if len(rejects) == 0 {
+		return nil
+	}
+	lines := make([]int, len(rejects))
+	texts := make([]string, len(rejects))
+	msgs := make([]string, len(rejects))
+	for i := range rejects {
+		lines[i] = rejects[i].line
+		texts[i] = rejects[i].text
+		msgs[i] = rejects[i].err
+	}
+	containers := []*valueContainer{
+		newValueContainer(lines, make([]bool, len(rejects)), "line"),
+		newValueContainer(texts, make([]bool, len(rejects)), "text"),
+		newValueContainer(msgs, make([]bool, len(rejects)), "error"),
+	}
+	return containersToDF(containers, 1, 1, "")
+}
+
+// ReadChunk reads the next n rows from the embedded encoding/csv.Reader into a DataFrame.
+// On the first call, r.HeaderRows header rows are read and retained,
+// and every subsequent chunk shares the same column names.
+// r.InferTypes and r.LabelLevels are applied to each chunk independently.
+// If no label levels are supplied, the default labels continue incrementing across chunks,
+// so that successive chunks may be combined with DataFrame.Append().
+// The final chunk may contain fewer than n rows.
+// If r.Lenient = true, skipped records do not count toward n and are reported by Rejected().
+// Once all rows have been read, returns a nil DataFrame and io.EOF.
+func (r *CSVReader) ReadChunk(n int) (*DataFrame, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("CSVReader: reading chunk: n must be greater than 0 (%d)", n)
+	}
+	if r.ByColumn {
+		return nil, fmt.Errorf("CSVReader: reading chunk: cannot read chunks when ByColumn is true")
+	}
+	if r.headers == nil {
+		r.headers = make([][]string, 0, r.HeaderRows)
+		for l := 0; l < r.HeaderRows; l++ {
+			record, _, _, err := r.nextRecord()
+			if err != nil {
+				if err == io.EOF && l == 0 {
+					return nil, io.EOF
+				}
+				return nil, fmt.Errorf("CSVReader: reading chunk: header row %d: %v", l, err)
+			}
+			r.headers = append(r.headers, record)
+		}
+	}
+	start := len(r.rejected)
+	records := make([][]string, len(r.headers), len(r.headers)+n)
+	copy(records, r.headers)
+	lines := make([]int, len(r.headers))
+	texts := make([]string, len(r.headers))
+	for i := 0; i < n; i++ {
+		record, line, text, err := r.nextRecord()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSVReader: reading chunk: %v", err)
+		}
+		records = append(records, record)
+		lines = append(lines, line)
+		texts = append(texts, text)
+	}
+	numRows := len(records) - len(r.headers)
+	if numRows == 0 {
+		return nil, io.EOF
 	}
 	r.records = records
-	df, err := r.RecordReader.Read()
+	var df *DataFrame
+	var err error
+	if r.Lenient {
+		df, err = r.readLenient(records, lines, texts, start)
+	} else {
+		df, err = r.RecordReader.Read()
+	}
 	if err != nil {
-		return nil, fmt.Errorf("CSVReader: %v", err)
+		return nil, fmt.Errorf("CSVReader: reading chunk: %v", err)
+	}
+	if r.LabelLevels == 0 {
+		// rows rejected according to r.Schema are not included in the chunk
+		df.labels[0] = makeDefaultLabels(r.rowsRead, r.rowsRead+df.Len(), true)
 	}
+	r.rowsRead += numRows
 	return df, nil
 }
 
@@ -340,6 +674,11 @@ func (r *CSVReader) Read() (*DataFrame, error) {
 type CSVWriter struct {
 	*RecordWriter
 	*csv.Writer
+	// Compression compresses the output of each Write (default: NoCompression).
+	// The settings of the embedded encoding/csv.Writer (e.g., Comma) still apply.
+	// Compression requires a CSVWriter created by NewCSVWriter (which retains the underlying io.Writer).
+	Compression Compression
+	w           io.Writer
 }
 
 // NewCSVWriter creates a new *CSVWriter with embedded encoding/csv.Writer and default settings.
@@ -350,12 +689,36 @@ func NewCSVWriter(w io.Writer) *CSVWriter {
 			ByColumn:      false,
 		},
 		Writer: csv.NewWriter(w),
+		w:      w,
 	}
 }
 
+// Write writes df to the embedded encoding/csv.Writer.
+// If w.Compression is set, each call to Write produces a complete compressed stream.
 func (w *CSVWriter) Write(df *DataFrame) error {
 	w.RecordWriter.Write(df)
-	return w.Writer.WriteAll(w.Records())
+	if w.Compression == NoCompression {
+		return w.Writer.WriteAll(w.Records())
+	}
+	if w.w == nil {
+		return fmt.Errorf("writing csv: cannot compress without an underlying io.Writer (use NewCSVWriter)")
+	}
+	cw, err := NewCompressingWriter(w.w, w.Compression)
+	if err != nil {
+		return fmt.Errorf("writing csv: %v", err)
+	}
+	csvWriter := csv.NewWriter(cw)
+	csvWriter.Comma = w.Comma
+	csvWriter.UseCRLF = w.UseCRLF
+	err = csvWriter.WriteAll(w.Records())
+	if err != nil {
+		return fmt.Errorf("writing csv: %v", err)
+	}
+	err = cw.Close()
+	if err != nil {
+		return fmt.Errorf("writing csv: %v", err)
+	}
+	return nil
 }
 
 // -- [][]interface{} records
@@ -531,6 +894,131 @@ func (r MatrixReader) Read() (*DataFrame, error) {
 	return df, nil
 }
 
+// MatrixWriter writes DataFrame columns into a *DenseMatrix.
+type MatrixWriter struct {
+	// Columns are the names of the columns to write, in order (default: every column).
+	Columns    []string
+	NullPolicy NullPolicy
+	// FillValue replaces null values if NullPolicy is NullPolicyFill.
+	FillValue float64
+	matrix    *DenseMatrix
+}
+
+// NewMatrixWriter returns a *MatrixWriter with default settings.
+// By default, every column is written, and a null value returns an error.
+func NewMatrixWriter() *MatrixWriter {
+	return &MatrixWriter{
+		NullPolicy: NullPolicyError,
+	}
+}
+
+// Matrix returns the *DenseMatrix written to w.
+func (w *MatrixWriter) Matrix() *DenseMatrix {
+	return w.matrix
+}
+
+// Write casts each selected column of df to float64 and writes the values into a *DenseMatrix
+// with one row per row in df and one column per selected column.
+// Values that cannot be converted to float64 (e.g., non-numeric strings) are treated as null values,
+// which are handled according to w.NullPolicy.
+func (w *MatrixWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing matrix: %v", df.err)
+	}
+	index := makeIntRange(0, len(df.values))
+	if len(w.Columns) > 0 {
+		var err error
+		index, err = indexOfContainers(w.Columns, df.values)
+		if err != nil {
+			return fmt.Errorf("writing matrix: %v", err)
+		}
+	}
+	cols := make([]floatValueContainer, len(index))
+	for k := range index {
+		cols[k] = df.values[index[k]].copy().float64()
+	}
+	rows := makeIntRange(0, df.Len())
+	switch w.NullPolicy {
+	case NullPolicyError:
+		for k := range cols {
+			for i := range cols[k].isNull {
+				if cols[k].isNull[i] {
+					return fmt.Errorf("writing matrix: column (%s): row %d: null value",
+						df.values[index[k]].name, i)
+				}
+			}
+		}
+	case NullPolicyDrop:
+		rows = rows[:0]
+		for i := 0; i < df.Len(); i++ {
+			valid := true
+			for k := range cols {
+				if cols[k].isNull[i] {
+					valid = false
+					break
+				}
+			}
+			if valid {
+				rows = append(rows, i)
+			}
+		}
+	case NullPolicyFill:
+	default:
+		return fmt.Errorf("writing matrix: unsupported NullPolicy (%d)", w.NullPolicy)
+	}
+	data := make([]float64, len(rows)*len(cols))
+	for r, i := range rows {
+		for k := range cols {
+			if cols[k].isNull[i] {
+				data[r*len(cols)+k] = w.FillValue
+			} else {
+				data[r*len(cols)+k] = cols[k].slice[i]
+			}
+		}
+	}
+	w.matrix = NewDenseMatrix(len(rows), len(cols), data)
+	return nil
+}
+
+// NewDenseMatrix returns a *DenseMatrix with r rows and c columns, backed by data (in row-major order).
+// Panics if len(data) != r*c.
+func NewDenseMatrix(r, c int, data []float64) *DenseMatrix {
+	if len(data) != r*c {
+		panic(fmt.Sprintf("NewDenseMatrix(): len(data) (%d) must equal r*c (%d)", len(data), r*c))
+	}
+	return &DenseMatrix{rows: r, cols: c, data: data}
+}
+
+// Dims returns the number of rows and columns in m.
+func (m *DenseMatrix) Dims() (r, c int) {
+	return m.rows, m.cols
+}
+
+// At returns the value at row i and column j. Panics if i or j is out of range.
+func (m *DenseMatrix) At(i, j int) float64 {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic(fmt.Sprintf("DenseMatrix.At(): index (%d, %d) out of range (%d, %d)", i, j, m.rows, m.cols))
+	}
+	return m.data[i*m.cols+j]
+}
+
+// T returns a transposed copy of m.
+func (m *DenseMatrix) T() Matrix {
+	data := make([]float64, len(m.data))
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			data[j*m.rows+i] = m.data[i*m.cols+j]
+		}
+	}
+	return NewDenseMatrix(m.cols, m.rows, data)
+}
+
+// RawData returns the row-major slice that backs m (not a copy).
+// For example, to create a gonum matrix: r, c := m.Dims(); mat.NewDense(r, c, m.RawData()).
+func (m *DenseMatrix) RawData() []float64 {
+	return m.data
+}
+
 // -- WRITERS
 
 // WriteMockCSV reads r, infers the types, and writes n mock rows to w.
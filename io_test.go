@@ -3,9 +3,12 @@ package tada
 import (
 	"bytes"
 	"encoding/csv"
+	"errors"
+	"io"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/civil"
 	"github.com/ptiger10/tablediff"
@@ -172,6 +175,7 @@ func TestRecordReader_Read(t *testing.T) {
 		ByColumn          bool
 		BlankStringAsNull bool
 		InferTypes        bool
+		Schema            map[string]ColumnSchema
 		records           [][]string
 	}
 	tests := []struct {
@@ -180,6 +184,65 @@ func TestRecordReader_Read(t *testing.T) {
 		want    *DataFrame
 		wantErr bool
 	}{
+		{"schema",
+			fields{
+				HeaderRows: 1,
+				InferTypes: true,
+				Schema: map[string]ColumnSchema{
+					"foo": {DType: Float64, NullTokens: []string{"-"}},
+					"bar": {DType: Date, Layout: "02.01.2006"},
+					"baz": {DType: String},
+				},
+				records: [][]string{{"foo", "bar", "baz", "qux"}, {"1", "31.12.2020", "2", "3"}, {"-", "", "", "4"}},
+			},
+			&DataFrame{values: []*valueContainer{
+				{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+				{slice: []civil.Date{{Year: 2020, Month: 12, Day: 31}, {}}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+				{slice: []string{"2", ""}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+				{slice: []int64{3, 4}, isNull: []bool{false, false}, id: mockID, name: "qux", cache: []string{"3", "4"}}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"schema by position without headers",
+			fields{
+				HeaderRows: 0,
+				Schema: map[string]ColumnSchema{
+					"1": {DType: DateTime},
+				},
+				records: [][]string{{"foo", "2020-01-01T00:00:00Z"}},
+			},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"foo"}, isNull: []bool{false}, id: mockID, name: "0"},
+				{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, isNull: []bool{false}, id: mockID, name: "1"}},
+				labels: []*valueContainer{
+					{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - schema parse error",
+			fields{
+				HeaderRows: 1,
+				Schema:     map[string]ColumnSchema{"foo": {DType: Float64}},
+				records:    [][]string{{"foo"}, {"1"}, {"bar"}},
+			},
+			nil,
+			true},
+		{"fail - int64 schema parse error",
+			fields{
+				HeaderRows: 1,
+				Schema:     map[string]ColumnSchema{"foo": {DType: Int64}},
+				records:    [][]string{{"foo"}, {"1"}, {"1.5"}},
+			},
+			nil,
+			true},
+		{"fail - schema column not found",
+			fields{
+				HeaderRows: 1,
+				Schema:     map[string]ColumnSchema{"bar": {DType: Float64}},
+				records:    [][]string{{"foo"}, {"1"}},
+			},
+			nil,
+			true},
 		{"empty space as null",
 			fields{
 				HeaderRows:        1,
@@ -206,7 +269,7 @@ func TestRecordReader_Read(t *testing.T) {
 			},
 			&DataFrame{values: []*valueContainer{
 				{slice: []string{"qux"}, isNull: []bool{false}, id: mockID, name: "foo", cache: []string{"qux"}},
-				{slice: []float64{2}, isNull: []bool{false}, id: mockID, name: "bar", cache: []string{"2"}},
+				{slice: []int64{2}, isNull: []bool{false}, id: mockID, name: "bar", cache: []string{"2"}},
 				{slice: []civil.Date{{Year: 2020, Month: 1, Day: 1}}, isNull: []bool{false}, id: mockID, name: "baz", cache: []string{"1/1/2020"}}},
 				labels: []*valueContainer{
 					{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
@@ -244,6 +307,7 @@ func TestRecordReader_Read(t *testing.T) {
 				ByColumn:          tt.fields.ByColumn,
 				BlankStringAsNull: tt.fields.BlankStringAsNull,
 				InferTypes:        tt.fields.InferTypes,
+				Schema:            tt.fields.Schema,
 				records:           tt.fields.records,
 			}
 			got, err := r.Read()
@@ -258,6 +322,89 @@ func TestRecordReader_Read(t *testing.T) {
 	}
 }
 
+func TestRecordReader_ReadWithRejects(t *testing.T) {
+	type fields struct {
+		LabelLevels   int
+		Schema        map[string]ColumnSchema
+		RejectBadRows bool
+		records       [][]string
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		want         *DataFrame
+		wantRejected *DataFrame
+		wantErr      bool
+	}{
+		{"reject bad rows",
+			fields{
+				LabelLevels:   1,
+				Schema:        map[string]ColumnSchema{"bar": {DType: Float64}, "baz": {DType: DateTime, Layout: "2006-01-02"}},
+				RejectBadRows: true,
+				records: [][]string{
+					{"foo", "bar", "baz"}, {"a", "1", "2020-01-01"}, {"b", "x", "y"}, {"c", "3", "z"}, {"d", "", "2020-01-04"}},
+			},
+			&DataFrame{values: []*valueContainer{
+				{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+				{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 4, 0, 0, 0, 0, time.UTC)},
+					isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				labels: []*valueContainer{
+					{slice: []string{"a", "d"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				colLevelNames: []string{"*0"}},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"b", "c"}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				{slice: []string{"x", "3"}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+				{slice: []string{"y", "z"}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+				{slice: []string{
+					`column bar: cannot parse "x" as Float64`,
+					`column baz: cannot parse "z" as DateTime`}, isNull: []bool{false, false}, id: mockID, name: "error"}},
+				labels: []*valueContainer{
+					{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "row"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"no rejects",
+			fields{
+				Schema:        map[string]ColumnSchema{"foo": {DType: Float64}},
+				RejectBadRows: true,
+				records:       [][]string{{"foo"}, {"1"}},
+			},
+			&DataFrame{values: []*valueContainer{
+				{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"}},
+				labels: []*valueContainer{
+					{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			nil,
+			false},
+		{"fail - bad row without RejectBadRows",
+			fields{
+				Schema:  map[string]ColumnSchema{"foo": {DType: Float64}},
+				records: [][]string{{"foo"}, {"bar"}},
+			},
+			nil,
+			nil,
+			true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRecordReader(tt.fields.records)
+			r.LabelLevels = tt.fields.LabelLevels
+			r.Schema = tt.fields.Schema
+			r.RejectBadRows = tt.fields.RejectBadRows
+			got, gotRejected, err := r.ReadWithRejects()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RecordReader.ReadWithRejects() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("RecordReader.ReadWithRejects() got = %v, want %v", got, tt.want)
+			}
+			if !EqualDataFrames(gotRejected, tt.wantRejected) {
+				t.Errorf("RecordReader.ReadWithRejects() rejected = %v, want %v", gotRejected, tt.wantRejected)
+			}
+		})
+	}
+}
+
 func TestRecordWriter_Write(t *testing.T) {
 	type fields struct {
 		IncludeLabels bool
@@ -503,6 +650,230 @@ func TestCSVReader_Read(t *testing.T) {
 	}
 }
 
+func TestCSVReader_ReadChunk(t *testing.T) {
+	type fields struct {
+		RecordReader RecordReader
+		Reader       *csv.Reader
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		n       int
+		want    []*DataFrame
+		wantErr bool
+	}{
+		{"pass - two chunks", fields{
+			RecordReader: RecordReader{HeaderRows: 1},
+			Reader:       csv.NewReader(strings.NewReader("Name,Age\nfoo,1\nbar,2\nbaz,3")),
+		},
+			2,
+			[]*DataFrame{
+				{values: []*valueContainer{
+					{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "Name"},
+					{slice: []string{"1", "2"}, isNull: []bool{false, false}, id: mockID, name: "Age"}},
+					labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+					colLevelNames: []string{"*0"}},
+				{values: []*valueContainer{
+					{slice: []string{"baz"}, isNull: []bool{false}, id: mockID, name: "Name"},
+					{slice: []string{"3"}, isNull: []bool{false}, id: mockID, name: "Age"}},
+					labels:        []*valueContainer{{slice: []int{2}, isNull: []bool{false}, id: mockID, name: "*0"}},
+					colLevelNames: []string{"*0"}},
+			},
+			false,
+		},
+		{"pass - infer types and label levels", fields{
+			RecordReader: RecordReader{HeaderRows: 1, LabelLevels: 1, InferTypes: true},
+			Reader:       csv.NewReader(strings.NewReader("Name,Age\nfoo,1\nbar,2")),
+		},
+			1,
+			[]*DataFrame{
+				{values: []*valueContainer{
+					{slice: []int64{1}, isNull: []bool{false}, id: mockID, name: "Age", cache: []string{"1"}}},
+					labels:        []*valueContainer{{slice: []string{"foo"}, isNull: []bool{false}, id: mockID, name: "Name", cache: []string{"foo"}}},
+					colLevelNames: []string{"*0"}},
+				{values: []*valueContainer{
+					{slice: []int64{2}, isNull: []bool{false}, id: mockID, name: "Age", cache: []string{"2"}}},
+					labels:        []*valueContainer{{slice: []string{"bar"}, isNull: []bool{false}, id: mockID, name: "Name", cache: []string{"bar"}}},
+					colLevelNames: []string{"*0"}},
+			},
+			false,
+		},
+		{"fail - bad n", fields{
+			RecordReader: RecordReader{HeaderRows: 1},
+			Reader:       csv.NewReader(strings.NewReader("Name,Age\nfoo,1")),
+		},
+			0,
+			nil,
+			true,
+		},
+		{"fail - wrong number of fields", fields{
+			RecordReader: RecordReader{HeaderRows: 1},
+			Reader:       csv.NewReader(strings.NewReader("Name,Age\nfoo")),
+		},
+			1,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &CSVReader{
+				RecordReader: tt.fields.RecordReader,
+				Reader:       tt.fields.Reader,
+			}
+			var got []*DataFrame
+			for {
+				df, err := r.ReadChunk(tt.n)
+				if err == io.EOF {
+					break
+				}
+				if (err != nil) != tt.wantErr {
+					t.Errorf("CSVReader.ReadChunk() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+				if err != nil {
+					return
+				}
+				got = append(got, df)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("CSVReader.ReadChunk() returned %d chunks, want %d", len(got), len(tt.want))
+				return
+			}
+			for i := range got {
+				if !EqualDataFrames(got[i], tt.want[i]) {
+					t.Errorf("CSVReader.ReadChunk() chunk %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCSVReader_ReadWithRejects_lenient(t *testing.T) {
+	data := "Name,Age\n" +
+		"foo,1\n" +
+		"bar\n" +
+		"\"baz\nqux\",2\n" +
+		"\n" +
+		"corge,x\n" +
+		"gr\"ault,4\n" +
+		"garply,5"
+	tests := []struct {
+		name         string
+		schema       map[string]ColumnSchema
+		want         *DataFrame
+		wantRejected *DataFrame
+		wantErr      bool
+	}{
+		{"malformed and unparseable rows", map[string]ColumnSchema{"Age": {DType: Float64}},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"foo", "baz\nqux", "garply"}, isNull: []bool{false, false, false}, id: mockID, name: "Name"},
+				{slice: []float64{1, 2, 5}, isNull: []bool{false, false, false}, id: mockID, name: "Age"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"bar", "corge,x", "gr\"ault,4"}, isNull: []bool{false, false, false}, id: mockID, name: "text"},
+				{slice: []string{
+					"wrong number of fields (1, want 2)",
+					`column Age: cannot parse "x" as Float64`,
+					`column 3: bare " in non-quoted-field`}, isNull: []bool{false, false, false}, id: mockID, name: "error"}},
+				labels:        []*valueContainer{{slice: []int{3, 7, 8}, isNull: []bool{false, false, false}, id: mockID, name: "line"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"no schema", nil,
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"foo", "baz\nqux", "corge", "garply"}, isNull: []bool{false, false, false, false}, id: mockID, name: "Name"},
+				{slice: []string{"1", "2", "x", "5"}, isNull: []bool{false, false, false, false}, id: mockID, name: "Age"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2, 3}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"bar", "gr\"ault,4"}, isNull: []bool{false, false}, id: mockID, name: "text"},
+				{slice: []string{
+					"wrong number of fields (1, want 2)",
+					`column 3: bare " in non-quoted-field`}, isNull: []bool{false, false}, id: mockID, name: "error"}},
+				labels:        []*valueContainer{{slice: []int{3, 8}, isNull: []bool{false, false}, id: mockID, name: "line"}},
+				colLevelNames: []string{"*0"}},
+			false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCSVReader(strings.NewReader(data))
+			r.Lenient = true
+			r.Schema = tt.schema
+			got, gotRejected, err := r.ReadWithRejects()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CSVReader.ReadWithRejects() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("CSVReader.ReadWithRejects() got = %v, want %v", got, tt.want)
+			}
+			if !EqualDataFrames(gotRejected, tt.wantRejected) {
+				t.Errorf("CSVReader.ReadWithRejects() rejected = %v, want %v", gotRejected, tt.wantRejected)
+			}
+			if !EqualDataFrames(r.Rejected(), tt.wantRejected) {
+				t.Errorf("CSVReader.Rejected() = %v, want %v", r.Rejected(), tt.wantRejected)
+			}
+		})
+	}
+}
+
+func TestCSVReader_ReadChunk_lenient(t *testing.T) {
+	r := NewCSVReader(strings.NewReader("Name,Age\nfoo,1\nbar\nbaz,2\nqux,3,4\ncorge,5"))
+	r.Lenient = true
+	want := []*DataFrame{
+		{values: []*valueContainer{
+			{slice: []string{"foo", "baz"}, isNull: []bool{false, false}, id: mockID, name: "Name"},
+			{slice: []string{"1", "2"}, isNull: []bool{false, false}, id: mockID, name: "Age"}},
+			labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"}},
+		{values: []*valueContainer{
+			{slice: []string{"corge"}, isNull: []bool{false}, id: mockID, name: "Name"},
+			{slice: []string{"5"}, isNull: []bool{false}, id: mockID, name: "Age"}},
+			labels:        []*valueContainer{{slice: []int{2}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"}},
+	}
+	for i := range want {
+		got, err := r.ReadChunk(2)
+		if err != nil {
+			t.Fatalf("CSVReader.ReadChunk() chunk %d error = %v", i, err)
+		}
+		if !EqualDataFrames(got, want[i]) {
+			t.Errorf("CSVReader.ReadChunk() chunk %d = %v, want %v", i, got, want[i])
+		}
+	}
+	if _, err := r.ReadChunk(2); err != io.EOF {
+		t.Errorf("CSVReader.ReadChunk() error = %v, want io.EOF", err)
+	}
+	wantRejected := &DataFrame{values: []*valueContainer{
+		{slice: []string{"bar", "qux,3,4"}, isNull: []bool{false, false}, id: mockID, name: "text"},
+		{slice: []string{"wrong number of fields (1, want 2)", "wrong number of fields (3, want 2)"},
+			isNull: []bool{false, false}, id: mockID, name: "error"}},
+		labels:        []*valueContainer{{slice: []int{3, 5}, isNull: []bool{false, false}, id: mockID, name: "line"}},
+		colLevelNames: []string{"*0"}}
+	if !EqualDataFrames(r.Rejected(), wantRejected) {
+		t.Errorf("CSVReader.Rejected() = %v, want %v", r.Rejected(), wantRejected)
+	}
+}
+
+func TestCSVReader_Read_lenientErrors(t *testing.T) {
+	r := NewCSVReader(strings.NewReader("a\"b,c\nfoo,bar"))
+	r.Lenient = true
+	if _, err := r.Read(); err == nil {
+		t.Errorf("CSVReader.Read() with malformed first record error = nil, want error")
+	}
+	r = NewCSVReader(strings.NewReader("foo,bar"))
+	r.Lenient = true
+	r.ByColumn = true
+	if _, err := r.Read(); err == nil {
+		t.Errorf("CSVReader.Read() with ByColumn error = nil, want error")
+	}
+	r = &CSVReader{RecordReader: RecordReader{HeaderRows: 1}, Reader: csv.NewReader(strings.NewReader("foo,bar")), Lenient: true}
+	if _, err := r.Read(); err == nil {
+		t.Errorf("CSVReader.Read() without NewCSVReader error = nil, want error")
+	}
+}
+
 func TestCSVWriter_Write(t *testing.T) {
 	b := new(bytes.Buffer)
 	type fields struct {
@@ -767,8 +1138,8 @@ func TestWriteMockCSV(t *testing.T) {
 10,fred
 100,corge`
 	want := `foo,bar
-0.5,baz
-0.5,baz
+5,baz
+5,baz
 `
 	b := new(bytes.Buffer)
 	type args struct {
@@ -840,6 +1211,100 @@ func TestMatrixReader_Read(t *testing.T) {
 	}
 }
 
+func TestMatrixWriter_Write(t *testing.T) {
+	newDF := func() *DataFrame {
+		return &DataFrame{
+			values: []*valueContainer{
+				{slice: []float64{1, 2, 3}, isNull: []bool{false, true, false}, id: mockID, name: "foo"},
+				{slice: []string{"4", "5", "bar"}, isNull: []bool{false, false, false}, id: mockID, name: "bar"},
+				{slice: []int{7, 8, 9}, isNull: []bool{false, false, false}, id: mockID, name: "baz"}},
+			labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"}}
+	}
+	type fields struct {
+		Columns    []string
+		NullPolicy NullPolicy
+		FillValue  float64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		df      *DataFrame
+		want    *DenseMatrix
+		wantErr bool
+	}{
+		{"fill", fields{NullPolicy: NullPolicyFill, FillValue: -1}, newDF(),
+			&DenseMatrix{rows: 3, cols: 3, data: []float64{1, 4, 7, -1, 5, 8, 3, -1, 9}}, false},
+		{"drop", fields{NullPolicy: NullPolicyDrop}, newDF(),
+			&DenseMatrix{rows: 1, cols: 3, data: []float64{1, 4, 7}}, false},
+		{"selected columns", fields{Columns: []string{"baz", "foo"}, NullPolicy: NullPolicyDrop}, newDF(),
+			&DenseMatrix{rows: 2, cols: 2, data: []float64{7, 1, 9, 3}}, false},
+		{"no nulls", fields{Columns: []string{"baz"}}, newDF(),
+			&DenseMatrix{rows: 3, cols: 1, data: []float64{7, 8, 9}}, false},
+		{"fail - null", fields{}, newDF(), nil, true},
+		{"fail - unparseable string is null", fields{Columns: []string{"bar"}}, newDF(), nil, true},
+		{"fail - bad column", fields{Columns: []string{"corge"}}, newDF(), nil, true},
+		{"fail - bad policy", fields{NullPolicy: 10}, newDF(), nil, true},
+		{"fail - df error", fields{}, dataFrameWithError(errors.New("foo")), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &MatrixWriter{
+				Columns:    tt.fields.Columns,
+				NullPolicy: tt.fields.NullPolicy,
+				FillValue:  tt.fields.FillValue,
+			}
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("MatrixWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := w.Matrix(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatrixWriter.Write() -> Matrix() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(tt.df.values[1].slice, []string{"4", "5", "bar"}) {
+				t.Errorf("MatrixWriter.Write() modified df = %v", tt.df)
+			}
+		})
+	}
+}
+
+func TestDenseMatrix(t *testing.T) {
+	m := NewDenseMatrix(2, 3, []float64{1, 2, 3, 4, 5, 6})
+	if r, c := m.Dims(); r != 2 || c != 3 {
+		t.Errorf("DenseMatrix.Dims() = %v, %v, want 2, 3", r, c)
+	}
+	if got := m.At(1, 0); got != 4 {
+		t.Errorf("DenseMatrix.At() = %v, want 4", got)
+	}
+	want := &DenseMatrix{rows: 3, cols: 2, data: []float64{1, 4, 2, 5, 3, 6}}
+	if got := m.T(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DenseMatrix.T() = %v, want %v", got, want)
+	}
+	if got := m.RawData(); !reflect.DeepEqual(got, []float64{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("DenseMatrix.RawData() = %v, want %v", got, []float64{1, 2, 3, 4, 5, 6})
+	}
+	// round trip through MatrixReader
+	df, _ := NewMatrixReader(m).Read()
+	w := NewMatrixWriter()
+	w.Write(df)
+	if !reflect.DeepEqual(w.Matrix(), m) {
+		t.Errorf("MatrixReader.Read() -> MatrixWriter.Write() = %v, want %v", w.Matrix(), m)
+	}
+	for _, f := range []func(){
+		func() { m.At(2, 0) },
+		func() { NewDenseMatrix(2, 2, []float64{1}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("DenseMatrix did not panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
 func TestDataFrame_EqualRecords(t *testing.T) {
 	type fields struct {
 		labels        []*valueContainer
@@ -0,0 +1,466 @@
+package tada
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// -- row-oriented JSON
+
+// JSONReader reads newline-delimited JSON (NDJSON) or a JSON array of row objects into a DataFrame.
+type JSONReader struct {
+	LabelLevels int
+	ByColumn    bool
+	Name        string
+	r           io.Reader
+}
+
+// NewJSONReader returns a default JSONReader.
+func NewJSONReader(r io.Reader) JSONReader {
+	return JSONReader{
+		LabelLevels: 0,
+		ByColumn:    false,
+		r:           r,
+	}
+}
+
+// Read reads JSON data into a DataFrame.
+// By default, the data must be either a sequence of objects (e.g., newline-delimited JSON) or an array of objects,
+// and each object is one row keyed by column name. The format is detected automatically.
+// Columns are ordered by their first appearance, and a key that is missing from a row is read as a null value.
+// If ByColumn is true, the data must instead be a single object that maps each column name to an array of values.
+//
+// Nested objects are flattened into multi-level column names joined by the level separator (default: "|").
+// If some columns are nested more deeply than others, the shallower column names are padded with empty levels.
+// The first r.LabelLevels columns are read as label levels.
+//
+// JSON null values are read as null values.
+// A column is read as []float64 if every non-null value is a number, as []bool if every non-null value is a boolean,
+// and as []string otherwise. Arrays are read as their JSON text.
+func (r JSONReader) Read() (*DataFrame, error) {
+	paths, columns, err := readJSON(r.r, r.ByColumn)
+	if err != nil {
+		return nil, fmt.Errorf("reading json: %v", err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("reading json: must have at least one column")
+	}
+	if r.LabelLevels >= len(columns) {
+		return nil, fmt.Errorf("reading json: number of label levels (%d) must be less than number of columns (%d)",
+			r.LabelLevels, len(columns))
+	}
+	numLevels := 1
+	for k := r.LabelLevels; k < len(paths); k++ {
+		if len(paths[k]) > numLevels {
+			numLevels = len(paths[k])
+		}
+	}
+	containers := make([]*valueContainer, len(columns))
+	for k := range columns {
+		path := paths[k]
+		if k >= r.LabelLevels {
+			for len(path) < numLevels {
+				path = append(path, "")
+			}
+		}
+		containers[k] = jsonValuesToContainer(columns[k], joinLevelsIntoName(path))
+	}
+	return containersToDF(containers, numLevels, r.LabelLevels, r.Name), nil
+}
+
+// JSONWriter writes a DataFrame as row-oriented JSON.
+type JSONWriter struct {
+	IncludeLabels bool
+	NDJSON        bool
+	ByColumn      bool
+	w             io.Writer
+}
+
+// NewJSONWriter returns a *JSONWriter with default settings.
+// By default, label levels are excluded, and the DataFrame is written as a JSON array with one object per row.
+// To write newline-delimited JSON (one object per line) instead, set NDJSON to true.
+// To write a single object that maps each column name to an array of values, set ByColumn to true.
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{
+		IncludeLabels: false,
+		w:             w,
+	}
+}
+
+// Write writes df as JSON objects keyed by column name.
+// Multi-level column names are written as nested objects (with any trailing empty levels omitted).
+// Null values are written as JSON null, as are NaN and infinite float values.
+// []time.Time values are written as RFC 3339 strings, and civil dates and times are written as strings.
+func (w *JSONWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing json: %v", df.err)
+	}
+	if w.NDJSON && w.ByColumn {
+		return fmt.Errorf("writing json: NDJSON and ByColumn cannot both be true")
+	}
+	containers := df.values
+	if w.IncludeLabels {
+		containers = append(df.labels, df.values...)
+	}
+	tree, err := newJSONTree(containers)
+	if err != nil {
+		return fmt.Errorf("writing json: %v", err)
+	}
+	cells := make([][]string, len(containers))
+	for k := range containers {
+		cells[k] = jsonCells(containers[k])
+	}
+	buf := new(bytes.Buffer)
+	switch {
+	case w.ByColumn:
+		columns := make([]string, len(cells))
+		for k := range cells {
+			columns[k] = "[" + strings.Join(cells[k], ",") + "]"
+		}
+		tree.write(buf, columns)
+		buf.WriteByte('\n')
+	default:
+		numRows := containers[0].len()
+		if !w.NDJSON {
+			buf.WriteByte('[')
+		}
+		row := make([]string, len(cells))
+		for i := 0; i < numRows; i++ {
+			for k := range cells {
+				row[k] = cells[k][i]
+			}
+			if i > 0 && !w.NDJSON {
+				buf.WriteByte(',')
+			}
+			tree.write(buf, row)
+			if w.NDJSON {
+				buf.WriteByte('\n')
+			}
+		}
+		if !w.NDJSON {
+			buf.WriteString("]\n")
+		}
+	}
+	_, err = w.w.Write(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("writing json: %v", err)
+	}
+	return nil
+}
+
+// -- json internals
+
+// readJSON reads JSON data and returns the path (i.e., name levels) of each column and the values of each column.
+func readJSON(r io.Reader, byColumn bool) ([][]string, [][]interface{}, error) {
+	var paths [][]string
+	var columns [][]interface{}
+	index := make(map[string]int)
+	column := func(path []string) int {
+		name := joinLevelsIntoName(path)
+		k, ok := index[name]
+		if !ok {
+			k = len(paths)
+			index[name] = k
+			paths = append(paths, append([]string{}, path...))
+			columns = append(columns, nil)
+		}
+		return k
+	}
+
+	dec := json.NewDecoder(r)
+	if byColumn {
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		numRows := -1
+		err = flattenJSONObject(raw, nil, func(path []string, value json.RawMessage) error {
+			var elements []json.RawMessage
+			err := json.Unmarshal(value, &elements)
+			if err != nil {
+				return fmt.Errorf("column %s: must be an array", joinLevelsIntoName(path))
+			}
+			if numRows != -1 && len(elements) != numRows {
+				return fmt.Errorf("column %s: length (%d) does not match first column (%d)",
+					joinLevelsIntoName(path), len(elements), numRows)
+			}
+			numRows = len(elements)
+			k := column(path)
+			for _, element := range elements {
+				v, err := decodeJSONValue(element)
+				if err != nil {
+					return err
+				}
+				columns[k] = append(columns[k], v)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return paths, columns, nil
+	}
+
+	var rows []json.RawMessage
+	for {
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("row %d: %v", len(rows), err)
+		}
+		if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("[")) {
+			var elements []json.RawMessage
+			err := json.Unmarshal(raw, &elements)
+			if err != nil {
+				return nil, nil, err
+			}
+			rows = append(rows, elements...)
+			continue
+		}
+		rows = append(rows, raw)
+	}
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("must have at least one row")
+	}
+	for i, row := range rows {
+		err := flattenJSONObject(row, nil, func(path []string, value json.RawMessage) error {
+			v, err := decodeJSONValue(value)
+			if err != nil {
+				return err
+			}
+			k := column(path)
+			if len(columns[k]) > i {
+				// ignore duplicate keys after the first
+				return nil
+			}
+			// backfill rows in which the column was missing
+			for len(columns[k]) < i {
+				columns[k] = append(columns[k], nil)
+			}
+			columns[k] = append(columns[k], v)
+			return nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("row %d: %v", i, err)
+		}
+	}
+	for k := range columns {
+		for len(columns[k]) < len(rows) {
+			columns[k] = append(columns[k], nil)
+		}
+	}
+	return paths, columns, nil
+}
+
+// flattenJSONObject calls fn with the path and value of every non-object value in a JSON object, in order of appearance.
+func flattenJSONObject(raw json.RawMessage, prefix []string, fn func(path []string, value json.RawMessage) error) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("must be an object")
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		// duck error because object keys are always strings
+		key, _ := tok.(string)
+		var value json.RawMessage
+		err = dec.Decode(&value)
+		if err != nil {
+			return err
+		}
+		path := append(append([]string{}, prefix...), key)
+		if bytes.HasPrefix(bytes.TrimSpace(value), []byte("{")) {
+			err = flattenJSONObject(value, path, fn)
+		} else {
+			err = fn(path, value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeJSONValue decodes a JSON value into float64, bool, string, or nil.
+// Arrays and objects are returned as their compact JSON text.
+func decodeJSONValue(raw json.RawMessage) (interface{}, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if bytes.HasPrefix(trimmed, []byte("[")) || bytes.HasPrefix(trimmed, []byte("{")) {
+		buf := new(bytes.Buffer)
+		err := json.Compact(buf, trimmed)
+		if err != nil {
+			return nil, err
+		}
+		return buf.String(), nil
+	}
+	var v interface{}
+	err := json.Unmarshal(trimmed, &v)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// jsonValuesToContainer converts decoded JSON values into a typed valueContainer.
+func jsonValuesToContainer(vals []interface{}, name string) *valueContainer {
+	isNull := make([]bool, len(vals))
+	isFloat, isBool := true, true
+	var hasValue bool
+	for i := range vals {
+		switch vals[i].(type) {
+		case nil:
+			isNull[i] = true
+			continue
+		case float64:
+			isBool = false
+		case bool:
+			isFloat = false
+		default:
+			isFloat, isBool = false, false
+		}
+		hasValue = true
+	}
+	var slice interface{}
+	switch {
+	case hasValue && isFloat:
+		arr := make([]float64, len(vals))
+		for i := range vals {
+			arr[i], _ = vals[i].(float64)
+		}
+		slice = arr
+	case hasValue && isBool:
+		arr := make([]bool, len(vals))
+		for i := range vals {
+			arr[i], _ = vals[i].(bool)
+		}
+		slice = arr
+	default:
+		arr := make([]string, len(vals))
+		for i := range vals {
+			if !isNull[i] {
+				arr[i] = fmt.Sprint(vals[i])
+			}
+		}
+		slice = arr
+	}
+	return newValueContainer(slice, isNull, name)
+}
+
+// jsonCells returns the JSON encoding of every value in vc.
+func jsonCells(vc *valueContainer) []string {
+	ret := make([]string, vc.len())
+	switch vc.slice.(type) {
+	case []float64, []float32:
+		vals := vc.float64().slice
+		for i := range vals {
+			if math.IsNaN(vals[i]) || math.IsInf(vals[i], 0) {
+				ret[i] = "null"
+				continue
+			}
+			b, _ := json.Marshal(vals[i])
+			ret[i] = string(b)
+		}
+	case []string, []bool, []time.Time, []civil.Date, []civil.Time, []civil.DateTime,
+		[]int, []int8, []int16, []int32, []int64, []uint, []uint16, []uint32, []uint64:
+		v := reflect.ValueOf(vc.slice)
+		for i := range ret {
+			// duck error because all of these types can be marshaled
+			b, _ := json.Marshal(v.Index(i).Interface())
+			ret[i] = string(b)
+		}
+	default:
+		vals := vc.string().slice
+		for i := range vals {
+			b, _ := json.Marshal(vals[i])
+			ret[i] = string(b)
+		}
+	}
+	for i := range ret {
+		if vc.isNull[i] {
+			ret[i] = "null"
+		}
+	}
+	return ret
+}
+
+// a jsonTree arranges columns into nested objects according to the levels in their names.
+type jsonTree struct {
+	keys     []string
+	children []*jsonTree
+	// position of the container written at each key, or -1 if the key holds a nested object
+	containers []int
+}
+
+// newJSONTree arranges containers by the levels in their names.
+func newJSONTree(containers []*valueContainer) (*jsonTree, error) {
+	root := &jsonTree{}
+	for k := range containers {
+		levels := splitNameIntoLevels(containers[k].name)
+		for len(levels) > 1 && levels[len(levels)-1] == "" {
+			levels = levels[:len(levels)-1]
+		}
+		node := root
+		for l, level := range levels {
+			pos := -1
+			for j := range node.keys {
+				if node.keys[j] == level {
+					pos = j
+				}
+			}
+			last := l == len(levels)-1
+			if pos == -1 {
+				node.keys = append(node.keys, level)
+				if last {
+					node.children = append(node.children, nil)
+					node.containers = append(node.containers, k)
+				} else {
+					node.children = append(node.children, &jsonTree{})
+					node.containers = append(node.containers, -1)
+				}
+				pos = len(node.keys) - 1
+			} else if last || node.containers[pos] != -1 {
+				return nil, fmt.Errorf("column name (%s) conflicts with another column", containers[k].name)
+			}
+			node = node.children[pos]
+		}
+	}
+	return root, nil
+}
+
+// write writes the tree as a JSON object, with the encoded value at values[k] written for container k.
+func (tree *jsonTree) write(buf *bytes.Buffer, values []string) {
+	buf.WriteByte('{')
+	for j := range tree.keys {
+		if j > 0 {
+			buf.WriteByte(',')
+		}
+		// duck error because strings can always be marshaled
+		key, _ := json.Marshal(tree.keys[j])
+		buf.Write(key)
+		buf.WriteByte(':')
+		if tree.containers[j] == -1 {
+			tree.children[j].write(buf, values)
+		} else {
+			buf.WriteString(values[tree.containers[j]])
+		}
+	}
+	buf.WriteByte('}')
+}
@@ -0,0 +1,162 @@
+package tada
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+func TestJSONReader_Read(t *testing.T) {
+	type fields struct {
+		LabelLevels int
+		ByColumn    bool
+		Name        string
+		data        string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - ndjson", fields{data: "{\"foo\": 1, \"bar\": \"a\"}\n{\"foo\": null, \"baz\": true}\n"},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []string{"a", ""}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []bool{false, true}, isNull: []bool{true, false}, id: mockID, name: "baz"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - array with labels and nested objects", fields{LabelLevels: 1, Name: "qux",
+			data: `[{"id": "x", "a": {"b": 1, "c": [1, 2]}, "d": 2}, {"id": "y", "a": {"b": 3, "c": "z"}}]`},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 3}, isNull: []bool{false, false}, id: mockID, name: "a|b"},
+					{slice: []string{"[1,2]", "z"}, isNull: []bool{false, false}, id: mockID, name: "a|c"},
+					{slice: []float64{2, 0}, isNull: []bool{false, true}, id: mockID, name: "d|"},
+				},
+				labels: []*valueContainer{
+					{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "id"}},
+				colLevelNames: []string{"*0", "*1"},
+				name:          "qux"},
+			false},
+		{"pass - by column", fields{ByColumn: true, data: `{"foo": [1, 2], "bar": ["a", null]}`},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+					{slice: []string{"a", ""}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - by column with unequal lengths", fields{ByColumn: true, data: `{"foo": [1, 2], "bar": ["a"]}`},
+			nil, true},
+		{"fail - not an object", fields{data: `[1, 2]`},
+			nil, true},
+		{"fail - malformed", fields{data: `{"foo": 1`},
+			nil, true},
+		{"fail - empty", fields{data: ``},
+			nil, true},
+		{"fail - too many label levels", fields{LabelLevels: 1, data: `{"foo": 1}`},
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewJSONReader(strings.NewReader(tt.fields.data))
+			r.LabelLevels = tt.fields.LabelLevels
+			r.ByColumn = tt.fields.ByColumn
+			r.Name = tt.fields.Name
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSONReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("JSONReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONWriter_Write(t *testing.T) {
+	df := func() *DataFrame {
+		return &DataFrame{
+			values: []*valueContainer{
+				{slice: []float64{1.5, 0}, isNull: []bool{false, true}, id: mockID, name: "a|b"},
+				{slice: []civil.Date{{Year: 2020, Month: 1, Day: 1}, {}}, isNull: []bool{false, true}, id: mockID, name: "a|c"},
+				{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "d|"},
+			},
+			labels: []*valueContainer{
+				{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0", "*1"}}
+	}
+	type fields struct {
+		IncludeLabels bool
+		NDJSON        bool
+		ByColumn      bool
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		df      *DataFrame
+		want    string
+		wantErr bool
+	}{
+		{"pass - array", fields{IncludeLabels: true}, df(),
+			`[{"*0":0,"a":{"b":1.5,"c":"2020-01-01"},"d":true},{"*0":1,"a":{"b":null,"c":null},"d":false}]` + "\n",
+			false},
+		{"pass - ndjson without labels", fields{NDJSON: true}, df(),
+			`{"a":{"b":1.5,"c":"2020-01-01"},"d":true}` + "\n" + `{"a":{"b":null,"c":null},"d":false}` + "\n",
+			false},
+		{"pass - by column", fields{ByColumn: true}, df(),
+			`{"a":{"b":[1.5,null],"c":["2020-01-01",null]},"d":[true,false]}` + "\n",
+			false},
+		{"pass - time and strings", fields{NDJSON: true},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, isNull: []bool{false}, id: mockID, name: "foo"},
+					{slice: []interface{}{"\"bar\""}, isNull: []bool{false}, id: mockID, name: "bar"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			`{"foo":"2020-01-01T00:00:00Z","bar":"\"bar\""}` + "\n",
+			false},
+		{"fail - conflicting names", fields{},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "a|b"},
+					{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "a|"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "*1"}},
+			"", true},
+		{"fail - ndjson and by column", fields{NDJSON: true, ByColumn: true}, df(), "", true},
+		{"fail - error in df", fields{}, dataFrameWithError(errors.New("foo")), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := NewJSONWriter(b)
+			w.IncludeLabels = tt.fields.IncludeLabels
+			w.NDJSON = tt.fields.NDJSON
+			w.ByColumn = tt.fields.ByColumn
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("JSONWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("JSONWriter.Write() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package tada
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// -- hashed row keys
+
+// rowKeys holds the values of one or more key containers in a typed form,
+// so that each row can be hashed and compared without stringifying and concatenating its values.
+type rowKeys struct {
+	levels []keyLevel
+	hashes []uint64
+}
+
+// keyLevel holds the values of a single key container in exactly one of five comparable forms.
+// Values are compared regardless of their null status, as in the stringified keys they replace.
+type keyLevel struct {
+	strings []string
+	ints    []int
+	int64s  []int64
+	floats  []float64
+	bools   []bool
+}
+
+// keyKind returns the form in which the values of vc are compared: "string", "int", "int64", "float64", or "bool".
+// Every other type is compared by its stringified values.
+func keyKind(vc *valueContainer) string {
+	switch vc.slice.(type) {
+	case []int:
+		return "int"
+	case []int64:
+		return "int64"
+	case []float64:
+		return "float64"
+	case []bool:
+		return "bool"
+	default:
+		return "string"
+	}
+}
+
+// newRowKeys hashes every row of containers, comparing the values of each container in its own form (see keyKind).
+func newRowKeys(containers []*valueContainer) *rowKeys {
+	kinds := make([]string, len(containers))
+	for j := range containers {
+		kinds[j] = keyKind(containers[j])
+	}
+	return newRowKeysOfKinds(containers, kinds)
+}
+
+// newJoinKeys hashes every row of containers1 and containers2 so that they may be compared with each other.
+// If a pair of key containers does not share the same form, both are compared by their stringified values
+// (e.g., an int key of 1 matches a string key of "1").
+func newJoinKeys(containers1 []*valueContainer, containers2 []*valueContainer) (*rowKeys, *rowKeys) {
+	kinds := make([]string, len(containers1))
+	for j := range containers1 {
+		kinds[j] = keyKind(containers1[j])
+		if kinds[j] != keyKind(containers2[j]) {
+			kinds[j] = "string"
+		}
+	}
+	return newRowKeysOfKinds(containers1, kinds), newRowKeysOfKinds(containers2, kinds)
+}
+
+func newRowKeysOfKinds(containers []*valueContainer, kinds []string) *rowKeys {
+	var numRows int
+	if len(containers) > 0 {
+		numRows = containers[0].len()
+	}
+	ret := &rowKeys{
+		levels: make([]keyLevel, len(containers)),
+		hashes: make([]uint64, numRows),
+	}
+	for i := range ret.hashes {
+		ret.hashes[i] = hashOffset
+	}
+	for j, vc := range containers {
+		switch kinds[j] {
+		case "int":
+			arr := vc.slice.([]int)
+			ret.levels[j].ints = arr
+			for i := range arr {
+				ret.hashes[i] = combineHashes(ret.hashes[i], mixHash(uint64(arr[i])))
+			}
+		case "int64":
+			arr := vc.slice.([]int64)
+			ret.levels[j].int64s = arr
+			for i := range arr {
+				ret.hashes[i] = combineHashes(ret.hashes[i], mixHash(uint64(arr[i])))
+			}
+		case "float64":
+			arr := vc.slice.([]float64)
+			ret.levels[j].floats = arr
+			for i := range arr {
+				ret.hashes[i] = combineHashes(ret.hashes[i], mixHash(floatBits(arr[i])))
+			}
+		case "bool":
+			arr := vc.slice.([]bool)
+			ret.levels[j].bools = arr
+			for i := range arr {
+				var v uint64
+				if arr[i] {
+					v = 1
+				}
+				ret.hashes[i] = combineHashes(ret.hashes[i], mixHash(v))
+			}
+		default:
+			vc.setCache()
+			ret.levels[j].strings = vc.cache
+			for i := range vc.cache {
+				ret.hashes[i] = combineHashes(ret.hashes[i], hashString(vc.cache[i]))
+			}
+		}
+	}
+	return ret
+}
+
+// equal returns true if row i of k has the same key as row j of other.
+// k and other must have the same number of levels, and each level must have the same form.
+func (k *rowKeys) equal(i int, other *rowKeys, j int) bool {
+	if k.hashes[i] != other.hashes[j] {
+		return false
+	}
+	for l := range k.levels {
+		switch {
+		case k.levels[l].ints != nil:
+			if k.levels[l].ints[i] != other.levels[l].ints[j] {
+				return false
+			}
+		case k.levels[l].int64s != nil:
+			if k.levels[l].int64s[i] != other.levels[l].int64s[j] {
+				return false
+			}
+		case k.levels[l].floats != nil:
+			if floatBits(k.levels[l].floats[i]) != floatBits(other.levels[l].floats[j]) {
+				return false
+			}
+		case k.levels[l].bools != nil:
+			if k.levels[l].bools[i] != other.levels[l].bools[j] {
+				return false
+			}
+		default:
+			if k.levels[l].strings[i] != other.levels[l].strings[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// keyIndex assigns a group to each unique key in a rowKeys, in order of first appearance.
+// Keys with the same hash are chained together and compared value by value, so hash collisions never merge groups.
+type keyIndex struct {
+	keys *rowKeys
+	// the first group with each hash
+	heads map[uint64]int
+	// the next group with the same hash as each group (or -1)
+	next []int
+	// the row in keys in which each group first appears
+	firstRows []int
+}
+
+func newKeyIndex(keys *rowKeys) *keyIndex {
+	return &keyIndex{
+		keys:  keys,
+		heads: make(map[uint64]int),
+	}
+}
+
+// add returns the group of row i in idx.keys, and true if row i is the first row in that group.
+func (idx *keyIndex) add(i int) (int, bool) {
+	if group := idx.find(idx.keys, i); group != -1 {
+		return group, false
+	}
+	group := len(idx.firstRows)
+	head, ok := idx.heads[idx.keys.hashes[i]]
+	if !ok {
+		head = -1
+	}
+	idx.next = append(idx.next, head)
+	idx.heads[idx.keys.hashes[i]] = group
+	idx.firstRows = append(idx.firstRows, i)
+	return group, true
+}
+
+// find returns the group whose key matches row i of keys, or -1 if there is none.
+func (idx *keyIndex) find(keys *rowKeys, i int) int {
+	group, ok := idx.heads[keys.hashes[i]]
+	if !ok {
+		return -1
+	}
+	for ; group != -1; group = idx.next[group] {
+		if idx.keys.equal(idx.firstRows[group], keys, i) {
+			return group
+		}
+	}
+	return -1
+}
+
+// groupRows returns an index of the unique keys in keys and the rows that share each key,
+// in order of first appearance.
+func groupRows(keys *rowKeys) (*keyIndex, [][]int) {
+	idx := newKeyIndex(keys)
+	var rows [][]int
+	for i := range keys.hashes {
+		group, isNew := idx.add(i)
+		if isNew {
+			rows = append(rows, []int{i})
+		} else {
+			rows[group] = append(rows[group], i)
+		}
+	}
+	return idx, rows
+}
+
+// rowString returns the name of the key in row i of containers (e.g., for naming a group):
+// the stringified value of each level, joined by optionLevelSeparator.
+// In a multi-level key, a backslash or optionLevelSeparator within a value is escaped with a backslash,
+// so that keys with different values always have different names.
+func rowString(containers []*valueContainer, i int) string {
+	if len(containers) == 1 {
+		return stringValue(containers[0], i)
+	}
+	values := make([]string, len(containers))
+	for j, vc := range containers {
+		values[j] = escapeLevel(stringValue(vc, i))
+	}
+	return strings.Join(values, optionLevelSeparator)
+}
+
+// escapeLevel escapes every backslash and optionLevelSeparator in s with a backslash.
+func escapeLevel(s string) string {
+	if !strings.Contains(s, `\`) && !strings.Contains(s, optionLevelSeparator) {
+		return s
+	}
+	s = strings.Replace(s, `\`, `\\`, -1)
+	return strings.Replace(s, optionLevelSeparator, `\`+optionLevelSeparator, -1)
+}
+
+// stringValue returns the stringified value of row i in vc.
+func stringValue(vc *valueContainer, i int) string {
+	if vc.cache != nil {
+		return vc.cache[i]
+	}
+	return fmt.Sprint(reflect.ValueOf(vc.slice).Index(i).Interface())
+}
+
+// -- hash functions
+
+// 64-bit FNV-1a parameters
+const (
+	hashOffset uint64 = 14695981039346656037
+	hashPrime  uint64 = 1099511628211
+)
+
+// hashString returns the 64-bit FNV-1a hash of s.
+func hashString(s string) uint64 {
+	h := hashOffset
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= hashPrime
+	}
+	return h
+}
+
+// mixHash scrambles the bits of x (using the splitmix64 finalizer), so that nearby numbers have unrelated hashes.
+func mixHash(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// combineHashes adds the hash of the next key level to h. The result depends on the order of the levels.
+func combineHashes(h uint64, next uint64) uint64 {
+	return (h ^ next) * hashPrime
+}
+
+// floatBits returns the bits of f, treating every NaN as the same value.
+func floatBits(f float64) uint64 {
+	if math.IsNaN(f) {
+		return math.Float64bits(math.NaN())
+	}
+	return math.Float64bits(f)
+}
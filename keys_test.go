@@ -0,0 +1,177 @@
+package tada
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_keyIndex(t *testing.T) {
+	type args struct {
+		containers []*valueContainer
+		hashes     []uint64
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantGroups    []int
+		wantFirstRows []int
+	}{
+		{"multiple types", args{
+			containers: []*valueContainer{
+				{slice: []int{1, 1, 2, 1}, isNull: []bool{false, false, false, false}, id: mockID, name: "foo"},
+				{slice: []float64{1, 1, 1, math.NaN()}, isNull: []bool{false, false, false, true}, id: mockID, name: "bar"},
+				{slice: []string{"a", "a", "a", "a"}, isNull: []bool{false, false, false, false}, id: mockID, name: "baz"},
+			}},
+			[]int{0, 0, 1, 2},
+			[]int{0, 2, 3}},
+		{"int64 and bool", args{
+			containers: []*valueContainer{
+				{slice: []int64{1 << 53, 1<<53 + 1, 1 << 53, 1 << 53}, isNull: []bool{false, false, false, false}, id: mockID, name: "foo"},
+				{slice: []bool{true, true, true, false}, isNull: []bool{false, false, false, false}, id: mockID, name: "bar"},
+			}},
+			[]int{0, 1, 0, 2},
+			[]int{0, 1, 3}},
+		{"NaN values match", args{
+			containers: []*valueContainer{
+				{slice: []float64{math.NaN(), math.NaN()}, isNull: []bool{true, true}, id: mockID, name: "foo"},
+			}},
+			[]int{0, 0},
+			[]int{0}},
+		{"hash collisions are compared by value", args{
+			containers: []*valueContainer{
+				{slice: []string{"a", "b", "a", "b"}, isNull: []bool{false, false, false, false}, id: mockID, name: "foo"},
+			},
+			hashes: []uint64{0, 0, 0, 0}},
+			[]int{0, 1, 0, 1},
+			[]int{0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := newRowKeys(tt.args.containers)
+			if tt.args.hashes != nil {
+				keys.hashes = tt.args.hashes
+			}
+			idx := newKeyIndex(keys)
+			var gotGroups []int
+			for i := range keys.hashes {
+				group, _ := idx.add(i)
+				gotGroups = append(gotGroups, group)
+			}
+			if !reflect.DeepEqual(gotGroups, tt.wantGroups) {
+				t.Errorf("keyIndex.add() groups = %v, want %v", gotGroups, tt.wantGroups)
+			}
+			if !reflect.DeepEqual(idx.firstRows, tt.wantFirstRows) {
+				t.Errorf("keyIndex.firstRows = %v, want %v", idx.firstRows, tt.wantFirstRows)
+			}
+		})
+	}
+}
+
+func Test_newRowKeys_nativeForms(t *testing.T) {
+	int64s := &valueContainer{slice: []int64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"}
+	bools := &valueContainer{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "bar"}
+	keys := newRowKeys([]*valueContainer{int64s, bools})
+	if keys.levels[0].int64s == nil || keys.levels[1].bools == nil {
+		t.Errorf("newRowKeys() levels = %v, want int64 and bool forms", keys.levels)
+	}
+	if int64s.cache != nil || bools.cache != nil {
+		t.Errorf("newRowKeys() stringified int64 or bool values")
+	}
+}
+
+func Test_rowString(t *testing.T) {
+	type args struct {
+		containers []*valueContainer
+		i          int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{"single level is not escaped", args{[]*valueContainer{
+			{slice: []string{"a|b"}, isNull: []bool{false}, id: mockID, name: "foo"}}, 0},
+			"a|b"},
+		{"multiple levels", args{[]*valueContainer{
+			{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "foo"},
+			{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "bar"}}, 0},
+			"a|1"},
+		{"separator and backslash are escaped", args{[]*valueContainer{
+			{slice: []string{`a\`}, isNull: []bool{false}, id: mockID, name: "foo"},
+			{slice: []string{"|b"}, isNull: []bool{false}, id: mockID, name: "bar"}}, 0},
+			`a\\|\|b`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rowString(tt.args.containers, tt.args.i); got != tt.want {
+				t.Errorf("rowString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Benchmark_reduceContainers compares grouping by hashed keys with grouping by concatenated strings
+// (the approach that the hashed keys replaced).
+func Benchmark_reduceContainers(b *testing.B) {
+	n := 1000000
+	ints := make([]int, n)
+	floats := make([]float64, n)
+	strs := make([]string, n)
+	for i := 0; i < n; i++ {
+		ints[i] = i % 1000
+		floats[i] = float64(i % 7)
+		strs[i] = strconv.Itoa(i % 13)
+	}
+	containers := func() []*valueContainer {
+		return []*valueContainer{
+			{slice: ints, isNull: make([]bool, n), name: "foo"},
+			{slice: floats, isNull: make([]bool, n), name: "bar"},
+			{slice: strs, isNull: make([]bool, n), name: "baz"},
+		}
+	}
+	b.Run("hashed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			reduceContainers(containers())
+		}
+	})
+	b.Run("concatenated", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			groupByConcatenatedStrings(containers())
+		}
+	})
+}
+
+// groupByConcatenatedStrings groups rows as reduceContainers did before hashed keys:
+// by their stringified values joined by optionLevelSeparator.
+func groupByConcatenatedStrings(containers []*valueContainer) [][]int {
+	for j := range containers {
+		containers[j].setCache()
+	}
+	uniqueLabelRows := make(map[string][]int)
+	orderedKeys := make([]string, 0)
+	var sb strings.Builder
+	for i := 0; i < containers[0].len(); i++ {
+		sb.Reset()
+		for j := range containers {
+			if j > 0 {
+				sb.WriteString(optionLevelSeparator)
+			}
+			sb.WriteString(containers[j].cache[i])
+		}
+		key := sb.String()
+		if _, ok := uniqueLabelRows[key]; !ok {
+			uniqueLabelRows[key] = []int{i}
+			orderedKeys = append(orderedKeys, key)
+		} else {
+			uniqueLabelRows[key] = append(uniqueLabelRows[key], i)
+		}
+	}
+	rows := make([][]int, len(orderedKeys))
+	for i, key := range orderedKeys {
+		rows[i] = uniqueLabelRows[key]
+	}
+	return rows
+}
@@ -0,0 +1,411 @@
+package tada
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// -- Markdown, HTML, and LaTeX tables
+
+// MarkdownWriter writes a DataFrame as a GitHub Flavored Markdown table.
+type MarkdownWriter struct {
+	IncludeLabels bool
+	// MergeRepeats blanks label values that repeat the row above (as in DataFrame.String()).
+	MergeRepeats bool
+	// Formats maps column (or label level) names to the fmt verb used to format each non-null value (e.g., "%.2f").
+	Formats map[string]string
+	// NullString is written in place of null values.
+	NullString string
+	w          io.Writer
+}
+
+// NewMarkdownWriter returns a *MarkdownWriter with default settings.
+// By default, label levels are included, repeated labels are merged if the global option is set (see PrintOptionMergeRepeats),
+// and null values are written as (null).
+func NewMarkdownWriter(w io.Writer) *MarkdownWriter {
+	return &MarkdownWriter{
+		IncludeLabels: true,
+		MergeRepeats:  optionMergeRepeats,
+		NullString:    optionsNullPrinter,
+		w:             w,
+	}
+}
+
+// Write writes df as a Markdown table, preceded by df's name in bold (if it has one).
+// Because Markdown tables have a single header row, the levels of multi-level column names are separated by line breaks (<br>).
+// Numeric columns are right-aligned. Markdown formatting characters and pipes in values are escaped.
+func (w *MarkdownWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing markdown: %v", df.err)
+	}
+	t := newMarkupTable(df, w.IncludeLabels, w.MergeRepeats, w.Formats, w.NullString)
+	bw := bufio.NewWriter(w.w)
+	if df.name != "" {
+		fmt.Fprintf(bw, "**%s**\n\n", escapeMarkdown(df.name))
+	}
+	header := make([]string, t.numCols())
+	divider := make([]string, t.numCols())
+	for k := range header {
+		var levels []string
+		for l := range t.headers {
+			if t.headers[l][k] != "" {
+				levels = append(levels, escapeMarkdown(t.headers[l][k]))
+			}
+		}
+		header[k] = strings.Join(levels, "<br>")
+		divider[k] = "---"
+		if t.numeric[k] {
+			divider[k] = "---:"
+		}
+	}
+	writeMarkdownRow(bw, header)
+	writeMarkdownRow(bw, divider)
+	for i := range t.rows {
+		row := make([]string, t.numCols())
+		for k := range row {
+			if k < t.numLabels && t.spans[i][k] == 0 {
+				continue
+			}
+			row[k] = escapeMarkdown(t.rows[i][k])
+		}
+		writeMarkdownRow(bw, row)
+	}
+	err := bw.Flush()
+	if err != nil {
+		return fmt.Errorf("writing markdown: %v", err)
+	}
+	return nil
+}
+
+func writeMarkdownRow(w io.Writer, cells []string) {
+	fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "|", `\|`,
+	"\r\n", "<br>", "\n", "<br>")
+
+// escapeMarkdown escapes characters that have special meaning in Markdown table cells.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// HTMLWriter writes a DataFrame as an HTML table.
+type HTMLWriter struct {
+	IncludeLabels bool
+	// MergeRepeats merges label cells that repeat the row above into a single cell (with rowspan).
+	MergeRepeats bool
+	// Formats maps column (or label level) names to the fmt verb used to format each non-null value (e.g., "%.2f").
+	Formats map[string]string
+	// NullString is written in place of null values.
+	NullString string
+	w          io.Writer
+}
+
+// NewHTMLWriter returns an *HTMLWriter with default settings.
+// By default, label levels are included, repeated labels are merged if the global option is set (see PrintOptionMergeRepeats),
+// and null values are written as (null).
+func NewHTMLWriter(w io.Writer) *HTMLWriter {
+	return &HTMLWriter{
+		IncludeLabels: true,
+		MergeRepeats:  optionMergeRepeats,
+		NullString:    optionsNullPrinter,
+		w:             w,
+	}
+}
+
+// Write writes df as an HTML <table>, with df's name (if it has one) as the caption.
+// Multi-level column names are written as one header row per level, and adjacent headers with the same name
+// (in the same parent header) are merged with colspan. Label values are written as row headers (<th>),
+// and numeric columns are right-aligned. All text is HTML-escaped.
+func (w *HTMLWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing html: %v", df.err)
+	}
+	t := newMarkupTable(df, w.IncludeLabels, w.MergeRepeats, w.Formats, w.NullString)
+	bw := bufio.NewWriter(w.w)
+	bw.WriteString("<table>\n")
+	if df.name != "" {
+		fmt.Fprintf(bw, "  <caption>%s</caption>\n", html.EscapeString(df.name))
+	}
+	bw.WriteString("  <thead>\n")
+	for l := range t.headers {
+		bw.WriteString("    <tr>\n")
+		for k, span := range t.headerSpans(l) {
+			if span == 0 {
+				continue
+			}
+			attr := ""
+			if span > 1 {
+				attr = fmt.Sprintf(` colspan="%d"`, span)
+			}
+			fmt.Fprintf(bw, "      <th%s>%s</th>\n", attr, html.EscapeString(t.headers[l][k]))
+		}
+		bw.WriteString("    </tr>\n")
+	}
+	bw.WriteString("  </thead>\n  <tbody>\n")
+	for i := range t.rows {
+		bw.WriteString("    <tr>\n")
+		for k := range t.rows[i] {
+			value := html.EscapeString(t.rows[i][k])
+			if k < t.numLabels {
+				if t.spans[i][k] == 0 {
+					continue
+				}
+				attr := ""
+				if t.spans[i][k] > 1 {
+					attr = fmt.Sprintf(` rowspan="%d"`, t.spans[i][k])
+				}
+				fmt.Fprintf(bw, "      <th%s>%s</th>\n", attr, value)
+				continue
+			}
+			if t.numeric[k] {
+				fmt.Fprintf(bw, "      <td style=\"text-align: right;\">%s</td>\n", value)
+				continue
+			}
+			fmt.Fprintf(bw, "      <td>%s</td>\n", value)
+		}
+		bw.WriteString("    </tr>\n")
+	}
+	bw.WriteString("  </tbody>\n</table>\n")
+	err := bw.Flush()
+	if err != nil {
+		return fmt.Errorf("writing html: %v", err)
+	}
+	return nil
+}
+
+// LaTeXWriter writes a DataFrame as a LaTeX tabular environment.
+type LaTeXWriter struct {
+	IncludeLabels bool
+	// MergeRepeats blanks label values that repeat the row above (as in DataFrame.String()).
+	MergeRepeats bool
+	// Formats maps column (or label level) names to the fmt verb used to format each non-null value (e.g., "%.2f").
+	Formats map[string]string
+	// NullString is written in place of null values.
+	NullString string
+	w          io.Writer
+}
+
+// NewLaTeXWriter returns a *LaTeXWriter with default settings.
+// By default, label levels are included, repeated labels are merged if the global option is set (see PrintOptionMergeRepeats),
+// and null values are written as (null).
+func NewLaTeXWriter(w io.Writer) *LaTeXWriter {
+	return &LaTeXWriter{
+		IncludeLabels: true,
+		MergeRepeats:  optionMergeRepeats,
+		NullString:    optionsNullPrinter,
+		w:             w,
+	}
+}
+
+// Write writes df as a tabular environment (requiring no packages beyond LaTeX itself).
+// If df has a name, the tabular is wrapped in a table environment with the name as its caption.
+// Multi-level column names are written as one header row per level, and adjacent headers with the same name
+// (in the same parent header) are merged with \multicolumn. Label columns are separated from the other columns by a rule,
+// and numeric columns are right-aligned. LaTeX special characters are escaped.
+func (w *LaTeXWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing latex: %v", df.err)
+	}
+	t := newMarkupTable(df, w.IncludeLabels, w.MergeRepeats, w.Formats, w.NullString)
+	bw := bufio.NewWriter(w.w)
+	if df.name != "" {
+		fmt.Fprintf(bw, "\\begin{table}\n\\centering\n\\caption{%s}\n", escapeLaTeX(df.name))
+	}
+	var spec strings.Builder
+	for k := 0; k < t.numCols(); k++ {
+		if k == t.numLabels && k > 0 {
+			spec.WriteString("|")
+		}
+		if t.numeric[k] {
+			spec.WriteString("r")
+		} else {
+			spec.WriteString("l")
+		}
+	}
+	fmt.Fprintf(bw, "\\begin{tabular}{%s}\n\\hline\n", spec.String())
+	for l := range t.headers {
+		var cells []string
+		for k, span := range t.headerSpans(l) {
+			if span == 0 {
+				continue
+			}
+			cell := escapeLaTeX(t.headers[l][k])
+			if span > 1 {
+				cell = fmt.Sprintf("\\multicolumn{%d}{c}{%s}", span, cell)
+			}
+			cells = append(cells, cell)
+		}
+		fmt.Fprintf(bw, "%s \\\\\n", strings.Join(cells, " & "))
+	}
+	bw.WriteString("\\hline\n")
+	for i := range t.rows {
+		cells := make([]string, t.numCols())
+		for k := range cells {
+			if k < t.numLabels && t.spans[i][k] == 0 {
+				continue
+			}
+			cells[k] = escapeLaTeX(t.rows[i][k])
+		}
+		fmt.Fprintf(bw, "%s \\\\\n", strings.Join(cells, " & "))
+	}
+	bw.WriteString("\\hline\n\\end{tabular}\n")
+	if df.name != "" {
+		bw.WriteString("\\end{table}\n")
+	}
+	err := bw.Flush()
+	if err != nil {
+		return fmt.Errorf("writing latex: %v", err)
+	}
+	return nil
+}
+
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`, "{", `\{`, "}", `\}`,
+	"~", `\textasciitilde{}`, "^", `\textasciicircum{}`, "\r\n", " ", "\n", " ")
+
+// escapeLaTeX escapes characters that have special meaning in LaTeX text.
+func escapeLaTeX(s string) string {
+	return latexEscaper.Replace(s)
+}
+
+// a markupTable is the unescaped text of a DataFrame, laid out for Markdown, HTML, or LaTeX.
+type markupTable struct {
+	// headers has one row per column level, and one cell per label level and column
+	headers [][]string
+	rows    [][]string
+	numeric []bool
+	// spans[i][j] is the number of rows merged into the label cell at row i and label level j
+	// (0 if the cell is merged into the cell above)
+	spans     [][]int
+	numLabels int
+}
+
+func newMarkupTable(df *DataFrame, includeLabels bool, mergeRepeats bool, formats map[string]string, nullString string) *markupTable {
+	var containers []*valueContainer
+	t := &markupTable{}
+	if includeLabels {
+		containers = append(containers, df.labels...)
+		t.numLabels = len(df.labels)
+	}
+	containers = append(containers, df.values...)
+	numLevels := df.numColLevels()
+	t.headers = make([][]string, numLevels)
+	for l := range t.headers {
+		t.headers[l] = make([]string, len(containers))
+	}
+	t.numeric = make([]bool, len(containers))
+	values := make([][]string, len(containers))
+	for k, vc := range containers {
+		if k < t.numLabels {
+			// label names are written in the bottom header row, and default label names are hidden
+			if !strings.HasPrefix(vc.name, optionPrefix) {
+				t.headers[numLevels-1][k] = vc.name
+			}
+		} else {
+			for l, level := range splitNameIntoNumLevels(vc.name, numLevels) {
+				if l < numLevels {
+					t.headers[l][k] = level
+				}
+			}
+		}
+		t.numeric[k] = isNumericSlice(vc.slice)
+		values[k] = formatMarkupValues(vc, formats[vc.name], nullString)
+	}
+	t.rows = make([][]string, df.Len())
+	t.spans = make([][]int, df.Len())
+	for i := range t.rows {
+		t.rows[i] = make([]string, len(containers))
+		for k := range containers {
+			t.rows[i][k] = values[k][i]
+		}
+		t.spans[i] = make([]int, t.numLabels)
+		for j := 0; j < t.numLabels; j++ {
+			t.spans[i][j] = 1
+		}
+	}
+	if mergeRepeats {
+		// a label is merged only if every label level to its left is also merged (as in a hierarchical index)
+		for j := 0; j < t.numLabels; j++ {
+			start := 0
+			for i := 1; i < len(t.rows); i++ {
+				if t.rows[i][j] == t.rows[i-1][j] && (j == 0 || t.spans[i][j-1] == 0) {
+					t.spans[i][j] = 0
+					t.spans[start][j]++
+				} else {
+					start = i
+				}
+			}
+		}
+	}
+	return t
+}
+
+func (t *markupTable) numCols() int {
+	return len(t.numeric)
+}
+
+// headerSpans returns the number of columns merged into each header cell in column level l
+// (0 if the cell is merged into the cell to its left).
+// Label headers and headers in the last level are never merged.
+func (t *markupTable) headerSpans(l int) []int {
+	spans := make([]int, t.numCols())
+	start := 0
+	for k := range spans {
+		spans[k] = 1
+		if k <= t.numLabels || l == len(t.headers)-1 {
+			start = k
+			continue
+		}
+		merge := true
+		for level := 0; level <= l; level++ {
+			if t.headers[level][k] != t.headers[level][k-1] {
+				merge = false
+				break
+			}
+		}
+		if merge {
+			spans[k] = 0
+			spans[start]++
+		} else {
+			start = k
+		}
+	}
+	return spans
+}
+
+// formatMarkupValues returns the text of each value in vc, formatted with format (if not empty).
+func formatMarkupValues(vc *valueContainer, format string, nullString string) []string {
+	ret := make([]string, len(vc.isNull))
+	var stringified []string
+	if format == "" {
+		stringified = vc.copy().string().slice
+	}
+	v := reflect.ValueOf(vc.slice)
+	for i := range ret {
+		if vc.isNull[i] {
+			ret[i] = nullString
+		} else if format != "" {
+			ret[i] = fmt.Sprintf(format, v.Index(i).Interface())
+		} else {
+			ret[i] = stringified[i]
+		}
+	}
+	return ret
+}
+
+// isNumericSlice returns true if slice is a slice of integers or floats.
+func isNumericSlice(slice interface{}) bool {
+	switch reflect.TypeOf(slice).Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
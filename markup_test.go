@@ -0,0 +1,207 @@
+package tada
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func markupTestDF() *DataFrame {
+	return &DataFrame{values: []*valueContainer{
+		{slice: []float64{1.5, 2, 3}, isNull: []bool{false, false, true}, id: mockID, name: "sales|q1"},
+		{slice: []float64{4, 5, 6}, isNull: []bool{false, false, false}, id: mockID, name: "sales|q2"},
+		{slice: []string{"a|b", "<i>", "50%_&"}, isNull: []bool{false, false, false}, id: mockID, name: "note|"}},
+		labels: []*valueContainer{
+			{slice: []string{"us", "us", "eu"}, isNull: []bool{false, false, false}, id: mockID, name: "region"},
+			{slice: []int{0, 0, 0}, isNull: []bool{false, false, false}, id: mockID, name: "*1"}},
+		colLevelNames: []string{"*0", "*1"},
+		name:          "baz"}
+}
+
+func TestMarkdownWriter_Write(t *testing.T) {
+	tests := []struct {
+		name    string
+		w       MarkdownWriter
+		df      *DataFrame
+		want    string
+		wantErr bool
+	}{
+		{"merge, formats, multi-level headers, escapes",
+			MarkdownWriter{IncludeLabels: true, MergeRepeats: true, NullString: "", Formats: map[string]string{"sales|q1": "%.2f"}},
+			markupTestDF(),
+			"**baz**\n\n" +
+				"| region |  | sales<br>q1 | sales<br>q2 | note |\n" +
+				"| --- | ---: | ---: | ---: | --- |\n" +
+				"| us | 0 | 1.50 | 4 | a\\|b |\n" +
+				"|  |  | 2.00 | 5 | \\<i\\> |\n" +
+				"| eu | 0 |  | 6 | 50%\\_& |\n",
+			false},
+		{"no labels, no merge",
+			MarkdownWriter{NullString: "(null)"},
+			markupTestDF().SetName("").SubsetCols([]int{0}),
+			"| sales<br>q1 |\n" +
+				"| ---: |\n" +
+				"| 1.5 |\n" +
+				"| 2 |\n" +
+				"| (null) |\n",
+			false},
+		{"fail - df error", MarkdownWriter{}, dataFrameWithError(errors.New("foo")), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := tt.w
+			w.w = buf
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("MarkdownWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("MarkdownWriter.Write() = \n%v\nwant \n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLWriter_Write(t *testing.T) {
+	tests := []struct {
+		name    string
+		w       HTMLWriter
+		df      *DataFrame
+		want    string
+		wantErr bool
+	}{
+		{"merge, formats, multi-level headers, escapes",
+			HTMLWriter{IncludeLabels: true, MergeRepeats: true, NullString: "n/a", Formats: map[string]string{"sales|q2": "%03.0f"}},
+			markupTestDF(),
+			`<table>
+  <caption>baz</caption>
+  <thead>
+    <tr>
+      <th></th>
+      <th></th>
+      <th colspan="2">sales</th>
+      <th>note</th>
+    </tr>
+    <tr>
+      <th>region</th>
+      <th></th>
+      <th>q1</th>
+      <th>q2</th>
+      <th></th>
+    </tr>
+  </thead>
+  <tbody>
+    <tr>
+      <th rowspan="2">us</th>
+      <th rowspan="2">0</th>
+      <td style="text-align: right;">1.5</td>
+      <td style="text-align: right;">004</td>
+      <td>a|b</td>
+    </tr>
+    <tr>
+      <td style="text-align: right;">2</td>
+      <td style="text-align: right;">005</td>
+      <td>&lt;i&gt;</td>
+    </tr>
+    <tr>
+      <th>eu</th>
+      <th>0</th>
+      <td style="text-align: right;">n/a</td>
+      <td style="text-align: right;">006</td>
+      <td>50%_&amp;</td>
+    </tr>
+  </tbody>
+</table>
+`,
+			false},
+		{"fail - df error", HTMLWriter{}, dataFrameWithError(errors.New("foo")), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := tt.w
+			w.w = buf
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("HTMLWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("HTMLWriter.Write() = \n%v\nwant \n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLaTeXWriter_Write(t *testing.T) {
+	tests := []struct {
+		name    string
+		w       LaTeXWriter
+		df      *DataFrame
+		want    string
+		wantErr bool
+	}{
+		{"merge, multi-level headers, escapes",
+			LaTeXWriter{IncludeLabels: true, MergeRepeats: true, NullString: "--"},
+			markupTestDF(),
+			`\begin{table}
+\centering
+\caption{baz}
+\begin{tabular}{lr|rrl}
+\hline
+ &  & \multicolumn{2}{c}{sales} & note \\
+region &  & q1 & q2 &  \\
+\hline
+us & 0 & 1.5 & 4 & a|b \\
+ &  & 2 & 5 & <i> \\
+eu & 0 & -- & 6 & 50\%\_\& \\
+\hline
+\end{tabular}
+\end{table}
+`,
+			false},
+		{"no name, no labels, special characters",
+			LaTeXWriter{},
+			(&DataFrame{values: []*valueContainer{
+				{slice: []string{`\{x}^~#$`}, isNull: []bool{false}, id: mockID, name: "a_b"}},
+				labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}}),
+			`\begin{tabular}{l}
+\hline
+a\_b \\
+\hline
+\textbackslash{}\{x\}\textasciicircum{}\textasciitilde{}\#\$ \\
+\hline
+\end{tabular}
+`,
+			false},
+		{"fail - df error", LaTeXWriter{}, dataFrameWithError(errors.New("foo")), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := tt.w
+			w.w = buf
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("LaTeXWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("LaTeXWriter.Write() = \n%v\nwant \n%v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMarkdownWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if w := NewMarkdownWriter(buf); !w.IncludeLabels || w.NullString != "(null)" || w.w != buf {
+		t.Errorf("NewMarkdownWriter() = %v, want defaults", w)
+	}
+	if w := NewHTMLWriter(buf); !w.IncludeLabels || w.NullString != "(null)" || w.w != buf {
+		t.Errorf("NewHTMLWriter() = %v, want defaults", w)
+	}
+	if w := NewLaTeXWriter(buf); !w.IncludeLabels || w.NullString != "(null)" || w.w != buf {
+		t.Errorf("NewLaTeXWriter() = %v, want defaults", w)
+	}
+}
@@ -0,0 +1,240 @@
+package tada
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// -- multiple files
+
+// MultiFileReader reads every file that matches a glob pattern (or every file in a directory)
+// into a single DataFrame.
+type MultiFileReader struct {
+	// Options configure how each file is read (see ReadFile).
+	Options FileOptions
+	// NewReader, if not nil, returns the Reader for each (decompressed) file instead of the Reader registered for its format.
+	// For example, it may return a CSVReader with custom settings.
+	NewReader ReaderFunc
+	// SourceLabel, if not empty, is the name of a label level (added before the others)
+	// that holds the path of the file from which each row was read.
+	SourceLabel string
+	// Concurrency is the maximum number of files read at once (default: runtime.NumCPU()).
+	Concurrency int
+	pattern     string
+}
+
+// NewMultiFileReader returns a default MultiFileReader for the files that match pattern
+// (with the syntax of filepath.Match, e.g., "data/part-*.csv.gz").
+// If pattern is a directory, every file in the directory is read (excluding subdirectories and hidden files).
+func NewMultiFileReader(pattern string) MultiFileReader {
+	return MultiFileReader{
+		pattern: pattern,
+	}
+}
+
+// Read reads each file with the same configuration and concatenates the results in the lexical order of their paths,
+// so the output does not depend on the order in which the files finish reading.
+//
+// Columns are matched by name and ordered by their first appearance. A column that is missing from a file
+// is null for the rows from that file. A column whose values have different types in different files is converted to string.
+// Every file must have the same number of label levels and column levels.
+// If each file has default labels, the labels are renumbered from 0 across all files.
+func (r MultiFileReader) Read() (*DataFrame, error) {
+	paths, err := r.paths()
+	if err != nil {
+		return nil, fmt.Errorf("reading multiple files: %v", err)
+	}
+	dfs, err := readFiles(paths, r.Options, r.NewReader, r.Concurrency)
+	if err != nil {
+		return nil, fmt.Errorf("reading multiple files: %v", err)
+	}
+	df, err := concatenateDataFrames(dfs, paths, r.SourceLabel)
+	if err != nil {
+		return nil, fmt.Errorf("reading multiple files: %v", err)
+	}
+	df.name = r.Options.Name
+	return df, nil
+}
+
+// paths returns the sorted paths of the files to read.
+func (r MultiFileReader) paths() ([]string, error) {
+	var paths []string
+	if info, err := os.Stat(r.pattern); err == nil && info.IsDir() {
+		files, err := ioutil.ReadDir(r.pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if !file.IsDir() && !strings.HasPrefix(file.Name(), ".") {
+				paths = append(paths, filepath.Join(r.pattern, file.Name()))
+			}
+		}
+	} else {
+		matches, err := filepath.Glob(r.pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range matches {
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				paths = append(paths, path)
+			}
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no files match %q", r.pattern)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// readFiles reads the files concurrently (see readFile) and returns their DataFrames in the same order as paths.
+// If any file cannot be read, the error for the first such path is returned.
+func readFiles(paths []string, opts FileOptions, fn ReaderFunc, concurrency int) ([]*DataFrame, error) {
+	workers := concurrency
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(paths) {
+		workers = len(paths)
+	}
+	dfs := make([]*DataFrame, len(paths))
+	errs := make([]error, len(paths))
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				dfs[i], errs[i] = readFile(paths[i], opts, fn)
+			}
+		}()
+	}
+	for i := range paths {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+	for i := range errs {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("%s: %v", paths[i], errs[i])
+		}
+	}
+	return dfs, nil
+}
+
+// concatenateDataFrames appends the rows of each DataFrame in dfs (read from sources), matching columns by name.
+// If sourceName is not empty, a label level with that name is added before the others, with the value sources[i]
+// for every row from dfs[i].
+func concatenateDataFrames(dfs []*DataFrame, sources []string, sourceName string) (*DataFrame, error) {
+	numLabels := len(dfs[0].labels)
+	numLevels := len(dfs[0].colLevelNames)
+	var colNames []string
+	firstContainers := make(map[string]*valueContainer)
+	renumber := true
+	for i, df := range dfs {
+		if len(df.labels) != numLabels {
+			return nil, fmt.Errorf("%s: number of label levels (%d) must match %s (%d)",
+				sources[i], len(df.labels), sources[0], numLabels)
+		}
+		if len(df.colLevelNames) != numLevels {
+			return nil, fmt.Errorf("%s: number of column levels (%d) must match %s (%d)",
+				sources[i], len(df.colLevelNames), sources[0], numLevels)
+		}
+		if numLabels != 1 || !isDefaultLabels(df.labels[0]) {
+			renumber = false
+		}
+		for _, vc := range df.values {
+			if _, ok := firstContainers[vc.name]; !ok {
+				firstContainers[vc.name] = vc
+				colNames = append(colNames, vc.name)
+			}
+		}
+	}
+
+	labels := make([]*valueContainer, numLabels)
+	for j := range labels {
+		labels[j] = dfs[0].labels[j].copy()
+		for _, df := range dfs[1:] {
+			labels[j] = labels[j].append(df.labels[j])
+		}
+	}
+	if renumber {
+		labels[0] = makeDefaultLabels(0, labels[0].len(), true)
+	}
+	if sourceName != "" {
+		var slice []string
+		for i, df := range dfs {
+			for n := 0; n < df.Len(); n++ {
+				slice = append(slice, sources[i])
+			}
+		}
+		labels = append([]*valueContainer{newValueContainer(slice, make([]bool, len(slice)), sourceName)}, labels...)
+	}
+
+	values := make([]*valueContainer, len(colNames))
+	for k, name := range colNames {
+		for i, df := range dfs {
+			vc := findContainerByName(df.values, name)
+			if vc == nil {
+				vc = makeNullContainer(firstContainers[name], df.Len(), name)
+			}
+			if i == 0 {
+				values[k] = vc.copy()
+			} else {
+				values[k] = values[k].append(vc)
+			}
+		}
+		values[k].name = name
+		values[k].id = firstContainers[name].id
+	}
+	return &DataFrame{
+		labels:        labels,
+		values:        values,
+		colLevelNames: dfs[0].colLevelNames,
+	}, nil
+}
+
+// isDefaultLabels returns true if vc has the name and values of default labels (see makeDefaultLabels).
+func isDefaultLabels(vc *valueContainer) bool {
+	if vc.name != optionPrefix+"0" {
+		return false
+	}
+	ints, ok := vc.slice.([]int)
+	if !ok {
+		return false
+	}
+	for i := range ints {
+		if ints[i] != i || vc.isNull[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// findContainerByName returns the first container named name, or nil if there is none.
+func findContainerByName(containers []*valueContainer, name string) *valueContainer {
+	for _, vc := range containers {
+		if vc.name == name {
+			return vc
+		}
+	}
+	return nil
+}
+
+// makeNullContainer returns a container of n null values with the same type as like.
+func makeNullContainer(like *valueContainer, n int, name string) *valueContainer {
+	isNull := make([]bool, n)
+	for i := range isNull {
+		isNull[i] = true
+	}
+	slice := reflect.MakeSlice(reflect.TypeOf(like.slice), n, n).Interface()
+	return newValueContainer(slice, isNull, name)
+}
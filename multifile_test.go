@@ -0,0 +1,118 @@
+package tada
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		b := []byte(data)
+		if filepath.Ext(name) == ".gz" {
+			b = gzipBytes(b)
+		}
+		if err := ioutil.WriteFile(path, b, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestMultiFileReader_Read(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"part-2.csv":    "foo,baz\n3,c\n",
+		"part-1.csv.gz": "foo,bar\n1,a\n2,b\n",
+		"part-3.csv":    "bar\nd\n",
+		"notes.txt":     "foo",
+		"sub/a.csv":     "foo,bar\n4,x\n",
+		"sub/b.json":    `[{"foo": 10, "baz": true}]`,
+		"sub/.c.csv":    "qux\n1\n",
+		"sub/d/e.csv":   "qux\n1\n",
+		"levels/a.json": `[{"foo": 1}]`,
+		"levels/b.json": `[{"foo": {"bar": 2}}]`,
+	})
+	defer os.RemoveAll(dir)
+	type fields struct {
+		Options     FileOptions
+		NewReader   ReaderFunc
+		SourceLabel string
+		Concurrency int
+		pattern     string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"glob - missing columns are null", fields{pattern: filepath.Join(dir, "part-*.csv*")},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"1", "2", "3", ""}, isNull: []bool{false, false, false, true}, id: mockID, name: "foo"},
+				{slice: []string{"a", "b", "", "d"}, isNull: []bool{false, false, true, false}, id: mockID, name: "bar"},
+				{slice: []string{"", "", "c", ""}, isNull: []bool{true, true, false, true}, id: mockID, name: "baz"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2, 3}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"glob - source label, one worker", fields{pattern: filepath.Join(dir, "part-[12].csv*"), SourceLabel: "file", Concurrency: 1},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"1", "2", "3"}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+				{slice: []string{"a", "b", ""}, isNull: []bool{false, false, true}, id: mockID, name: "bar"},
+				{slice: []string{"", "", "c"}, isNull: []bool{true, true, false}, id: mockID, name: "baz"}},
+				labels: []*valueContainer{
+					{slice: []string{filepath.Join(dir, "part-1.csv.gz"), filepath.Join(dir, "part-1.csv.gz"), filepath.Join(dir, "part-2.csv")},
+						isNull: []bool{false, false, false}, id: mockID, name: "file"},
+					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"directory - mixed formats, label levels, name", fields{pattern: filepath.Join(dir, "sub"), Options: FileOptions{LabelLevels: 1, Name: "qux"}},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"x", ""}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+				{slice: []bool{false, true}, isNull: []bool{true, false}, id: mockID, name: "baz"}},
+				labels:        []*valueContainer{{slice: []string{"4", "10"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				colLevelNames: []string{"*0"}, name: "qux"},
+			false},
+		{"custom reader", fields{pattern: filepath.Join(dir, "part-3.csv"),
+			NewReader: func(r io.Reader, opts FileOptions) (Reader, error) {
+				reader := NewCSVReader(r)
+				reader.HeaderRows = 0
+				return reader, nil
+			}},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"bar", "d"}, isNull: []bool{false, false}, id: mockID, name: "0"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - no matches", fields{pattern: filepath.Join(dir, "*.parquet")}, nil, true},
+		{"fail - bad pattern", fields{pattern: "["}, nil, true},
+		{"fail - unregistered format", fields{pattern: filepath.Join(dir, "*")}, nil, true},
+		{"fail - different column levels", fields{pattern: filepath.Join(dir, "levels")}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := MultiFileReader{
+				Options:     tt.fields.Options,
+				NewReader:   tt.fields.NewReader,
+				SourceLabel: tt.fields.SourceLabel,
+				Concurrency: tt.fields.Concurrency,
+				pattern:     tt.fields.pattern,
+			}
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MultiFileReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("MultiFileReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
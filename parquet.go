@@ -0,0 +1,829 @@
+package tada
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"reflect"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// -- Apache Parquet
+
+// parquet physical types
+const (
+	parquetBoolean           = 0
+	parquetInt32             = 1
+	parquetInt64             = 2
+	parquetInt96             = 3
+	parquetFloat             = 4
+	parquetDouble            = 5
+	parquetByteArray         = 6
+	parquetFixedLenByteArray = 7
+)
+
+// parquet converted types (legacy logical type annotations)
+const (
+	parquetConvertedUTF8            = 0
+	parquetConvertedDate            = 6
+	parquetConvertedTimeMillis      = 7
+	parquetConvertedTimeMicros      = 8
+	parquetConvertedTimestampMillis = 9
+	parquetConvertedTimestampMicros = 10
+	parquetConvertedInt64           = 18
+)
+
+// parquet page types and encodings
+const (
+	parquetDataPage       = 0
+	parquetDictionaryPage = 2
+	parquetDataPageV2     = 3
+
+	parquetPlain           = 0
+	parquetPlainDictionary = 2
+	parquetRLE             = 3
+	parquetRLEDictionary   = 8
+)
+
+var parquetMagic = []byte("PAR1")
+
+var unixEpochDate = civil.Date{Year: 1970, Month: 1, Day: 1}
+
+// ParquetReader reads an Apache Parquet file into a DataFrame.
+type ParquetReader struct {
+	LabelLevels int
+	Name        string
+	r           io.Reader
+}
+
+// NewParquetReader returns a default ParquetReader.
+func NewParquetReader(r io.Reader) ParquetReader {
+	return ParquetReader{
+		LabelLevels: 0,
+		r:           r,
+	}
+}
+
+// Read reads a DataFrame from Apache Parquet data.
+// The entire file is read into memory, and all row groups are concatenated.
+// Only flat (non-nested) schemas are supported.
+//
+// Parquet types are read as:
+// BOOLEAN -> []bool;
+// INT32 and INT64 -> []int64, unless annotated as a DATE ([]civil.Date), TIME ([]civil.Time), or TIMESTAMP ([]time.Time);
+// INT96 -> []time.Time;
+// FLOAT and DOUBLE -> []float64;
+// BYTE_ARRAY -> []string.
+// Null values (i.e., values with an undefined definition level) are read as the zero value of the column type.
+//
+// If the data was written by ParquetWriter, the label levels, column level names, and DataFrame name are restored from the file metadata.
+// Otherwise, the first r.LabelLevels columns are read as label levels.
+func (r ParquetReader) Read() (*DataFrame, error) {
+	b, err := ioutil.ReadAll(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("reading parquet: %v", err)
+	}
+	containers, meta, err := readParquet(b)
+	if err != nil {
+		return nil, fmt.Errorf("reading parquet: %v", err)
+	}
+	if meta == nil {
+		if r.LabelLevels >= len(containers) {
+			return nil, fmt.Errorf("reading parquet: number of label levels (%d) must be less than number of columns (%d)",
+				r.LabelLevels, len(containers))
+		}
+		return containersToDF(containers, 1, r.LabelLevels, r.Name), nil
+	}
+	if meta.NumLabelLevels >= len(containers) {
+		return nil, fmt.Errorf("reading parquet: metadata: number of label levels (%d) must be less than number of columns (%d)",
+			meta.NumLabelLevels, len(containers))
+	}
+	name := meta.Name
+	if r.Name != "" {
+		name = r.Name
+	}
+	df := containersToDF(containers, 1, meta.NumLabelLevels, name)
+	if len(meta.ColLevelNames) > 0 {
+		df.colLevelNames = meta.ColLevelNames
+	}
+	return df, nil
+}
+
+// ParquetWriter writes a DataFrame as an Apache Parquet file.
+type ParquetWriter struct {
+	IncludeLabels bool
+	w             io.Writer
+}
+
+// NewParquetWriter returns a *ParquetWriter with default settings.
+// By default, label levels are written as ordinary columns and restored as label levels by ParquetReader.
+func NewParquetWriter(w io.Writer) *ParquetWriter {
+	return &ParquetWriter{
+		IncludeLabels: true,
+		w:             w,
+	}
+}
+
+// Write writes df to w as a single row group of uncompressed, plain-encoded, optional columns.
+// Null values are preserved as undefined definition levels.
+//
+// Container types are written as:
+// []float64 and []float32 -> DOUBLE;
+// []bool -> BOOLEAN;
+// signed integer slices -> INT64;
+// []time.Time -> INT64 TIMESTAMP(MICROS) adjusted to UTC (truncated to microseconds);
+// []civil.Date -> INT32 DATE;
+// []civil.Time -> INT64 TIME(MICROS);
+// all other types are converted to string and written as BYTE_ARRAY STRING.
+//
+// The number of label levels, the column level names, and the DataFrame name are written to the file metadata.
+func (w *ParquetWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing parquet: %v", df.err)
+	}
+	containers := df.values
+	var numLabels int
+	if w.IncludeLabels {
+		containers = append(df.labels, df.values...)
+		numLabels = len(df.labels)
+	}
+	meta, err := json.Marshal(dataFrameMetadata{
+		Name:           df.name,
+		NumLabelLevels: numLabels,
+		ColLevelNames:  df.colLevelNames,
+	})
+	if err != nil {
+		return fmt.Errorf("writing parquet: %v", err)
+	}
+	b, err := writeParquet(containers, string(meta))
+	if err != nil {
+		return fmt.Errorf("writing parquet: %v", err)
+	}
+	_, err = w.w.Write(b)
+	if err != nil {
+		return fmt.Errorf("writing parquet: %v", err)
+	}
+	return nil
+}
+
+// -- parquet internals
+
+// a parquetSchemaColumn describes a leaf column in a parquet schema
+type parquetSchemaColumn struct {
+	name          string
+	physicalType  int64
+	typeLength    int64
+	optional      bool
+	convertedType int64
+	logicalType   thriftStruct
+}
+
+// readParquet reads a parquet file into one valueContainer per column
+// and returns the tada metadata stored in the file, if any.
+func readParquet(b []byte) ([]*valueContainer, *dataFrameMetadata, error) {
+	if len(b) < 12 || !bytes.Equal(b[:4], parquetMagic) || !bytes.Equal(b[len(b)-4:], parquetMagic) {
+		return nil, nil, fmt.Errorf("not a parquet file")
+	}
+	footerLength := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+	if footerLength > len(b)-12 {
+		return nil, nil, fmt.Errorf("invalid footer length (%d)", footerLength)
+	}
+	footer := b[len(b)-8-footerLength : len(b)-8]
+	fileMetadata, _, err := decodeThriftStruct(footer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("file metadata: %v", err)
+	}
+	schema, err := readParquetSchema(fileMetadata.list(2))
+	if err != nil {
+		return nil, nil, fmt.Errorf("schema: %v", err)
+	}
+	// read each column chunk of each row group, then concatenate the row groups
+	values := make([]interface{}, len(schema))
+	isNull := make([][]bool, len(schema))
+	for k := range isNull {
+		isNull[k] = []bool{}
+	}
+	for g, rg := range fileMetadata.list(4) {
+		rowGroup, _ := rg.(thriftStruct)
+		chunks := rowGroup.list(1)
+		if len(chunks) != len(schema) {
+			return nil, nil, fmt.Errorf("row group %d: number of column chunks (%d) does not match schema (%d)",
+				g, len(chunks), len(schema))
+		}
+		for k := range chunks {
+			chunk, _ := chunks[k].(thriftStruct)
+			vals, nulls, err := readParquetColumnChunk(b, chunk.strct(3), schema[k])
+			if err != nil {
+				return nil, nil, fmt.Errorf("row group %d: column %s: %v", g, schema[k].name, err)
+			}
+			if values[k] == nil {
+				values[k] = vals
+			} else {
+				values[k] = reflect.AppendSlice(reflect.ValueOf(values[k]), reflect.ValueOf(vals)).Interface()
+			}
+			isNull[k] = append(isNull[k], nulls...)
+		}
+	}
+	ret := make([]*valueContainer, len(schema))
+	for k := range schema {
+		if values[k] == nil {
+			values[k] = parquetEmptySlice(schema[k])
+		}
+		ret[k] = newValueContainer(values[k], isNull[k], schema[k].name)
+	}
+	var meta *dataFrameMetadata
+	for _, kv := range fileMetadata.list(5) {
+		keyValue, _ := kv.(thriftStruct)
+		if keyValue.string(1) == dataFrameMetadataKey {
+			meta = new(dataFrameMetadata)
+			err := json.Unmarshal([]byte(keyValue.string(2)), meta)
+			if err != nil {
+				return nil, nil, fmt.Errorf("metadata: %v", err)
+			}
+		}
+	}
+	return ret, meta, nil
+}
+
+func readParquetSchema(elements []interface{}) ([]parquetSchemaColumn, error) {
+	if len(elements) < 2 {
+		return nil, fmt.Errorf("must contain at least one column")
+	}
+	ret := make([]parquetSchemaColumn, 0, len(elements)-1)
+	// the first element is the root of the schema
+	for _, e := range elements[1:] {
+		element, _ := e.(thriftStruct)
+		if numChildren, ok := element.int(5); ok && numChildren > 0 {
+			return nil, fmt.Errorf("column %s: nested columns are not supported", element.string(4))
+		}
+		repetition, _ := element.int(3)
+		if repetition == 2 {
+			return nil, fmt.Errorf("column %s: repeated columns are not supported", element.string(4))
+		}
+		physicalType, _ := element.int(1)
+		typeLength, _ := element.int(2)
+		convertedType, ok := element.int(6)
+		if !ok {
+			convertedType = -1
+		}
+		ret = append(ret, parquetSchemaColumn{
+			name:          element.string(4),
+			physicalType:  physicalType,
+			typeLength:    typeLength,
+			optional:      repetition == 1,
+			convertedType: convertedType,
+			logicalType:   element.strct(10),
+		})
+	}
+	return ret, nil
+}
+
+// readParquetColumnChunk reads every page in a column chunk
+// and returns the column values (with zero values in null positions) and their null status.
+func readParquetColumnChunk(b []byte, meta thriftStruct, col parquetSchemaColumn) (interface{}, []bool, error) {
+	if meta == nil {
+		return nil, nil, fmt.Errorf("missing column metadata")
+	}
+	codec, _ := meta.int(4)
+	numValues, _ := meta.int(5)
+	offset, _ := meta.int(9)
+	if dictOffset, ok := meta.int(11); ok && dictOffset > 0 && dictOffset < offset {
+		offset = dictOffset
+	}
+	var dictionary interface{}
+	var definedValues interface{}
+	definitionLevels := make([]int32, 0, numValues)
+	pos := int(offset)
+	for int64(len(definitionLevels)) < numValues {
+		if pos < 0 || pos >= len(b) {
+			return nil, nil, fmt.Errorf("page offset out of range [%d] with length %d", pos, len(b))
+		}
+		header, n, err := decodeThriftStruct(b[pos:])
+		if err != nil {
+			return nil, nil, fmt.Errorf("page header: %v", err)
+		}
+		pos += n
+		uncompressedSize, _ := header.int(2)
+		compressedSize, _ := header.int(3)
+		if compressedSize < 0 || pos+int(compressedSize) > len(b) {
+			return nil, nil, fmt.Errorf("page size exceeds file length")
+		}
+		page := b[pos : pos+int(compressedSize)]
+		pos += int(compressedSize)
+
+		pageType, _ := header.int(1)
+		var pageValues interface{}
+		var pageLevels []int32
+		switch pageType {
+		case parquetDictionaryPage:
+			data, err := decompressParquetPage(codec, page, int(uncompressedSize))
+			if err != nil {
+				return nil, nil, fmt.Errorf("dictionary page: %v", err)
+			}
+			n, _ := header.strct(7).int(1)
+			dictionary, _, err = decodeParquetPlain(data, col, int(n))
+			if err != nil {
+				return nil, nil, fmt.Errorf("dictionary page: %v", err)
+			}
+			continue
+		case parquetDataPage:
+			data, err := decompressParquetPage(codec, page, int(uncompressedSize))
+			if err != nil {
+				return nil, nil, fmt.Errorf("data page: %v", err)
+			}
+			h := header.strct(5)
+			n, _ := h.int(1)
+			encoding, _ := h.int(2)
+			if col.optional {
+				if len(data) < 4 {
+					return nil, nil, fmt.Errorf("data page: missing definition levels")
+				}
+				l := int(binary.LittleEndian.Uint32(data))
+				if 4+l > len(data) {
+					return nil, nil, fmt.Errorf("data page: definition levels exceed page length")
+				}
+				pageLevels, err = decodeRLEHybrid(data[4:4+l], 1, int(n))
+				if err != nil {
+					return nil, nil, fmt.Errorf("data page: definition levels: %v", err)
+				}
+				data = data[4+l:]
+			} else {
+				pageLevels = parquetRequiredLevels(int(n))
+			}
+			pageValues, err = decodeParquetValues(data, col, encoding, countDefined(pageLevels), dictionary)
+			if err != nil {
+				return nil, nil, fmt.Errorf("data page: %v", err)
+			}
+		case parquetDataPageV2:
+			h := header.strct(8)
+			n, _ := h.int(1)
+			encoding, _ := h.int(4)
+			defLength, _ := h.int(5)
+			repLength, _ := h.int(6)
+			if int(defLength+repLength) > len(page) {
+				return nil, nil, fmt.Errorf("data page: levels exceed page length")
+			}
+			if col.optional {
+				pageLevels, err = decodeRLEHybrid(page[repLength:repLength+defLength], 1, int(n))
+				if err != nil {
+					return nil, nil, fmt.Errorf("data page: definition levels: %v", err)
+				}
+			} else {
+				pageLevels = parquetRequiredLevels(int(n))
+			}
+			data := page[repLength+defLength:]
+			if isCompressed, ok := h.bool(7); !ok || isCompressed {
+				data, err = decompressParquetPage(codec, data, int(uncompressedSize-defLength-repLength))
+				if err != nil {
+					return nil, nil, fmt.Errorf("data page: %v", err)
+				}
+			}
+			pageValues, err = decodeParquetValues(data, col, encoding, countDefined(pageLevels), dictionary)
+			if err != nil {
+				return nil, nil, fmt.Errorf("data page: %v", err)
+			}
+		default:
+			// skip index pages and unknown page types
+			continue
+		}
+		definitionLevels = append(definitionLevels, pageLevels...)
+		if definedValues == nil {
+			definedValues = pageValues
+		} else {
+			definedValues = reflect.AppendSlice(reflect.ValueOf(definedValues), reflect.ValueOf(pageValues)).Interface()
+		}
+	}
+	if definedValues == nil {
+		definedValues = parquetEmptySlice(col)
+	}
+	// expand the defined values to full length, with zero values in null positions
+	src := reflect.ValueOf(definedValues)
+	vals := reflect.MakeSlice(src.Type(), len(definitionLevels), len(definitionLevels))
+	isNull := make([]bool, len(definitionLevels))
+	var counter int
+	for i, level := range definitionLevels {
+		if level == 0 {
+			isNull[i] = true
+			continue
+		}
+		vals.Index(i).Set(src.Index(counter))
+		counter++
+	}
+	return convertParquetLogicalType(vals.Interface(), isNull, col), isNull, nil
+}
+
+func parquetRequiredLevels(n int) []int32 {
+	ret := make([]int32, n)
+	for i := range ret {
+		ret[i] = 1
+	}
+	return ret
+}
+
+func countDefined(levels []int32) int {
+	var ret int
+	for _, level := range levels {
+		if level > 0 {
+			ret++
+		}
+	}
+	return ret
+}
+
+// decodeParquetValues decodes n values that are either plain-encoded or dictionary-encoded.
+func decodeParquetValues(data []byte, col parquetSchemaColumn, encoding int64, n int, dictionary interface{}) (interface{}, error) {
+	switch encoding {
+	case parquetPlain:
+		ret, _, err := decodeParquetPlain(data, col, n)
+		return ret, err
+	case parquetPlainDictionary, parquetRLEDictionary:
+		if dictionary == nil {
+			return nil, fmt.Errorf("dictionary-encoded page without dictionary")
+		}
+		if n == 0 {
+			return parquetEmptySlice(col), nil
+		}
+		if len(data) == 0 {
+			return nil, fmt.Errorf("missing dictionary indices")
+		}
+		indices, err := decodeRLEHybrid(data[1:], int(data[0]), n)
+		if err != nil {
+			return nil, fmt.Errorf("dictionary indices: %v", err)
+		}
+		l := reflect.ValueOf(dictionary).Len()
+		index := make([]int, len(indices))
+		for i := range indices {
+			if int(indices[i]) >= l || indices[i] < 0 {
+				return nil, fmt.Errorf("dictionary index out of range [%d] with length %d", indices[i], l)
+			}
+			index[i] = int(indices[i])
+		}
+		return subsetInterfaceSlice(dictionary, index), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding (%d)", encoding)
+	}
+}
+
+// parquetEmptySlice returns an empty slice of the type returned by decodeParquetPlain for col.
+func parquetEmptySlice(col parquetSchemaColumn) interface{} {
+	ret, _, _ := decodeParquetPlain(nil, col, 0)
+	return convertParquetLogicalType(ret, nil, col)
+}
+
+// decodeParquetPlain decodes n plain-encoded values and returns them with the number of bytes consumed.
+func decodeParquetPlain(data []byte, col parquetSchemaColumn, n int) (interface{}, int, error) {
+	var width int
+	switch col.physicalType {
+	case parquetBoolean:
+		if (n+7)/8 > len(data) {
+			return nil, 0, fmt.Errorf("plain values: unexpected end of data")
+		}
+		ret := make([]bool, n)
+		for i := range ret {
+			ret[i] = data[i/8]&(1<<uint(i%8)) != 0
+		}
+		return ret, (n + 7) / 8, nil
+	case parquetInt32, parquetFloat:
+		width = 4
+	case parquetInt64, parquetDouble:
+		width = 8
+	case parquetInt96:
+		width = 12
+	case parquetFixedLenByteArray:
+		width = int(col.typeLength)
+	case parquetByteArray:
+		ret := make([]string, n)
+		pos := 0
+		for i := range ret {
+			if pos+4 > len(data) {
+				return nil, 0, fmt.Errorf("plain values: unexpected end of data")
+			}
+			l := int(binary.LittleEndian.Uint32(data[pos:]))
+			pos += 4
+			if pos+l > len(data) {
+				return nil, 0, fmt.Errorf("plain values: unexpected end of data")
+			}
+			ret[i] = string(data[pos : pos+l])
+			pos += l
+		}
+		return ret, pos, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported physical type (%d)", col.physicalType)
+	}
+	if n*width > len(data) {
+		return nil, 0, fmt.Errorf("plain values: unexpected end of data")
+	}
+	switch col.physicalType {
+	case parquetInt32:
+		ret := make([]int64, n)
+		for i := range ret {
+			ret[i] = int64(int32(binary.LittleEndian.Uint32(data[i*4:])))
+		}
+		return ret, n * width, nil
+	case parquetInt64:
+		ret := make([]int64, n)
+		for i := range ret {
+			ret[i] = int64(binary.LittleEndian.Uint64(data[i*8:]))
+		}
+		return ret, n * width, nil
+	case parquetFloat:
+		ret := make([]float64, n)
+		for i := range ret {
+			ret[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:])))
+		}
+		return ret, n * width, nil
+	case parquetDouble:
+		ret := make([]float64, n)
+		for i := range ret {
+			ret[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*8:]))
+		}
+		return ret, n * width, nil
+	case parquetInt96:
+		// legacy timestamp: nanoseconds within the day, followed by the julian day number
+		ret := make([]time.Time, n)
+		for i := range ret {
+			nanos := int64(binary.LittleEndian.Uint64(data[i*12:]))
+			julianDay := int64(binary.LittleEndian.Uint32(data[i*12+8:]))
+			ret[i] = time.Unix((julianDay-2440588)*86400, nanos).UTC()
+		}
+		return ret, n * width, nil
+	default:
+		ret := make([]string, n)
+		for i := range ret {
+			ret[i] = string(data[i*width : (i+1)*width])
+		}
+		return ret, n * width, nil
+	}
+}
+
+// parquetTimeUnit returns the number of nanoseconds in the time unit of a TIME or TIMESTAMP logical type
+func parquetTimeUnit(logicalType thriftStruct) int64 {
+	unit := logicalType.strct(2)
+	if _, ok := unit[1]; ok {
+		return int64(time.Millisecond)
+	}
+	if _, ok := unit[3]; ok {
+		return int64(time.Nanosecond)
+	}
+	return int64(time.Microsecond)
+}
+
+// convertParquetLogicalType converts integer values annotated with a date or time logical type
+// to []civil.Date, []civil.Time, or []time.Time. All other values are returned unchanged.
+func convertParquetLogicalType(vals interface{}, isNull []bool, col parquetSchemaColumn) interface{} {
+	ints, ok := vals.([]int64)
+	if !ok {
+		return vals
+	}
+	var nanosPerUnit int64
+	var dtype DType
+	switch {
+	case col.logicalType.strct(6) != nil || col.convertedType == parquetConvertedDate:
+		dtype = Date
+	case col.logicalType.strct(7) != nil:
+		dtype = Time
+		nanosPerUnit = parquetTimeUnit(col.logicalType.strct(7))
+	case col.convertedType == parquetConvertedTimeMillis:
+		dtype, nanosPerUnit = Time, int64(time.Millisecond)
+	case col.convertedType == parquetConvertedTimeMicros:
+		dtype, nanosPerUnit = Time, int64(time.Microsecond)
+	case col.logicalType.strct(8) != nil:
+		dtype = DateTime
+		nanosPerUnit = parquetTimeUnit(col.logicalType.strct(8))
+	case col.convertedType == parquetConvertedTimestampMillis:
+		dtype, nanosPerUnit = DateTime, int64(time.Millisecond)
+	case col.convertedType == parquetConvertedTimestampMicros:
+		dtype, nanosPerUnit = DateTime, int64(time.Microsecond)
+	default:
+		return vals
+	}
+	switch dtype {
+	case Date:
+		ret := make([]civil.Date, len(ints))
+		for i := range ints {
+			if isNull[i] {
+				continue
+			}
+			ret[i] = unixEpochDate.AddDays(int(ints[i]))
+		}
+		return ret
+	case Time:
+		ret := make([]civil.Time, len(ints))
+		for i := range ints {
+			if isNull[i] {
+				continue
+			}
+			ret[i] = civil.TimeOf(time.Unix(0, ints[i]*nanosPerUnit).UTC())
+		}
+		return ret
+	default:
+		ret := make([]time.Time, len(ints))
+		unitsPerSecond := int64(time.Second) / nanosPerUnit
+		for i := range ints {
+			if isNull[i] {
+				continue
+			}
+			seconds := ints[i] / unitsPerSecond
+			remainder := ints[i] % unitsPerSecond
+			ret[i] = time.Unix(seconds, remainder*nanosPerUnit).UTC()
+		}
+		return ret
+	}
+}
+
+// writeParquet writes containers as a parquet file with a single row group.
+// If metadata is not empty, it is written as key-value metadata.
+func writeParquet(containers []*valueContainer, metadata string) ([]byte, error) {
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("must have at least one container")
+	}
+	numRows := containers[0].len()
+	buf := new(bytes.Buffer)
+	buf.Write(parquetMagic)
+
+	schema := []thriftStruct{{
+		4: "schema",
+		5: int32(len(containers)),
+	}}
+	chunks := make([]thriftStruct, len(containers))
+	var totalSize int64
+	for k := range containers {
+		element, values, err := encodeParquetColumn(containers[k])
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", containers[k].name, err)
+		}
+		schema = append(schema, element)
+
+		// definition levels: 1 = defined, 0 = null
+		levels := make([]int32, numRows)
+		for i := range levels {
+			if !containers[k].isNull[i] {
+				levels[i] = 1
+			}
+		}
+		encodedLevels := encodeRLE(levels, 1)
+		page := make([]byte, 4, 4+len(encodedLevels)+len(values))
+		binary.LittleEndian.PutUint32(page, uint32(len(encodedLevels)))
+		page = append(page, encodedLevels...)
+		page = append(page, values...)
+
+		header, err := encodeThriftStruct(thriftStruct{
+			1: int32(parquetDataPage),
+			2: int32(len(page)),
+			3: int32(len(page)),
+			5: thriftStruct{
+				1: int32(numRows),
+				2: int32(parquetPlain),
+				3: int32(parquetRLE),
+				4: int32(parquetRLE),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", containers[k].name, err)
+		}
+		offset := int64(buf.Len())
+		buf.Write(header)
+		buf.Write(page)
+		size := int64(len(header) + len(page))
+		totalSize += size
+		chunks[k] = thriftStruct{
+			2: offset,
+			3: thriftStruct{
+				1: element[1],
+				2: []int32{parquetPlain, parquetRLE},
+				3: []string{containers[k].name},
+				4: int32(parquetUncompressed),
+				5: int64(numRows),
+				6: size,
+				7: size,
+				9: offset,
+			},
+		}
+	}
+	fileMetadata := thriftStruct{
+		1: int32(1),
+		2: schema,
+		3: int64(numRows),
+		4: []thriftStruct{{
+			1: chunks,
+			2: totalSize,
+			3: int64(numRows),
+		}},
+		6: "tada",
+	}
+	if metadata != "" {
+		fileMetadata[5] = []thriftStruct{{
+			1: dataFrameMetadataKey,
+			2: metadata,
+		}}
+	}
+	footer, err := encodeThriftStruct(fileMetadata)
+	if err != nil {
+		return nil, err
+	}
+	buf.Write(footer)
+	binary.Write(buf, binary.LittleEndian, uint32(len(footer)))
+	buf.Write(parquetMagic)
+	return buf.Bytes(), nil
+}
+
+// encodeParquetColumn returns the schema element for vc and its non-null values in plain encoding.
+func encodeParquetColumn(vc *valueContainer) (thriftStruct, []byte, error) {
+	element := thriftStruct{
+		3: int32(1), // optional
+		4: vc.name,
+	}
+	buf := new(bytes.Buffer)
+	switch vc.slice.(type) {
+	case []float64, []float32:
+		element[1] = int32(parquetDouble)
+		vals := vc.float64().slice
+		for i := range vals {
+			if !vc.isNull[i] {
+				binary.Write(buf, binary.LittleEndian, vals[i])
+			}
+		}
+	case []bool:
+		element[1] = int32(parquetBoolean)
+		vals := vc.slice.([]bool)
+		var current byte
+		var counter uint
+		for i := range vals {
+			if vc.isNull[i] {
+				continue
+			}
+			if vals[i] {
+				current |= 1 << (counter % 8)
+			}
+			counter++
+			if counter%8 == 0 {
+				buf.WriteByte(current)
+				current = 0
+			}
+		}
+		if counter%8 != 0 {
+			buf.WriteByte(current)
+		}
+	case []int, []int8, []int16, []int32, []int64:
+		element[1] = int32(parquetInt64)
+		element[6] = int32(parquetConvertedInt64)
+		element[10] = thriftStruct{10: thriftStruct{1: int32(64), 2: true}}
+		v := reflect.ValueOf(vc.slice)
+		for i := 0; i < v.Len(); i++ {
+			if !vc.isNull[i] {
+				binary.Write(buf, binary.LittleEndian, v.Index(i).Int())
+			}
+		}
+	case []time.Time:
+		element[1] = int32(parquetInt64)
+		element[6] = int32(parquetConvertedTimestampMicros)
+		element[10] = thriftStruct{8: thriftStruct{1: true, 2: thriftStruct{2: thriftStruct{}}}}
+		vals := vc.slice.([]time.Time)
+		for i := range vals {
+			if !vc.isNull[i] {
+				micros := vals[i].Unix()*1e6 + int64(vals[i].Nanosecond())/1e3
+				binary.Write(buf, binary.LittleEndian, micros)
+			}
+		}
+	case []civil.Date:
+		element[1] = int32(parquetInt32)
+		element[6] = int32(parquetConvertedDate)
+		element[10] = thriftStruct{6: thriftStruct{}}
+		vals := vc.slice.([]civil.Date)
+		for i := range vals {
+			if !vc.isNull[i] {
+				binary.Write(buf, binary.LittleEndian, int32(vals[i].DaysSince(unixEpochDate)))
+			}
+		}
+	case []civil.Time:
+		element[1] = int32(parquetInt64)
+		element[6] = int32(parquetConvertedTimeMicros)
+		element[10] = thriftStruct{7: thriftStruct{1: false, 2: thriftStruct{2: thriftStruct{}}}}
+		vals := vc.slice.([]civil.Time)
+		for i := range vals {
+			if !vc.isNull[i] {
+				t := vals[i]
+				micros := int64(t.Hour*3600+t.Minute*60+t.Second)*1e6 + int64(t.Nanosecond)/1e3
+				binary.Write(buf, binary.LittleEndian, micros)
+			}
+		}
+	default:
+		element[1] = int32(parquetByteArray)
+		element[6] = int32(parquetConvertedUTF8)
+		element[10] = thriftStruct{1: thriftStruct{}}
+		vals := vc.string().slice
+		for i := range vals {
+			if !vc.isNull[i] {
+				binary.Write(buf, binary.LittleEndian, uint32(len(vals[i])))
+				buf.WriteString(vals[i])
+			}
+		}
+	}
+	return element, buf.Bytes(), nil
+}
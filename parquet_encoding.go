@@ -0,0 +1,561 @@
+package tada
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+)
+
+// -- thrift compact protocol
+
+// thrift compact protocol type identifiers
+const (
+	thriftStop       = 0
+	thriftTrue       = 1
+	thriftFalse      = 2
+	thriftByte       = 3
+	thriftI16        = 4
+	thriftI32        = 5
+	thriftI64        = 6
+	thriftDouble     = 7
+	thriftBinary     = 8
+	thriftList       = 9
+	thriftSet        = 10
+	thriftMap        = 11
+	thriftStructType = 12
+)
+
+// a thriftStruct maps field ids to field values.
+// When decoded, values are one of: bool, int64, float64, []byte, []interface{}, thriftStruct.
+// When encoded, values may additionally be: int32, string, []thriftStruct, []string, []int32.
+type thriftStruct map[int16]interface{}
+
+func (s thriftStruct) int(id int16) (int64, bool) {
+	v, ok := s[id].(int64)
+	return v, ok
+}
+
+func (s thriftStruct) bool(id int16) (bool, bool) {
+	v, ok := s[id].(bool)
+	return v, ok
+}
+
+func (s thriftStruct) string(id int16) string {
+	v, _ := s[id].([]byte)
+	return string(v)
+}
+
+func (s thriftStruct) strct(id int16) thriftStruct {
+	v, _ := s[id].(thriftStruct)
+	return v
+}
+
+func (s thriftStruct) list(id int16) []interface{} {
+	v, _ := s[id].([]interface{})
+	return v
+}
+
+type compactDecoder struct {
+	b   []byte
+	pos int
+}
+
+func (d *compactDecoder) readByte() (byte, error) {
+	if d.pos >= len(d.b) {
+		return 0, fmt.Errorf("unexpected end of thrift data")
+	}
+	b := d.b[d.pos]
+	d.pos++
+	return b, nil
+}
+
+func (d *compactDecoder) readUvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.b[d.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid thrift varint at position %d", d.pos)
+	}
+	d.pos += n
+	return v, nil
+}
+
+func (d *compactDecoder) readVarint() (int64, error) {
+	v, err := d.readUvarint()
+	if err != nil {
+		return 0, err
+	}
+	// zigzag decoding
+	return int64(v>>1) ^ -int64(v&1), nil
+}
+
+func (d *compactDecoder) readBinary() ([]byte, error) {
+	l, err := d.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(d.b)-d.pos) < l {
+		return nil, fmt.Errorf("thrift binary length (%d) exceeds remaining data", l)
+	}
+	ret := d.b[d.pos : d.pos+int(l)]
+	d.pos += int(l)
+	return ret, nil
+}
+
+func (d *compactDecoder) readValue(thriftType byte) (interface{}, error) {
+	switch thriftType {
+	case thriftTrue:
+		return true, nil
+	case thriftFalse:
+		return false, nil
+	case thriftByte:
+		b, err := d.readByte()
+		return int64(int8(b)), err
+	case thriftI16, thriftI32, thriftI64:
+		return d.readVarint()
+	case thriftDouble:
+		if len(d.b)-d.pos < 8 {
+			return nil, fmt.Errorf("unexpected end of thrift data")
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(d.b[d.pos:]))
+		d.pos += 8
+		return v, nil
+	case thriftBinary:
+		return d.readBinary()
+	case thriftList, thriftSet:
+		return d.readList()
+	case thriftMap:
+		return d.readMap()
+	case thriftStructType:
+		return d.readStruct()
+	default:
+		return nil, fmt.Errorf("unsupported thrift type (%d)", thriftType)
+	}
+}
+
+func (d *compactDecoder) readList() ([]interface{}, error) {
+	header, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	size := int(header >> 4)
+	elemType := header & 0x0f
+	if size == 15 {
+		l, err := d.readUvarint()
+		if err != nil {
+			return nil, err
+		}
+		size = int(l)
+	}
+	if size > len(d.b)-d.pos && elemType != thriftTrue && elemType != thriftFalse {
+		return nil, fmt.Errorf("thrift list size (%d) exceeds remaining data", size)
+	}
+	ret := make([]interface{}, size)
+	for i := range ret {
+		// booleans within containers are encoded as a single byte
+		if elemType == thriftTrue || elemType == thriftFalse {
+			b, err := d.readByte()
+			if err != nil {
+				return nil, err
+			}
+			ret[i] = b == thriftTrue
+			continue
+		}
+		ret[i], err = d.readValue(elemType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// maps are not used by parquet metadata, so they are read and discarded
+func (d *compactDecoder) readMap() (interface{}, error) {
+	size, err := d.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, nil
+	}
+	types, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint64(0); i < size; i++ {
+		if _, err := d.readValue(types >> 4); err != nil {
+			return nil, err
+		}
+		if _, err := d.readValue(types & 0x0f); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (d *compactDecoder) readStruct() (thriftStruct, error) {
+	ret := make(thriftStruct)
+	var lastID int16
+	for {
+		header, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if header == thriftStop {
+			return ret, nil
+		}
+		fieldType := header & 0x0f
+		delta := int16(header >> 4)
+		var id int16
+		if delta == 0 {
+			v, err := d.readVarint()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		} else {
+			id = lastID + delta
+		}
+		lastID = id
+		ret[id], err = d.readValue(fieldType)
+		if err != nil {
+			return nil, fmt.Errorf("field %d: %v", id, err)
+		}
+	}
+}
+
+// decodeThriftStruct decodes a single thrift struct from the beginning of b
+// and returns the struct and the number of bytes consumed.
+func decodeThriftStruct(b []byte) (thriftStruct, int, error) {
+	d := &compactDecoder{b: b}
+	s, err := d.readStruct()
+	if err != nil {
+		return nil, 0, fmt.Errorf("decoding thrift struct: %v", err)
+	}
+	return s, d.pos, nil
+}
+
+type compactEncoder struct {
+	buf bytes.Buffer
+}
+
+func (e *compactEncoder) writeUvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	e.buf.Write(b[:n])
+}
+
+func (e *compactEncoder) writeVarint(v int64) {
+	// zigzag encoding
+	e.writeUvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (e *compactEncoder) writeBinary(b []byte) {
+	e.writeUvarint(uint64(len(b)))
+	e.buf.Write(b)
+}
+
+func (e *compactEncoder) writeListHeader(size int, elemType byte) {
+	if size < 15 {
+		e.buf.WriteByte(byte(size<<4) | elemType)
+		return
+	}
+	e.buf.WriteByte(0xf0 | elemType)
+	e.writeUvarint(uint64(size))
+}
+
+func (e *compactEncoder) writeFieldHeader(id, lastID int16, fieldType byte) {
+	if delta := id - lastID; delta > 0 && delta <= 15 {
+		e.buf.WriteByte(byte(delta<<4) | fieldType)
+		return
+	}
+	e.buf.WriteByte(fieldType)
+	e.writeVarint(int64(id))
+}
+
+func (e *compactEncoder) writeStruct(s thriftStruct) error {
+	ids := make([]int, 0, len(s))
+	for id := range s {
+		ids = append(ids, int(id))
+	}
+	sort.Ints(ids)
+	var lastID int16
+	for _, i := range ids {
+		id := int16(i)
+		switch v := s[id].(type) {
+		case bool:
+			if v {
+				e.writeFieldHeader(id, lastID, thriftTrue)
+			} else {
+				e.writeFieldHeader(id, lastID, thriftFalse)
+			}
+		case int32:
+			e.writeFieldHeader(id, lastID, thriftI32)
+			e.writeVarint(int64(v))
+		case int64:
+			e.writeFieldHeader(id, lastID, thriftI64)
+			e.writeVarint(v)
+		case string:
+			e.writeFieldHeader(id, lastID, thriftBinary)
+			e.writeBinary([]byte(v))
+		case []byte:
+			e.writeFieldHeader(id, lastID, thriftBinary)
+			e.writeBinary(v)
+		case thriftStruct:
+			e.writeFieldHeader(id, lastID, thriftStructType)
+			if err := e.writeStruct(v); err != nil {
+				return err
+			}
+		case []thriftStruct:
+			e.writeFieldHeader(id, lastID, thriftList)
+			e.writeListHeader(len(v), thriftStructType)
+			for k := range v {
+				if err := e.writeStruct(v[k]); err != nil {
+					return err
+				}
+			}
+		case []string:
+			e.writeFieldHeader(id, lastID, thriftList)
+			e.writeListHeader(len(v), thriftBinary)
+			for k := range v {
+				e.writeBinary([]byte(v[k]))
+			}
+		case []int32:
+			e.writeFieldHeader(id, lastID, thriftList)
+			e.writeListHeader(len(v), thriftI32)
+			for k := range v {
+				e.writeVarint(int64(v[k]))
+			}
+		default:
+			return fmt.Errorf("encoding thrift struct: field %d: unsupported type (%T)", id, v)
+		}
+		lastID = id
+	}
+	e.buf.WriteByte(thriftStop)
+	return nil
+}
+
+func encodeThriftStruct(s thriftStruct) ([]byte, error) {
+	e := &compactEncoder{}
+	err := e.writeStruct(s)
+	if err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+// -- RLE/bit-packing hybrid
+
+// decodeRLEHybrid decodes n values of bitWidth from the RLE/bit-packing hybrid encoding.
+func decodeRLEHybrid(b []byte, bitWidth int, n int) ([]int32, error) {
+	ret := make([]int32, 0, n)
+	byteWidth := (bitWidth + 7) / 8
+	pos := 0
+	for len(ret) < n {
+		header, l := binary.Uvarint(b[pos:])
+		if l <= 0 {
+			return nil, fmt.Errorf("decoding rle: invalid run header at position %d", pos)
+		}
+		pos += l
+		if header&1 == 0 {
+			// rle run: repeated value stored in byteWidth bytes
+			count := int(header >> 1)
+			if pos+byteWidth > len(b) {
+				return nil, fmt.Errorf("decoding rle: unexpected end of data")
+			}
+			var v int32
+			for i := 0; i < byteWidth; i++ {
+				v |= int32(b[pos+i]) << (8 * uint(i))
+			}
+			pos += byteWidth
+			for i := 0; i < count && len(ret) < n; i++ {
+				ret = append(ret, v)
+			}
+			continue
+		}
+		// bit-packed run: groups of 8 values, least significant bit first
+		numValues := int(header>>1) * 8
+		numBytes := int(header>>1) * bitWidth
+		if pos+numBytes > len(b) {
+			return nil, fmt.Errorf("decoding bit-packed run: unexpected end of data")
+		}
+		run := b[pos : pos+numBytes]
+		pos += numBytes
+		for i := 0; i < numValues && len(ret) < n; i++ {
+			var v int32
+			for bit := 0; bit < bitWidth; bit++ {
+				offset := i*bitWidth + bit
+				if run[offset/8]&(1<<uint(offset%8)) != 0 {
+					v |= 1 << uint(bit)
+				}
+			}
+			ret = append(ret, v)
+		}
+	}
+	return ret, nil
+}
+
+// encodeRLE encodes values of bitWidth as a series of rle runs.
+func encodeRLE(values []int32, bitWidth int) []byte {
+	e := &compactEncoder{}
+	byteWidth := (bitWidth + 7) / 8
+	for i := 0; i < len(values); {
+		j := i
+		for j < len(values) && values[j] == values[i] {
+			j++
+		}
+		e.writeUvarint(uint64(j-i) << 1)
+		for k := 0; k < byteWidth; k++ {
+			e.buf.WriteByte(byte(values[i] >> (8 * uint(k))))
+		}
+		i = j
+	}
+	return e.buf.Bytes()
+}
+
+// -- compression
+
+// parquet compression codecs
+const (
+	parquetUncompressed = 0
+	parquetSnappy       = 1
+	parquetGzip         = 2
+)
+
+// decompressParquetPage decompresses b, which must decompress to exactly size bytes
+// (the uncompressed size in the page header).
+func decompressParquetPage(codec int64, b []byte, size int) ([]byte, error) {
+	if size < 0 {
+		return nil, fmt.Errorf("invalid uncompressed page size (%d)", size)
+	}
+	var ret []byte
+	switch codec {
+	case parquetUncompressed:
+		return b, nil
+	case parquetSnappy:
+		// check the length header before allocating
+		if l, n := binary.Uvarint(b); n > 0 && l != uint64(size) {
+			return nil, fmt.Errorf("decoding snappy: decoded length does not match page header (%d != %d)", l, size)
+		}
+		return snappyDecode(b)
+	case parquetGzip:
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		// read at most one byte more than size, to detect a page that decompresses to more than its header states
+		ret, err = ioutil.ReadAll(io.LimitReader(r, int64(size)+1))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported compression codec (%d)", codec)
+	}
+	if len(ret) != size {
+		return nil, fmt.Errorf("decompressed length does not match page header (%d != %d)", len(ret), size)
+	}
+	return ret, nil
+}
+
+// snappyMaxExpansion is the largest ratio of decoded to encoded length in the snappy format
+// (a 3-byte copy element decodes to at most 64 bytes).
+const snappyMaxExpansion = 22
+
+// snappyDecode decodes a block in the raw (unframed) snappy format.
+func snappyDecode(src []byte) ([]byte, error) {
+	l, n := binary.Uvarint(src)
+	if n <= 0 {
+		return nil, fmt.Errorf("decoding snappy: invalid length header")
+	}
+	if l > uint64(len(src))*snappyMaxExpansion {
+		return nil, fmt.Errorf("decoding snappy: length header (%d) exceeds the maximum for %d bytes of input", l, len(src))
+	}
+	dst := make([]byte, 0, l)
+	pos := n
+	for pos < len(src) {
+		tag := src[pos]
+		pos++
+		switch tag & 0x03 {
+		case 0x00:
+			// literal
+			length := int(tag >> 2)
+			if length >= 60 {
+				extraBytes := length - 59
+				if pos+extraBytes > len(src) {
+					return nil, fmt.Errorf("decoding snappy: unexpected end of data")
+				}
+				length = 0
+				for i := 0; i < extraBytes; i++ {
+					length |= int(src[pos+i]) << (8 * uint(i))
+				}
+				pos += extraBytes
+			}
+			length++
+			if pos+length > len(src) {
+				return nil, fmt.Errorf("decoding snappy: unexpected end of data")
+			}
+			if uint64(len(dst)+length) > l {
+				return nil, fmt.Errorf("decoding snappy: decoded length exceeds header (%d)", l)
+			}
+			dst = append(dst, src[pos:pos+length]...)
+			pos += length
+			continue
+		case 0x01:
+			// copy with 1-byte offset
+			if pos >= len(src) {
+				return nil, fmt.Errorf("decoding snappy: unexpected end of data")
+			}
+			length := int(tag>>2&0x07) + 4
+			offset := int(tag>>5)<<8 | int(src[pos])
+			pos++
+			if err := snappyCopy(&dst, offset, length, l); err != nil {
+				return nil, err
+			}
+		case 0x02:
+			// copy with 2-byte offset
+			if pos+2 > len(src) {
+				return nil, fmt.Errorf("decoding snappy: unexpected end of data")
+			}
+			length := int(tag>>2) + 1
+			offset := int(binary.LittleEndian.Uint16(src[pos:]))
+			pos += 2
+			if err := snappyCopy(&dst, offset, length, l); err != nil {
+				return nil, err
+			}
+		case 0x03:
+			// copy with 4-byte offset
+			if pos+4 > len(src) {
+				return nil, fmt.Errorf("decoding snappy: unexpected end of data")
+			}
+			length := int(tag>>2) + 1
+			offset := int(binary.LittleEndian.Uint32(src[pos:]))
+			pos += 4
+			if err := snappyCopy(&dst, offset, length, l); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if uint64(len(dst)) != l {
+		return nil, fmt.Errorf("decoding snappy: decoded length does not match header (%d != %d)", len(dst), l)
+	}
+	return dst, nil
+}
+
+// copies are byte-by-byte because the source and destination may overlap
+func snappyCopy(dst *[]byte, offset, length int, maxLength uint64) error {
+	if offset <= 0 || offset > len(*dst) {
+		return fmt.Errorf("decoding snappy: invalid copy offset (%d)", offset)
+	}
+	if uint64(len(*dst)+length) > maxLength {
+		return fmt.Errorf("decoding snappy: decoded length exceeds header (%d)", maxLength)
+	}
+	start := len(*dst) - offset
+	for i := 0; i < length; i++ {
+		*dst = append(*dst, (*dst)[start+i])
+	}
+	return nil
+}
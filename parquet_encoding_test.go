@@ -0,0 +1,134 @@
+package tada
+
+import (
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"testing"
+)
+
+func Test_encodeThriftStruct(t *testing.T) {
+	s := thriftStruct{
+		1:   int32(-5),
+		2:   []thriftStruct{{4: "foo"}, {20: int64(1 << 40)}},
+		3:   true,
+		4:   []string{"bar", "baz"},
+		5:   thriftStruct{1: false},
+		300: []int32{1, 2},
+	}
+	want := thriftStruct{
+		1:   int64(-5),
+		2:   []interface{}{thriftStruct{4: []byte("foo")}, thriftStruct{20: int64(1 << 40)}},
+		3:   true,
+		4:   []interface{}{[]byte("bar"), []byte("baz")},
+		5:   thriftStruct{1: false},
+		300: []interface{}{int64(1), int64(2)},
+	}
+	b, err := encodeThriftStruct(s)
+	if err != nil {
+		t.Errorf("encodeThriftStruct() error = %v", err)
+		return
+	}
+	got, n, err := decodeThriftStruct(b)
+	if err != nil {
+		t.Errorf("decodeThriftStruct() error = %v", err)
+		return
+	}
+	if n != len(b) {
+		t.Errorf("decodeThriftStruct() n = %v, want %v", n, len(b))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decodeThriftStruct() = %v, want %v", got, want)
+	}
+}
+
+func Test_decodeRLEHybrid(t *testing.T) {
+	type args struct {
+		b        []byte
+		bitWidth int
+		n        int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []int32
+		wantErr bool
+	}{
+		{"rle", args{encodeRLE([]int32{1, 1, 0, 2}, 2), 2, 4}, []int32{1, 1, 0, 2}, false},
+		{"bit-packed", args{[]byte{3, 0x8D}, 1, 8}, []int32{1, 0, 1, 1, 0, 0, 0, 1}, false},
+		{"bit-packed - partial group", args{[]byte{3, 0x8D}, 1, 3}, []int32{1, 0, 1}, false},
+		{"fail - truncated", args{[]byte{3}, 1, 8}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeRLEHybrid(tt.args.b, tt.args.bitWidth, tt.args.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decodeRLEHybrid() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeRLEHybrid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_snappyDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     []byte
+		want    string
+		wantErr bool
+	}{
+		{"literal and copy", []byte{0x0a, 0x00, 'a', 0x15, 0x01}, "aaaaaaaaaa", false},
+		{"literal", []byte{0x03, 0x08, 'f', 'o', 'o'}, "foo", false},
+		{"fail - copy before start", []byte{0x0a, 0x15, 0x01}, "", true},
+		{"fail - length header exceeds maximum expansion", []byte{0xff, 0xff, 0xff, 0xff, 0x0f, 0x00, 'a'}, "", true},
+		{"fail - decoded length exceeds header", []byte{0x02, 0x08, 'f', 'o', 'o'}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := snappyDecode(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("snappyDecode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("snappyDecode() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_decompressParquetPage(t *testing.T) {
+	gzipped := new(bytes.Buffer)
+	w := gzip.NewWriter(gzipped)
+	w.Write([]byte("foobar"))
+	w.Close()
+	tests := []struct {
+		name    string
+		codec   int64
+		b       []byte
+		size    int
+		want    string
+		wantErr bool
+	}{
+		{"snappy", parquetSnappy, []byte{0x03, 0x08, 'f', 'o', 'o'}, 3, "foo", false},
+		{"gzip", parquetGzip, gzipped.Bytes(), 6, "foobar", false},
+		{"fail - snappy length does not match page header", parquetSnappy, []byte{0x03, 0x08, 'f', 'o', 'o'}, 2, "", true},
+		{"fail - gzip exceeds page header", parquetGzip, gzipped.Bytes(), 3, "", true},
+		{"fail - negative size", parquetGzip, gzipped.Bytes(), -1, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decompressParquetPage(tt.codec, tt.b, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decompressParquetPage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if string(got) != tt.want {
+				t.Errorf("decompressParquetPage() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package tada
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+func TestParquetWriter_Write(t *testing.T) {
+	d := time.Date(2020, 1, 1, 12, 30, 0, 500000000, time.UTC)
+	type fields struct {
+		IncludeLabels bool
+	}
+	type args struct {
+		df *DataFrame
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - round trip", fields{IncludeLabels: true},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1.5, 0, 3}, isNull: []bool{false, true, false}, id: mockID, name: "foo"},
+					{slice: []string{"a", "", "c"}, isNull: []bool{false, true, false}, id: mockID, name: "bar"},
+					{slice: []bool{true, false, true}, isNull: []bool{false, false, false}, id: mockID, name: "baz"},
+					{slice: []time.Time{d, {}, d}, isNull: []bool{false, true, false}, id: mockID, name: "qux"},
+					{slice: []civil.Date{{Year: 2020, Month: 1, Day: 2}, {Year: 1969, Month: 12, Day: 31}, {}},
+						isNull: []bool{false, false, true}, id: mockID, name: "corge"},
+					{slice: []civil.Time{{Hour: 1, Minute: 2, Second: 3}, {}, {Hour: 23}},
+						isNull: []bool{false, true, false}, id: mockID, name: "grault"},
+				},
+				labels: []*valueContainer{
+					{slice: []int64{0, -1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"},
+				name:          "qux"}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1.5, 0, 3}, isNull: []bool{false, true, false}, id: mockID, name: "foo"},
+					{slice: []string{"a", "", "c"}, isNull: []bool{false, true, false}, id: mockID, name: "bar"},
+					{slice: []bool{true, false, true}, isNull: []bool{false, false, false}, id: mockID, name: "baz"},
+					{slice: []time.Time{d, {}, d}, isNull: []bool{false, true, false}, id: mockID, name: "qux"},
+					{slice: []civil.Date{{Year: 2020, Month: 1, Day: 2}, {Year: 1969, Month: 12, Day: 31}, {}},
+						isNull: []bool{false, false, true}, id: mockID, name: "corge"},
+					{slice: []civil.Time{{Hour: 1, Minute: 2, Second: 3}, {}, {Hour: 23}},
+						isNull: []bool{false, true, false}, id: mockID, name: "grault"},
+				},
+				labels: []*valueContainer{
+					{slice: []int64{0, -1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"},
+				name:          "qux"},
+			false},
+		{"pass - exclude labels", fields{IncludeLabels: false},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels: []*valueContainer{
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []int64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - empty", fields{IncludeLabels: true},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{}, isNull: []bool{}, id: mockID, name: "foo"}},
+				labels: []*valueContainer{
+					{slice: []int64{}, isNull: []bool{}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{}, isNull: []bool{}, id: mockID, name: "foo"}},
+				labels: []*valueContainer{
+					{slice: []int64{}, isNull: []bool{}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - error in df", fields{IncludeLabels: true},
+			args{dataFrameWithError(errors.New("foo"))},
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := NewParquetWriter(b)
+			w.IncludeLabels = tt.fields.IncludeLabels
+			if err := w.Write(tt.args.df); (err != nil) != tt.wantErr {
+				t.Errorf("ParquetWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := NewParquetReader(b).Read()
+			if err != nil {
+				t.Errorf("ParquetReader.Read() error = %v", err)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("ParquetWriter.Write() -> ParquetReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParquetReader_Read(t *testing.T) {
+	noMetadata, _ := writeParquet([]*valueContainer{
+		{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+		{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+	}, "")
+	type fields struct {
+		LabelLevels int
+		Name        string
+		b           []byte
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - no tada metadata", fields{LabelLevels: 1, Name: "baz", b: noMetadata},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				colLevelNames: []string{"*0"},
+				name:          "baz"},
+			false},
+		{"fail - too many label levels", fields{LabelLevels: 2, b: noMetadata},
+			nil, true},
+		{"fail - not parquet", fields{b: []byte("foo,bar\n1,2\n")},
+			nil, true},
+		{"fail - truncated", fields{b: noMetadata[len(noMetadata)-20:]},
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewParquetReader(bytes.NewReader(tt.fields.b))
+			r.LabelLevels = tt.fields.LabelLevels
+			r.Name = tt.fields.Name
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParquetReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("ParquetReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// pyarrowFixture returns the table in every file in test_pyarrow (see test_pyarrow/generate.py).
+func pyarrowFixture() *DataFrame {
+	return &DataFrame{
+		values: []*valueContainer{
+			{slice: []int64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "id"},
+			{slice: []string{"foo", "", "foo"}, isNull: []bool{false, true, false}, id: mockID, name: "name"},
+			{slice: []float64{1.5, 0, 3}, isNull: []bool{false, true, false}, id: mockID, name: "score"},
+			{slice: []bool{true, false, false}, isNull: []bool{false, false, true}, id: mockID, name: "flag"},
+		},
+		labels:        []*valueContainer{makeDefaultLabels(0, 3, true)},
+		colLevelNames: []string{"*0"},
+	}
+}
+
+// readPyarrowFixture returns the contents of a file written by test_pyarrow/generate.py,
+// and skips the test if it has not been generated.
+func readPyarrowFixture(t *testing.T, name string) []byte {
+	b, err := ioutil.ReadFile(filepath.Join("test_pyarrow", name))
+	if os.IsNotExist(err) {
+		t.Skipf("test_pyarrow/%s has not been generated (run python3 test_pyarrow/generate.py with pyarrow installed)", name)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestParquetReader_Read_pyarrow(t *testing.T) {
+	for _, name := range []string{"pyarrow.parquet", "pyarrow_v2_gzip.parquet"} {
+		t.Run(name, func(t *testing.T) {
+			b := readPyarrowFixture(t, name)
+			got, err := NewParquetReader(bytes.NewReader(b)).Read()
+			if err != nil {
+				t.Fatalf("ParquetReader.Read() error = %v", err)
+			}
+			if !EqualDataFrames(got, pyarrowFixture()) {
+				t.Errorf("ParquetReader.Read() = %v, want %v", got, pyarrowFixture())
+			}
+		})
+	}
+}
+
+// test_pyarrow/tada.parquet is checked by test_pyarrow/generate.py, so it must match the current output of ParquetWriter.
+func TestParquetWriter_Write_pyarrowFixture(t *testing.T) {
+	want, err := ioutil.ReadFile(filepath.Join("test_pyarrow", "tada.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := new(bytes.Buffer)
+	w := NewParquetWriter(got)
+	w.IncludeLabels = false
+	err = w.Write(pyarrowFixture())
+	if err != nil {
+		t.Fatalf("ParquetWriter.Write() error = %v", err)
+	}
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Errorf("ParquetWriter.Write() does not match test_pyarrow/tada.parquet " +
+			"(if the change is intended, replace the file and check it with test_pyarrow/generate.py)")
+	}
+}
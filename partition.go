@@ -0,0 +1,241 @@
+package tada
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// -- Hive-style partitions
+
+// hiveDefaultPartition is the directory value of a null partition key.
+const hiveDefaultPartition = "__HIVE_DEFAULT_PARTITION__"
+
+// PartitionedWriter writes each group of a DataFrame to its own file in a Hive-style directory tree
+// (e.g., date=2026-10-01/region=us/part.csv).
+type PartitionedWriter struct {
+	// FileName is the name of the file written in each partition directory (default: "part.csv").
+	FileName string
+	// Options configure how each file is written (see WriteFile).
+	Options FileOptions
+	// NewWriter, if not nil, returns the Writer for each file instead of the Writer registered for the format of FileName.
+	NewWriter     WriterFunc
+	dir           string
+	partitionCols []string
+}
+
+// NewPartitionedWriter returns a *PartitionedWriter with default settings that writes to the directory dir,
+// partitioned by the containers (either label levels or columns) named in partitionCols.
+func NewPartitionedWriter(dir string, partitionCols ...string) *PartitionedWriter {
+	return &PartitionedWriter{
+		FileName:      "part.csv",
+		dir:           dir,
+		partitionCols: partitionCols,
+	}
+}
+
+// Write groups the rows of df by the partition containers (as in DataFrame.GroupBy) and writes each group
+// to the file w.FileName in a directory with one level per partition container, named key=value.
+// The partition containers are removed from each group before it is written (a removed label level is replaced
+// with default labels if it is the only label level), and can be rebuilt by PartitionedReader.
+//
+// Values are stringified and escaped as in Hive: characters that are not allowed in paths (and %, =, and /)
+// are replaced with their percent-encoding, and null values are written as __HIVE_DEFAULT_PARTITION__.
+// Existing files with the same path are overwritten.
+func (w *PartitionedWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing partitions: %v", df.err)
+	}
+	if len(w.partitionCols) == 0 {
+		return fmt.Errorf("writing partitions: must supply at least one partition container")
+	}
+	g := df.GroupBy(w.partitionCols...)
+	if g.err != nil {
+		return fmt.Errorf("writing partitions: %v", g.err)
+	}
+	partitionValues := make([][]string, len(g.labels))
+	for j := range g.labels {
+		partitionValues[j] = g.labels[j].string().slice
+	}
+	for i := range g.rowIndices {
+		segments := make([]string, len(w.partitionCols)+1)
+		segments[0] = w.dir
+		for j := range g.labels {
+			value := hiveDefaultPartition
+			if !g.labels[j].isNull[i] {
+				value = escapePartitionValue(partitionValues[j][i])
+			}
+			segments[j+1] = escapePartitionValue(g.labels[j].name) + "=" + value
+		}
+		dir := filepath.Join(segments...)
+		partition, err := dropPartitionContainers(df.Subset(g.rowIndices[i]), w.partitionCols)
+		if err != nil {
+			return fmt.Errorf("writing partitions: %v", err)
+		}
+		err = os.MkdirAll(dir, 0755)
+		if err != nil {
+			return fmt.Errorf("writing partitions: %v", err)
+		}
+		err = writeFile(filepath.Join(dir, w.FileName), partition, w.Options, w.NewWriter)
+		if err != nil {
+			return fmt.Errorf("writing partitions: %v", err)
+		}
+	}
+	return nil
+}
+
+// dropPartitionContainers removes the label levels or columns named in names from df.
+// As in GroupBy, a label level takes precedence over a column with the same name.
+func dropPartitionContainers(df *DataFrame, names []string) (*DataFrame, error) {
+	for _, name := range names {
+		if _, err := indexOfContainer(name, df.labels); err == nil {
+			if len(df.labels) == 1 {
+				df.InPlace().Relabel()
+			} else {
+				df.InPlace().DropLabels(name)
+			}
+			continue
+		}
+		if len(df.values) == 1 {
+			return nil, fmt.Errorf("cannot partition by every column (%v)", name)
+		}
+		df.InPlace().DropCol(name)
+	}
+	return df, nil
+}
+
+// escapePartitionValue percent-encodes the characters that Hive escapes in partition directory names.
+func escapePartitionValue(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 0x20 || c == 0x7F || strings.IndexByte("\"#%'*/:=?\\{[]^", c) >= 0 {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// unescapePartitionValue reverses escapePartitionValue. Invalid escape sequences are kept as is.
+func unescapePartitionValue(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// PartitionedReader reads a Hive-style directory tree (e.g., as written by PartitionedWriter) into a single DataFrame.
+type PartitionedReader struct {
+	// Options configure how each file is read (see ReadFile).
+	Options FileOptions
+	// NewReader, if not nil, returns the Reader for each (decompressed) file instead of the Reader registered for its format.
+	NewReader ReaderFunc
+	// Concurrency is the maximum number of files read at once (default: runtime.NumCPU()).
+	Concurrency int
+	dir         string
+}
+
+// NewPartitionedReader returns a default PartitionedReader for the directory dir.
+func NewPartitionedReader(dir string) PartitionedReader {
+	return PartitionedReader{
+		dir: dir,
+	}
+}
+
+// Read reads every file in the directory tree and concatenates the results in the lexical order of their paths
+// (as in MultiFileReader). Hidden files and files whose names start with an underscore (e.g., _SUCCESS) are skipped,
+// as are directories whose names start with an underscore, unless they are named key=value (e.g., _temporary but not _id=1).
+//
+// Each directory named key=value between r.dir and a file is rebuilt as a column named key, with the (unescaped) value
+// in every row read from that file. Partition columns are added after the other columns as []string values,
+// and __HIVE_DEFAULT_PARTITION__ is read as a null value. A partition column that is missing from a file's path is null.
+func (r PartitionedReader) Read() (*DataFrame, error) {
+	var paths []string
+	err := filepath.Walk(r.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name := info.Name()
+		// a key=value directory is a partition even if its key starts with an underscore
+		isPartition := info.IsDir() && strings.Index(name, "=") > 0
+		if path != r.dir && (strings.HasPrefix(name, ".") || (strings.HasPrefix(name, "_") && !isPartition)) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading partitions: %v", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("reading partitions: no files in %q", r.dir)
+	}
+	sort.Strings(paths)
+	dfs, err := readFiles(paths, r.Options, r.NewReader, r.Concurrency)
+	if err != nil {
+		return nil, fmt.Errorf("reading partitions: %v", err)
+	}
+	for i := range dfs {
+		err := addPartitionColumns(dfs[i], r.dir, paths[i])
+		if err != nil {
+			return nil, fmt.Errorf("reading partitions: %s: %v", paths[i], err)
+		}
+	}
+	df, err := concatenateDataFrames(dfs, paths, "")
+	if err != nil {
+		return nil, fmt.Errorf("reading partitions: %v", err)
+	}
+	df.name = r.Options.Name
+	return df, nil
+}
+
+// addPartitionColumns adds a column to df for each directory named key=value in the path of the file relative to root.
+func addPartitionColumns(df *DataFrame, root string, path string) error {
+	rel, err := filepath.Rel(root, filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	for _, segment := range strings.Split(filepath.ToSlash(rel), "/") {
+		split := strings.Index(segment, "=")
+		if split <= 0 {
+			continue
+		}
+		name := unescapePartitionValue(segment[:split])
+		if _, err := indexOfContainer(name, df.values); err == nil {
+			return fmt.Errorf("partition column (%v) already exists", name)
+		}
+		value := unescapePartitionValue(segment[split+1:])
+		slice := make([]string, df.Len())
+		isNull := make([]bool, df.Len())
+		for i := range slice {
+			if segment[split+1:] == hiveDefaultPartition {
+				isNull[i] = true
+				continue
+			}
+			slice[i] = value
+		}
+		df.values = append(df.values, newValueContainer(slice, isNull, name))
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package tada
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func listFiles(dir string) []string {
+	var ret []string
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			ret = append(ret, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	sort.Strings(ret)
+	return ret
+}
+
+func TestPartitionedWriter_Write(t *testing.T) {
+	newDF := func() *DataFrame {
+		return &DataFrame{values: []*valueContainer{
+			{slice: []string{"2026-10-01", "2026-10-02", "2026-10-01", ""}, isNull: []bool{false, false, false, true}, id: mockID, name: "date"},
+			{slice: []string{"us", "us", "a/b=c", "us"}, isNull: []bool{false, false, false, false}, id: mockID, name: "region"},
+			{slice: []float64{1, 2, 3, 4}, isNull: []bool{false, false, false, false}, id: mockID, name: "amount"}},
+			labels:        []*valueContainer{{slice: []string{"a", "b", "c", "d"}, isNull: []bool{false, false, false, false}, id: mockID, name: "id"}},
+			colLevelNames: []string{"*0"}}
+	}
+	type fields struct {
+		FileName      string
+		Options       FileOptions
+		NewWriter     WriterFunc
+		partitionCols []string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		df        *DataFrame
+		wantFiles []string
+		wantErr   bool
+	}{
+		{"columns", fields{FileName: "part.csv", partitionCols: []string{"date", "region"}}, newDF(),
+			[]string{"date=2026-10-01/region=a%2Fb%3Dc/part.csv", "date=2026-10-01/region=us/part.csv",
+				"date=2026-10-02/region=us/part.csv", "date=__HIVE_DEFAULT_PARTITION__/region=us/part.csv"}, false},
+		{"label level and compression", fields{FileName: "part.json.gz", partitionCols: []string{"id"}}, newDF(),
+			[]string{"id=a/part.json.gz", "id=b/part.json.gz", "id=c/part.json.gz", "id=d/part.json.gz"}, false},
+		{"custom writer", fields{FileName: "data", partitionCols: []string{"region"},
+			NewWriter: func(w io.Writer, opts FileOptions) (Writer, error) {
+				writer := NewCSVWriter(w)
+				writer.Comma = ';'
+				return writer, nil
+			}}, newDF(),
+			[]string{"region=a%2Fb%3Dc/data", "region=us/data"}, false},
+		{"fail - no partitions", fields{FileName: "part.csv"}, newDF(), nil, true},
+		{"fail - every column", fields{FileName: "part.csv", partitionCols: []string{"date", "region", "amount"}}, newDF(), nil, true},
+		{"fail - missing column", fields{FileName: "part.csv", partitionCols: []string{"corge"}}, newDF(), nil, true},
+		{"fail - unregistered format", fields{FileName: "part.foo", partitionCols: []string{"date"}}, newDF(), nil, true},
+		{"fail - df error", fields{FileName: "part.csv", partitionCols: []string{"date"}}, dataFrameWithError(errors.New("foo")), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "tada")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			w := &PartitionedWriter{
+				FileName:      tt.fields.FileName,
+				Options:       tt.fields.Options,
+				NewWriter:     tt.fields.NewWriter,
+				dir:           dir,
+				partitionCols: tt.fields.partitionCols,
+			}
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("PartitionedWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := listFiles(dir); !reflect.DeepEqual(got, tt.wantFiles) {
+				t.Errorf("PartitionedWriter.Write() files = %v, want %v", got, tt.wantFiles)
+			}
+		})
+	}
+}
+
+func TestPartitionedReader_Read(t *testing.T) {
+	dir := writeTestFiles(t, map[string]string{
+		"date=2026-10-01/region=us/part.csv":                    "amount\n1\n3\n",
+		"date=2026-10-01/region=a%2Fb%3Dc/part.csv":             "amount\n2\n",
+		"date=__HIVE_DEFAULT_PARTITION__/region=us/part.csv.gz": "amount\n4\n",
+		"date=2026-10-02/part.csv":                              "amount,extra\n5,x\n",
+		"_SUCCESS":                                              "",
+		"date=2026-10-02/.part.csv.crc":                         "",
+		"_temporary/date=2026-10-03/part.csv":                   "amount\n6\n",
+	})
+	defer os.RemoveAll(dir)
+	conflict := writeTestFiles(t, map[string]string{"amount=1/part.csv": "amount\n1\n"})
+	defer os.RemoveAll(conflict)
+	underscore := writeTestFiles(t, map[string]string{
+		"_id=1/part.csv":            "amount\n1\n",
+		"_temporary/_id=2/part.csv": "amount\n2\n",
+	})
+	defer os.RemoveAll(underscore)
+	empty := writeTestFiles(t, map[string]string{"_SUCCESS": ""})
+	defer os.RemoveAll(empty)
+	tests := []struct {
+		name    string
+		r       PartitionedReader
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass", PartitionedReader{dir: dir, Options: FileOptions{Name: "foo"}},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"2", "1", "3", "5", "4"}, isNull: []bool{false, false, false, false, false}, id: mockID, name: "amount"},
+				{slice: []string{"2026-10-01", "2026-10-01", "2026-10-01", "2026-10-02", ""},
+					isNull: []bool{false, false, false, false, true}, id: mockID, name: "date"},
+				{slice: []string{"a/b=c", "us", "us", "", "us"}, isNull: []bool{false, false, false, true, false}, id: mockID, name: "region"},
+				{slice: []string{"", "", "", "x", ""}, isNull: []bool{true, true, true, false, true}, id: mockID, name: "extra"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2, 3, 4}, isNull: []bool{false, false, false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}, name: "foo"},
+			false},
+		{"pass - partition key starts with underscore", PartitionedReader{dir: underscore},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"1"}, isNull: []bool{false}, id: mockID, name: "amount"},
+				{slice: []string{"1"}, isNull: []bool{false}, id: mockID, name: "_id"}},
+				labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - partition column already exists", PartitionedReader{dir: conflict}, nil, true},
+		{"fail - no files", PartitionedReader{dir: empty}, nil, true},
+		{"fail - missing directory", PartitionedReader{dir: filepath.Join(empty, "foo")}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PartitionedReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("PartitionedReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartitionedWriter_roundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	r := NewSliceReader([]interface{}{[]string{"us", "eu", "us"}, []float64{1, 2, 3}})
+	r.ColNames = []string{"region", "amount"}
+	df, _ := r.Read()
+	err = NewPartitionedWriter(dir, "region").Write(df)
+	if err != nil {
+		t.Fatalf("PartitionedWriter.Write() error = %v", err)
+	}
+	got, err := NewPartitionedReader(dir).Read()
+	if err != nil {
+		t.Fatalf("PartitionedReader.Read() error = %v", err)
+	}
+	got.InPlace().Sort(Sorter{Name: "amount", DType: Float64})
+	if !reflect.DeepEqual(got.Col("region").GetValuesAsString(), []string{"us", "eu", "us"}) ||
+		!reflect.DeepEqual(got.Col("amount").GetValuesAsFloat64(), []float64{1, 2, 3}) {
+		t.Errorf("PartitionedWriter.Write() -> PartitionedReader.Read() = %v, want %v", got, df)
+	}
+}
+
+func Test_escapePartitionValue(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"no escapes", "foo bar", "foo bar"},
+		{"escapes", "a/b=c:d%e\n", "a%2Fb%3Dc%3Ad%25e%0A"},
+		{"unicode", "café", "café"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := escapePartitionValue(tt.s)
+			if got != tt.want {
+				t.Errorf("escapePartitionValue() = %v, want %v", got, tt.want)
+			}
+			if back := unescapePartitionValue(got); back != tt.s {
+				t.Errorf("unescapePartitionValue() = %v, want %v", back, tt.s)
+			}
+		})
+	}
+	if got := unescapePartitionValue("100%zz%"); got != "100%zz%" {
+		t.Errorf("unescapePartitionValue() = %v, want %v", got, "100%zz%")
+	}
+}
@@ -0,0 +1,302 @@
+package tada
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// -- file format registry
+
+// FileOptions configures ReadFile and WriteFile.
+type FileOptions struct {
+	// Format is the file extension (e.g., "csv" or ".csv") or MIME type (e.g., "text/csv") of a registered format.
+	// By default, the format is chosen by the extension of the file path.
+	Format string
+	// Compression compresses the file written by WriteFile.
+	// By default, a file path ending in .gz or .zst is compressed with Gzip or Zstd, respectively.
+	// ReadFile always detects compression automatically.
+	Compression Compression
+	// LabelLevels is the number of leading columns that ReadFile reads as label levels.
+	LabelLevels int
+	// Name is the name of the DataFrame returned by ReadFile.
+	Name string
+}
+
+// A ReaderFunc returns a Reader that reads a DataFrame from r.
+// r has already been decompressed, but not transcoded to UTF-8 (see NewDecodingReader).
+type ReaderFunc func(r io.Reader, opts FileOptions) (Reader, error)
+
+// A WriterFunc returns a Writer that writes a DataFrame to w.
+// If the Writer is also an io.Closer, WriteFile calls Close after Write.
+type WriterFunc func(w io.Writer, opts FileOptions) (Writer, error)
+
+type formatRegistry struct {
+	readers map[string]ReaderFunc
+	writers map[string]WriterFunc
+	mu      sync.RWMutex
+}
+
+var formats = &formatRegistry{
+	readers: make(map[string]ReaderFunc),
+	writers: make(map[string]WriterFunc),
+}
+
+// RegisterReader makes a Reader available to ReadFile for each key,
+// which is either a file extension (e.g., "csv" or ".csv") or a MIME type (e.g., "text/csv").
+// Keys are case-insensitive. Registering a key that is already registered replaces the previous ReaderFunc.
+func RegisterReader(fn ReaderFunc, keys ...string) {
+	formats.mu.Lock()
+	for _, key := range keys {
+		formats.readers[normalizeFormat(key)] = fn
+	}
+	formats.mu.Unlock()
+}
+
+// RegisterWriter makes a Writer available to WriteFile for each key,
+// which is either a file extension (e.g., "csv" or ".csv") or a MIME type (e.g., "text/csv").
+// Keys are case-insensitive. Registering a key that is already registered replaces the previous WriterFunc.
+func RegisterWriter(fn WriterFunc, keys ...string) {
+	formats.mu.Lock()
+	for _, key := range keys {
+		formats.writers[normalizeFormat(key)] = fn
+	}
+	formats.mu.Unlock()
+}
+
+// RegisteredFormats returns the sorted keys of every registered Reader and Writer.
+func RegisteredFormats() (readers []string, writers []string) {
+	formats.mu.RLock()
+	defer formats.mu.RUnlock()
+	for key := range formats.readers {
+		readers = append(readers, key)
+	}
+	for key := range formats.writers {
+		writers = append(writers, key)
+	}
+	sort.Strings(readers)
+	sort.Strings(writers)
+	return readers, writers
+}
+
+// ReadFile reads the file at path into a DataFrame using the Reader registered for opts.Format
+// or (by default) the extension of path. Compression is detected automatically,
+// and a compression extension (.gz, .bz2, or .zst) is ignored when choosing the format (e.g., foo.csv.gz is read as csv).
+func ReadFile(path string, opts FileOptions) (*DataFrame, error) {
+	df, err := readFile(path, opts, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %v", err)
+	}
+	return df, nil
+}
+
+// readFile reads the file at path with fn or, if fn is nil, with the Reader registered for its format.
+func readFile(path string, opts FileOptions, fn ReaderFunc) (*DataFrame, error) {
+	if fn == nil {
+		key, _ := fileFormat(path, opts.Format)
+		var ok bool
+		formats.mu.RLock()
+		fn, ok = formats.readers[key]
+		formats.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("no reader registered for format %q", key)
+		}
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r, err := decompress(f)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := fn(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	return reader.Read()
+}
+
+// WriteFile writes df to the file at path (creating or truncating it) using the Writer registered for opts.Format
+// or (by default) the extension of path. A compression extension (.gz or .zst) is ignored when choosing the format,
+// and sets the compression if opts.Compression is NoCompression (e.g., foo.csv.gz is written as gzip-compressed csv).
+func WriteFile(path string, df *DataFrame, opts FileOptions) error {
+	err := writeFile(path, df, opts, nil)
+	if err != nil {
+		return fmt.Errorf("writing file: %v", err)
+	}
+	return nil
+}
+
+// writeFile writes df to the file at path with fn or, if fn is nil, with the Writer registered for its format.
+func writeFile(path string, df *DataFrame, opts FileOptions, fn WriterFunc) error {
+	key, compression := fileFormat(path, opts.Format)
+	if opts.Compression == NoCompression {
+		opts.Compression = compression
+	}
+	if fn == nil {
+		var ok bool
+		formats.mu.RLock()
+		fn, ok = formats.writers[key]
+		formats.mu.RUnlock()
+		if !ok {
+			return fmt.Errorf("no writer registered for format %q", key)
+		}
+	}
+	// check the compression before creating the file, so that an existing file is not truncated
+	err := checkWritableCompression(opts.Compression)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = writeCompressed(f, df, opts, fn)
+	closeErr := f.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// writeCompressed writes df to w with the Writer returned by fn, compressed with opts.Compression.
+func writeCompressed(w io.Writer, df *DataFrame, opts FileOptions, fn WriterFunc) error {
+	cw, err := NewCompressingWriter(w, opts.Compression)
+	if err != nil {
+		return err
+	}
+	writer, err := fn(cw, opts)
+	if err != nil {
+		return err
+	}
+	err = writer.Write(df)
+	if err != nil {
+		return err
+	}
+	if closer, ok := writer.(io.Closer); ok {
+		err = closer.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return cw.Close()
+}
+
+// normalizeFormat returns the lowercase form of a file extension (without a leading dot) or MIME type.
+func normalizeFormat(key string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(key)), ".")
+}
+
+// fileFormat returns the registry key for a file path (or the format override, if not empty)
+// and the compression implied by the path's extension.
+func fileFormat(path string, format string) (string, Compression) {
+	ext := normalizeFormat(filepath.Ext(path))
+	compression := NoCompression
+	switch ext {
+	case "gz":
+		compression = Gzip
+	case "bz2":
+		compression = Bzip2
+	case "zst":
+		compression = Zstd
+	}
+	if compression != NoCompression {
+		ext = normalizeFormat(filepath.Ext(strings.TrimSuffix(path, filepath.Ext(path))))
+	}
+	if format != "" {
+		return normalizeFormat(format), compression
+	}
+	return ext, compression
+}
+
+func init() {
+	RegisterReader(func(r io.Reader, opts FileOptions) (Reader, error) {
+		reader := NewCSVReader(NewDecodingReader(r))
+		reader.LabelLevels = opts.LabelLevels
+		reader.Name = opts.Name
+		return reader, nil
+	}, "csv", "text/csv")
+	RegisterWriter(func(w io.Writer, opts FileOptions) (Writer, error) {
+		return NewCSVWriter(w), nil
+	}, "csv", "text/csv")
+
+	RegisterReader(func(r io.Reader, opts FileOptions) (Reader, error) {
+		reader := NewCSVReader(NewDecodingReader(r))
+		reader.Comma = '\t'
+		reader.LabelLevels = opts.LabelLevels
+		reader.Name = opts.Name
+		return reader, nil
+	}, "tsv", "tab", "text/tab-separated-values")
+	RegisterWriter(func(w io.Writer, opts FileOptions) (Writer, error) {
+		writer := NewCSVWriter(w)
+		writer.Comma = '\t'
+		return writer, nil
+	}, "tsv", "tab", "text/tab-separated-values")
+
+	RegisterReader(func(r io.Reader, opts FileOptions) (Reader, error) {
+		reader := NewJSONReader(NewDecodingReader(r))
+		reader.LabelLevels = opts.LabelLevels
+		reader.Name = opts.Name
+		return reader, nil
+	}, "json", "ndjson", "jsonl", "application/json", "application/x-ndjson")
+	RegisterWriter(func(w io.Writer, opts FileOptions) (Writer, error) {
+		return NewJSONWriter(w), nil
+	}, "json", "application/json")
+	RegisterWriter(func(w io.Writer, opts FileOptions) (Writer, error) {
+		writer := NewJSONWriter(w)
+		writer.NDJSON = true
+		return writer, nil
+	}, "ndjson", "jsonl", "application/x-ndjson")
+
+	RegisterReader(func(r io.Reader, opts FileOptions) (Reader, error) {
+		reader := NewParquetReader(r)
+		reader.LabelLevels = opts.LabelLevels
+		reader.Name = opts.Name
+		return reader, nil
+	}, "parquet", "application/vnd.apache.parquet")
+	RegisterWriter(func(w io.Writer, opts FileOptions) (Writer, error) {
+		return NewParquetWriter(w), nil
+	}, "parquet", "application/vnd.apache.parquet")
+
+	// ArrowReader detects the file or stream format automatically
+	RegisterReader(func(r io.Reader, opts FileOptions) (Reader, error) {
+		reader := NewArrowReader(r)
+		reader.LabelLevels = opts.LabelLevels
+		reader.Name = opts.Name
+		return reader, nil
+	}, "arrow", "feather", "arrows", "application/vnd.apache.arrow.file", "application/vnd.apache.arrow.stream")
+	RegisterWriter(func(w io.Writer, opts FileOptions) (Writer, error) {
+		return NewArrowWriter(w), nil
+	}, "arrow", "feather", "application/vnd.apache.arrow.file")
+	RegisterWriter(func(w io.Writer, opts FileOptions) (Writer, error) {
+		writer := NewArrowWriter(w)
+		writer.Stream = true
+		return writer, nil
+	}, "arrows", "application/vnd.apache.arrow.stream")
+
+	RegisterReader(func(r io.Reader, opts FileOptions) (Reader, error) {
+		reader := NewXLSXReader(r)
+		reader.LabelLevels = opts.LabelLevels
+		reader.Name = opts.Name
+		return reader, nil
+	}, "xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	RegisterWriter(func(w io.Writer, opts FileOptions) (Writer, error) {
+		return NewXLSXWriter(w), nil
+	}, "xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+
+	RegisterWriter(func(w io.Writer, opts FileOptions) (Writer, error) {
+		return NewMarkdownWriter(w), nil
+	}, "md", "markdown", "text/markdown")
+	RegisterWriter(func(w io.Writer, opts FileOptions) (Writer, error) {
+		return NewHTMLWriter(w), nil
+	}, "html", "htm", "text/html")
+	RegisterWriter(func(w io.Writer, opts FileOptions) (Writer, error) {
+		return NewLaTeXWriter(w), nil
+	}, "tex", "latex", "application/x-latex")
+}
@@ -0,0 +1,229 @@
+package tada
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadFile_WriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	newDF := func() *DataFrame {
+		return &DataFrame{values: []*valueContainer{
+			{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+			{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+			labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"}}
+	}
+	tests := []struct {
+		name            string
+		path            string
+		opts            FileOptions
+		wantCompression Compression
+	}{
+		{"csv", "foo.csv", FileOptions{}, NoCompression},
+		{"csv - gzip by extension", "foo.csv.gz", FileOptions{}, Gzip},
+		{"tsv - zstd by extension", "foo.TSV.zst", FileOptions{}, Zstd},
+		{"csv - gzip by option", "foo.csv", FileOptions{Compression: Gzip}, Gzip},
+		{"csv - mime type", "foo", FileOptions{Format: "text/csv"}, NoCompression},
+		{"json", "foo.json", FileOptions{}, NoCompression},
+		{"ndjson", "foo.ndjson", FileOptions{}, NoCompression},
+		{"parquet", "foo.parquet", FileOptions{LabelLevels: 1}, NoCompression},
+		{"parquet - gzip", "foo.parquet.gz", FileOptions{LabelLevels: 1}, Gzip},
+		{"arrow", "foo.arrow", FileOptions{}, NoCompression},
+		{"arrow stream", "foo.arrows", FileOptions{}, NoCompression},
+		{"xlsx - format override", "foo.dat", FileOptions{Format: ".XLSX", LabelLevels: 1}, NoCompression},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.path)
+			err := WriteFile(path, newDF(), tt.opts)
+			if err != nil {
+				t.Errorf("WriteFile() error = %v", err)
+				return
+			}
+			b, _ := ioutil.ReadFile(path)
+			if got := detectCompression(b); got != tt.wantCompression {
+				t.Errorf("WriteFile() compression = %v, want %v", got, tt.wantCompression)
+			}
+			got, err := ReadFile(path, tt.opts)
+			if err != nil {
+				t.Errorf("ReadFile() error = %v", err)
+				return
+			}
+			if got.Len() != 2 || !reflect.DeepEqual(got.Col("foo").GetValuesAsFloat64(), []float64{1, 2}) ||
+				!reflect.DeepEqual(got.Col("bar").GetValuesAsString(), []string{"a", "b"}) {
+				t.Errorf("WriteFile() -> ReadFile() = %v, want %v", got, newDF())
+			}
+		})
+	}
+}
+
+type mockFormatWriter struct {
+	w      io.Writer
+	closed bool
+}
+
+func (w *mockFormatWriter) Write(df *DataFrame) error {
+	_, err := io.WriteString(w.w, df.name)
+	return err
+}
+
+func (w *mockFormatWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+type mockFormatReader struct {
+	r    io.Reader
+	opts FileOptions
+}
+
+func (r mockFormatReader) Read() (*DataFrame, error) {
+	b, err := ioutil.ReadAll(r.r)
+	if err != nil {
+		return nil, err
+	}
+	return NewSeries([]string{string(b)}).DataFrame().SetName(r.opts.Name), nil
+}
+
+func TestRegisterReader_RegisterWriter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var writer *mockFormatWriter
+	RegisterWriter(func(w io.Writer, opts FileOptions) (Writer, error) {
+		writer = &mockFormatWriter{w: w}
+		return writer, nil
+	}, ".Mock", "application/x-mock")
+	RegisterReader(func(r io.Reader, opts FileOptions) (Reader, error) {
+		return mockFormatReader{r: r, opts: opts}, nil
+	}, ".Mock", "application/x-mock")
+	defer func() {
+		formats.mu.Lock()
+		for _, key := range []string{"mock", "application/x-mock"} {
+			delete(formats.readers, key)
+			delete(formats.writers, key)
+		}
+		formats.mu.Unlock()
+	}()
+
+	readers, writers := RegisteredFormats()
+	if !containsString(readers, "mock") || !containsString(writers, "application/x-mock") {
+		t.Errorf("RegisteredFormats() = %v, %v, want to include mock formats", readers, writers)
+	}
+	path := filepath.Join(dir, "foo.mock.gz")
+	err = WriteFile(path, NewSeries([]int{1}).DataFrame().SetName("hello"), FileOptions{})
+	if err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if !writer.closed {
+		t.Errorf("WriteFile() did not close Writer")
+	}
+	df, err := ReadFile(path, FileOptions{Format: "application/x-mock", Name: "baz"})
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if df.name != "baz" || !reflect.DeepEqual(df.values[0].slice, []string{"hello"}) {
+		t.Errorf("ReadFile() = %v, want name baz and value hello", df)
+	}
+}
+
+func TestReadFile_fail(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "foo.csv"), []byte("foo\n1\n"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "foo.json"), []byte("[1, 2]"), 0644)
+	tests := []struct {
+		name string
+		path string
+		opts FileOptions
+	}{
+		{"unregistered extension", "foo.bar", FileOptions{}},
+		{"unregistered format", "foo.csv", FileOptions{Format: "bar"}},
+		{"missing file", "bar.csv", FileOptions{}},
+		{"reader error", "foo.json", FileOptions{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadFile(filepath.Join(dir, tt.path), tt.opts); err == nil {
+				t.Errorf("ReadFile() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestWriteFile_fail(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tests := []struct {
+		name string
+		path string
+		df   *DataFrame
+		opts FileOptions
+	}{
+		{"unregistered extension", "foo.bar", NewSeries([]int{1}).DataFrame(), FileOptions{}},
+		{"bzip2", "foo.csv.bz2", NewSeries([]int{1}).DataFrame(), FileOptions{}},
+		{"writer error", "foo.json", dataFrameWithError(errors.New("foo")), FileOptions{}},
+		{"missing directory", "bar/foo.csv", NewSeries([]int{1}).DataFrame(), FileOptions{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := WriteFile(filepath.Join(dir, tt.path), tt.df, tt.opts); err == nil {
+				t.Errorf("WriteFile() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestWriteFile_unsupportedCompressionKeepsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "foo.csv.bz2")
+	err = ioutil.WriteFile(path, []byte("existing"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(path, NewSeries([]int{1}).DataFrame(), FileOptions{}); err == nil {
+		t.Errorf("WriteFile() error = nil, want error")
+	}
+	if b, _ := ioutil.ReadFile(path); string(b) != "existing" {
+		t.Errorf("WriteFile() changed existing file to %q, want %q", b, "existing")
+	}
+}
+
+func TestWriteFile_markup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"foo.md", "foo.html", "foo.tex"} {
+		path := filepath.Join(dir, name)
+		if err := WriteFile(path, NewSeries([]int{1}).DataFrame(), FileOptions{}); err != nil {
+			t.Errorf("WriteFile(%s) error = %v", name, err)
+		}
+		if b, _ := ioutil.ReadFile(path); len(b) == 0 {
+			t.Errorf("WriteFile(%s) wrote empty file", name)
+		}
+	}
+}
@@ -129,7 +129,8 @@ func (s *Series) numLevels() int {
 }
 
 // Cast casts the underlying container values (either label levels or Series values) to
-// []float64, []string, []time.Time (aka timezone-aware DateTime), []civil.Date, or []civil.Time.
+// []float64, []string, []time.Time (aka timezone-aware DateTime), []civil.Date, []civil.Time, []int64, or []bool.
+// When casting to Int64, a non-null value that cannot be represented as int64 (e.g., 1.5 or 1e19) sets an error on s.
 // To apply to Series values, supply empty string name ("") or the Series name.
 // Use cast to improve performance when calling multiple operations on values.
 func (s *Series) Cast(containerAsType map[string]DType) {
@@ -143,7 +144,11 @@ func (s *Series) Cast(containerAsType map[string]DType) {
 			s.resetWithError(fmt.Errorf("type casting: %v", err))
 			return
 		}
-		mergedLabelsAndValues[index].cast(dtype)
+		err = mergedLabelsAndValues[index].cast(dtype)
+		if err != nil {
+			s.resetWithError(fmt.Errorf("type casting: %v: %v", name, err))
+			return
+		}
 	}
 	return
 }
@@ -828,13 +833,16 @@ func (s *Series) Lookup(other *Series, options ...JoinOption) (*Series, error) {
 			return nil, fmt.Errorf("lookup: if either leftOn or rightOn is empty, both must be empty")
 		}
 	}
+	switch {
+	case config.how == "cross":
+		// a cross join does not use keys
 	// no join keys specified? find matching labels
-	if len(config.leftOn) == 0 {
+	case len(config.leftOn) == 0:
 		leftKeys, rightKeys, err = findMatchingKeysBetweenTwoContainers(s.labels, other.labels)
 		if err != nil {
 			return nil, fmt.Errorf("lookup: %v", err)
 		}
-	} else {
+	default:
 		leftKeys, err = indexOfContainers(config.leftOn, s.labels)
 		if err != nil {
 			return nil, fmt.Errorf("lookup: leftOn: %v", err)
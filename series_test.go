@@ -1300,8 +1300,7 @@ func TestSeries_Lookup(t *testing.T) {
 				labels: []*valueContainer{
 					{id: mockID, name: "waldo", slice: []string{"baz", "bar"}, isNull: []bool{false, false},
 						cache: []string{"baz", "bar"}},
-					{id: mockID, name: "corge", slice: []int{0, 1}, isNull: []bool{false, false},
-						cache: []string{"0", "1"}}}},
+					{id: mockID, name: "corge", slice: []int{0, 1}, isNull: []bool{false, false}}}},
 			false,
 		},
 		{"fail - leftOn but not rightOn", fields{
@@ -1402,9 +1401,7 @@ func TestSeries_Merge(t *testing.T) {
 					{slice: []string{"", "c"}, isNull: []bool{true, false}, id: mockID, name: "bar"},
 				},
 				labels: []*valueContainer{
-					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0",
-						cache: []string{"0", "1"},
-					}},
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
 				colLevelNames: []string{"*0"}},
 			false,
 		},
@@ -1422,9 +1419,7 @@ func TestSeries_Merge(t *testing.T) {
 					{slice: []string{"b"}, isNull: []bool{false}, id: mockID, name: "foo"},
 				},
 				labels: []*valueContainer{
-					{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0",
-						cache: []string{"1"},
-					}},
+					{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0"}},
 				colLevelNames: []string{"*0"}},
 			false,
 		},
@@ -1664,9 +1659,7 @@ func TestSeries_Add(t *testing.T) {
 			&Series{
 				values: &valueContainer{slice: []float64{1, 6}, isNull: []bool{false, false}, id: mockID},
 				labels: []*valueContainer{
-					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID,
-						cache: []string{"0", "1"},
-					}}},
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
 		},
 		{"missing as null",
 			fields{
@@ -1679,9 +1672,7 @@ func TestSeries_Add(t *testing.T) {
 			&Series{
 				values: &valueContainer{slice: []float64{0, 6}, isNull: []bool{true, false}, id: mockID},
 				labels: []*valueContainer{
-					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID,
-						cache: []string{"0", "1"},
-					}}},
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
 		},
 	}
 	for _, tt := range tests {
@@ -1725,9 +1716,7 @@ func TestSeries_Subtract(t *testing.T) {
 			&Series{
 				values: &valueContainer{slice: []float64{1, -2}, isNull: []bool{false, false}, id: mockID},
 				labels: []*valueContainer{
-					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID,
-						cache: []string{"0", "1"},
-					}}},
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
 		},
 		{"missing as null",
 			fields{
@@ -1740,9 +1729,7 @@ func TestSeries_Subtract(t *testing.T) {
 			&Series{
 				values: &valueContainer{slice: []float64{0, -2}, isNull: []bool{true, false}, id: mockID},
 				labels: []*valueContainer{
-					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID,
-						cache: []string{"0", "1"},
-					}}},
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
 		},
 	}
 	for _, tt := range tests {
@@ -1787,7 +1774,7 @@ func TestSeries_Multiply(t *testing.T) {
 				values: &valueContainer{slice: []float64{1, 8}, isNull: []bool{false, false}, id: mockID},
 				labels: []*valueContainer{
 					{slice: []int{0, 1}, isNull: []bool{false, false},
-						cache: []string{"0", "1"}, id: mockID,
+						id: mockID,
 					}}},
 		},
 		{"missing as null",
@@ -1802,7 +1789,7 @@ func TestSeries_Multiply(t *testing.T) {
 				values: &valueContainer{slice: []float64{0, 8}, isNull: []bool{true, false}, id: mockID},
 				labels: []*valueContainer{
 					{slice: []int{0, 1}, isNull: []bool{false, false},
-						cache: []string{"0", "1"}, id: mockID,
+						id: mockID,
 					}}},
 		},
 	}
@@ -1848,7 +1835,7 @@ func TestSeries_Divide(t *testing.T) {
 				values: &valueContainer{slice: []float64{1, .5}, isNull: []bool{false, false}, id: mockID},
 				labels: []*valueContainer{
 					{slice: []int{0, 1}, isNull: []bool{false, false},
-						cache: []string{"0", "1"}, id: mockID,
+						id: mockID,
 					}}},
 		},
 		{"missing as null - divide by 0",
@@ -1863,7 +1850,7 @@ func TestSeries_Divide(t *testing.T) {
 				values: &valueContainer{slice: []float64{0, 1, 0}, isNull: []bool{true, false, true}, id: mockID},
 				labels: []*valueContainer{
 					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false},
-						cache: []string{"0", "1", "2"}, id: mockID,
+						id: mockID,
 					}}},
 		},
 	}
@@ -2103,8 +2090,7 @@ func TestSeries_GroupBy(t *testing.T) {
 				series: &Series{
 					values: &valueContainer{slice: []float64{1, 2, 3, 4}, isNull: []bool{false, false, false, false}},
 					labels: []*valueContainer{
-						{slice: []int{0, 0, 1, 2}, isNull: []bool{false, false, false, false}, id: mockID, name: "a",
-							cache: []string{"0", "0", "1", "2"}},
+						{slice: []int{0, 0, 1, 2}, isNull: []bool{false, false, false, false}, id: mockID, name: "a"},
 						{slice: []string{"foo", "foo", "foo", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "b",
 							cache: []string{"foo", "foo", "foo", "bar"}}},
 				},
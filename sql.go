@@ -0,0 +1,495 @@
+package tada
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// -- database/sql
+
+// SQLReader reads the results of a SQL query into a DataFrame.
+type SQLReader struct {
+	LabelLevels int
+	Name        string
+	rows        *sql.Rows
+	db          *sql.DB
+	query       string
+	args        []interface{}
+}
+
+// NewSQLReader returns a default SQLReader that reads from rows.
+// rows are consumed and closed by Read.
+func NewSQLReader(rows *sql.Rows) SQLReader {
+	return SQLReader{
+		LabelLevels: 0,
+		rows:        rows,
+	}
+}
+
+// NewSQLQueryReader returns a default SQLReader that reads the results of query (with optional args) executed against db.
+func NewSQLQueryReader(db *sql.DB, query string, args ...interface{}) SQLReader {
+	return SQLReader{
+		LabelLevels: 0,
+		db:          db,
+		query:       query,
+		args:        args,
+	}
+}
+
+// Read reads every row of the query results into a DataFrame, and then closes the rows.
+// Each result column becomes a DataFrame column with the same name, and the first r.LabelLevels columns become label levels.
+//
+// The type of each column is chosen from its column type in the result set:
+// integer columns (including sql.NullInt64 and sql.NullInt32) -> []int64;
+// other numeric columns (including sql.NullFloat64) -> []float64;
+// time columns (including sql.NullTime) -> []time.Time;
+// boolean columns (including sql.NullBool) -> []bool;
+// all other columns -> []string.
+// If the driver does not report a specific scan type, the type is inferred from the non-null values in the column.
+// SQL NULL values are read as null values.
+func (r SQLReader) Read() (*DataFrame, error) {
+	rows := r.rows
+	if r.db != nil {
+		var err error
+		rows, err = r.db.Query(r.query, r.args...)
+		if err != nil {
+			return nil, fmt.Errorf("reading sql: %v", err)
+		}
+	}
+	if rows == nil {
+		return nil, fmt.Errorf("reading sql: rows cannot be nil")
+	}
+	defer rows.Close()
+	containers, err := readSQLRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("reading sql: %v", err)
+	}
+	if r.LabelLevels >= len(containers) {
+		return nil, fmt.Errorf("reading sql: number of label levels (%d) must be less than number of columns (%d)",
+			r.LabelLevels, len(containers))
+	}
+	return containersToDF(containers, 1, r.LabelLevels, r.Name), nil
+}
+
+// SQLWriter writes a DataFrame into a SQL table.
+type SQLWriter struct {
+	IncludeLabels bool
+	// CreateTable issues a CREATE TABLE statement derived from the container types before inserting any rows.
+	CreateTable bool
+	// BatchSize is the maximum number of rows inserted by each INSERT statement.
+	BatchSize int
+	// UpsertKeys are the names of the columns that uniquely identify a row.
+	// If set, rows that conflict on these columns are updated with the new values (using ON CONFLICT ... DO UPDATE).
+	UpsertKeys []string
+	// NumberedPlaceholders writes query parameters as $1, $2, etc (default: ?).
+	NumberedPlaceholders bool
+	db                   *sql.DB
+	table                string
+}
+
+// NewSQLWriter returns a *SQLWriter with default settings that writes into table in db.
+// table may be qualified by a schema (e.g., "public.foo").
+// By default, label levels are written as ordinary columns, and rows are inserted in batches of 100.
+func NewSQLWriter(db *sql.DB, table string) *SQLWriter {
+	return &SQLWriter{
+		IncludeLabels: true,
+		BatchSize:     100,
+		db:            db,
+		table:         table,
+	}
+}
+
+// Write inserts every row of df into the table within a single transaction.
+// If any statement fails, the transaction is rolled back and no rows are written.
+// Identifiers are quoted with double quotes, and null values are written as SQL NULL.
+//
+// If CreateTable is true, the table is created first, with column types derived from the container types:
+// []float64 and []float32 -> DOUBLE PRECISION;
+// signed integer slices -> BIGINT;
+// []bool -> BOOLEAN;
+// []time.Time -> TIMESTAMP;
+// []civil.Date -> DATE;
+// []civil.Time -> TIME;
+// all other types -> TEXT.
+// If UpsertKeys are supplied, they become the primary key of the created table.
+func (w *SQLWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing sql: %v", df.err)
+	}
+	if w.db == nil {
+		return fmt.Errorf("writing sql: db cannot be nil")
+	}
+	if w.table == "" {
+		return fmt.Errorf("writing sql: table name cannot be empty")
+	}
+	containers := df.values
+	if w.IncludeLabels {
+		containers = append(df.labels, df.values...)
+	}
+	names := make([]string, len(containers))
+	for k := range containers {
+		names[k] = containers[k].name
+	}
+	for _, key := range w.UpsertKeys {
+		if !containsString(names, key) {
+			return fmt.Errorf("writing sql: upsert key: name (%v) not found", key)
+		}
+	}
+	statements, args := w.statements(containers)
+	tx, err := w.db.Begin()
+	if err != nil {
+		return fmt.Errorf("writing sql: %v", err)
+	}
+	for i := range statements {
+		_, err := tx.Exec(statements[i], args[i]...)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("writing sql: %v", err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("writing sql: %v", err)
+	}
+	return nil
+}
+
+// -- sql internals
+
+// sql column kinds
+const (
+	sqlString = iota
+	sqlInt
+	sqlFloat
+	sqlDateTime
+	sqlBool
+	sqlUnknown
+)
+
+var (
+	sqlTimeType    = reflect.TypeOf(time.Time{})
+	sqlNullTime    = reflect.TypeOf(sql.NullTime{})
+	sqlNullBool    = reflect.TypeOf(sql.NullBool{})
+	sqlNullFloat64 = reflect.TypeOf(sql.NullFloat64{})
+	sqlNullInt64   = reflect.TypeOf(sql.NullInt64{})
+	sqlNullInt32   = reflect.TypeOf(sql.NullInt32{})
+	sqlNullString  = reflect.TypeOf(sql.NullString{})
+	sqlRawBytes    = reflect.TypeOf(sql.RawBytes{})
+	sqlBytes       = reflect.TypeOf([]byte{})
+)
+
+// sqlKindOfScanType returns the column kind that corresponds to the scan type reported by a driver.
+func sqlKindOfScanType(t reflect.Type) int {
+	if t == nil {
+		return sqlUnknown
+	}
+	switch t {
+	case sqlTimeType, sqlNullTime:
+		return sqlDateTime
+	case sqlNullBool:
+		return sqlBool
+	case sqlNullInt64, sqlNullInt32:
+		return sqlInt
+	case sqlNullFloat64:
+		return sqlFloat
+	case sqlNullString, sqlRawBytes, sqlBytes:
+		return sqlString
+	}
+	if t.Kind() == reflect.Ptr {
+		return sqlKindOfScanType(t.Elem())
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return sqlInt
+	case reflect.Float32, reflect.Float64:
+		return sqlFloat
+	case reflect.Bool:
+		return sqlBool
+	case reflect.String:
+		return sqlString
+	default:
+		return sqlUnknown
+	}
+}
+
+// sqlKindOfValues infers the column kind from the non-null values returned by a driver.
+// A column with both integer and float values is a float column.
+func sqlKindOfValues(vals []interface{}) int {
+	kind := sqlUnknown
+	for i := range vals {
+		var valueKind int
+		switch vals[i].(type) {
+		case nil:
+			continue
+		case int64, int32, int, uint64:
+			valueKind = sqlInt
+		case float64, float32:
+			valueKind = sqlFloat
+		case time.Time:
+			valueKind = sqlDateTime
+		case bool:
+			valueKind = sqlBool
+		default:
+			return sqlString
+		}
+		if (kind == sqlInt && valueKind == sqlFloat) || (kind == sqlFloat && valueKind == sqlInt) {
+			kind = sqlFloat
+			continue
+		}
+		if kind != sqlUnknown && valueKind != kind {
+			return sqlString
+		}
+		kind = valueKind
+	}
+	if kind == sqlUnknown {
+		return sqlString
+	}
+	return kind
+}
+
+// readSQLRows reads every row in rows into one valueContainer per column.
+func readSQLRows(rows *sql.Rows) ([]*valueContainer, error) {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	if len(columnTypes) == 0 {
+		return nil, fmt.Errorf("query must return at least one column")
+	}
+	vals := make([][]interface{}, len(columnTypes))
+	dest := make([]interface{}, len(columnTypes))
+	for k := range vals {
+		vals[k] = make([]interface{}, 0)
+	}
+	for rows.Next() {
+		row := make([]interface{}, len(columnTypes))
+		for k := range row {
+			dest[k] = &row[k]
+		}
+		err := rows.Scan(dest...)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", len(vals[0]), err)
+		}
+		for k := range row {
+			if b, ok := row[k].([]byte); ok {
+				row[k] = string(b)
+			}
+			vals[k] = append(vals[k], row[k])
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	ret := make([]*valueContainer, len(columnTypes))
+	for k := range columnTypes {
+		isNull := make([]bool, len(vals[k]))
+		for i := range vals[k] {
+			isNull[i] = vals[k][i] == nil
+		}
+		kind := sqlKindOfScanType(columnTypes[k].ScanType())
+		if kind == sqlUnknown {
+			kind = sqlKindOfValues(vals[k])
+		}
+		vc := &valueContainer{slice: vals[k], isNull: isNull}
+		var slice interface{}
+		switch kind {
+		case sqlInt:
+			// an unsigned value that overflows int64 is null
+			slice = vc.int64().slice
+		case sqlFloat:
+			slice = vc.float64().slice
+		case sqlDateTime:
+			slice = vc.dateTime().slice
+		case sqlBool:
+			arr := make([]bool, len(vals[k]))
+			for i := range vals[k] {
+				switch v := vals[k][i].(type) {
+				case bool:
+					arr[i] = v
+				case int64:
+					arr[i] = v != 0
+				default:
+					isNull[i] = true
+				}
+			}
+			slice = arr
+		default:
+			arr := vc.string().slice
+			for i := range arr {
+				if isNull[i] {
+					arr[i] = ""
+				}
+			}
+			slice = arr
+		}
+		ret[k] = newValueContainer(slice, isNull, columnTypes[k].Name())
+	}
+	return ret, nil
+}
+
+func containsString(list []string, s string) bool {
+	for i := range list {
+		if list[i] == s {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteSQLIdentifier quotes a table or column name with double quotes.
+func quoteSQLIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+// quoteSQLTableName quotes each dot-separated part of a (possibly schema-qualified) table name,
+// so that "public.foo" is written as "public"."foo".
+func quoteSQLTableName(name string) string {
+	parts := strings.Split(name, ".")
+	for i := range parts {
+		parts[i] = quoteSQLIdentifier(parts[i])
+	}
+	return strings.Join(parts, ".")
+}
+
+// sqlColumnType returns the column type used to create a table column for vc.
+func sqlColumnType(vc *valueContainer) string {
+	switch vc.slice.(type) {
+	case []float64, []float32:
+		return "DOUBLE PRECISION"
+	case []int, []int8, []int16, []int32, []int64:
+		return "BIGINT"
+	case []bool:
+		return "BOOLEAN"
+	case []time.Time:
+		return "TIMESTAMP"
+	case []civil.Date:
+		return "DATE"
+	case []civil.Time:
+		return "TIME"
+	default:
+		return "TEXT"
+	}
+}
+
+// sqlArgs returns the query parameter for each value in vc, with nil in place of null values.
+func sqlArgs(vc *valueContainer) []interface{} {
+	ret := make([]interface{}, vc.len())
+	switch vc.slice.(type) {
+	case []float64, []float32:
+		vals := vc.float64().slice
+		for i := range vals {
+			ret[i] = vals[i]
+		}
+	case []int, []int8, []int16, []int32, []int64:
+		v := reflect.ValueOf(vc.slice)
+		for i := range ret {
+			ret[i] = v.Index(i).Int()
+		}
+	case []bool:
+		vals := vc.slice.([]bool)
+		for i := range vals {
+			ret[i] = vals[i]
+		}
+	case []time.Time:
+		vals := vc.slice.([]time.Time)
+		for i := range vals {
+			ret[i] = vals[i]
+		}
+	default:
+		vals := vc.string().slice
+		for i := range vals {
+			ret[i] = vals[i]
+		}
+	}
+	for i := range ret {
+		if vc.isNull[i] {
+			ret[i] = nil
+		}
+	}
+	return ret
+}
+
+// statements returns every statement required to write containers, along with the query parameters for each statement.
+func (w *SQLWriter) statements(containers []*valueContainer) ([]string, [][]interface{}) {
+	var statements []string
+	var args [][]interface{}
+	table := quoteSQLTableName(w.table)
+	columns := make([]string, len(containers))
+	for k := range containers {
+		columns[k] = quoteSQLIdentifier(containers[k].name)
+	}
+	if w.CreateTable {
+		definitions := make([]string, len(containers))
+		for k := range containers {
+			definitions[k] = columns[k] + " " + sqlColumnType(containers[k])
+		}
+		if len(w.UpsertKeys) > 0 {
+			keys := make([]string, len(w.UpsertKeys))
+			for i := range w.UpsertKeys {
+				keys[i] = quoteSQLIdentifier(w.UpsertKeys[i])
+			}
+			definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(keys, ", ")))
+		}
+		statements = append(statements, fmt.Sprintf("CREATE TABLE %s (%s)", table, strings.Join(definitions, ", ")))
+		args = append(args, nil)
+	}
+
+	var conflict string
+	if len(w.UpsertKeys) > 0 {
+		keys := make([]string, len(w.UpsertKeys))
+		for i := range w.UpsertKeys {
+			keys[i] = quoteSQLIdentifier(w.UpsertKeys[i])
+		}
+		var updates []string
+		for k := range containers {
+			if !containsString(w.UpsertKeys, containers[k].name) {
+				updates = append(updates, fmt.Sprintf("%s = excluded.%s", columns[k], columns[k]))
+			}
+		}
+		if len(updates) == 0 {
+			conflict = fmt.Sprintf(" ON CONFLICT (%s) DO NOTHING", strings.Join(keys, ", "))
+		} else {
+			conflict = fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(keys, ", "), strings.Join(updates, ", "))
+		}
+	}
+
+	columnArgs := make([][]interface{}, len(containers))
+	for k := range containers {
+		columnArgs[k] = sqlArgs(containers[k])
+	}
+	batchSize := w.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	numRows := containers[0].len()
+	for start := 0; start < numRows; start += batchSize {
+		end := start + batchSize
+		if end > numRows {
+			end = numRows
+		}
+		rows := make([]string, 0, end-start)
+		batchArgs := make([]interface{}, 0, (end-start)*len(containers))
+		for i := start; i < end; i++ {
+			placeholders := make([]string, len(containers))
+			for k := range containers {
+				batchArgs = append(batchArgs, columnArgs[k][i])
+				if w.NumberedPlaceholders {
+					placeholders[k] = fmt.Sprintf("$%d", len(batchArgs))
+				} else {
+					placeholders[k] = "?"
+				}
+			}
+			rows = append(rows, "("+strings.Join(placeholders, ", ")+")")
+		}
+		statements = append(statements, fmt.Sprintf("INSERT INTO %s (%s) VALUES %s%s",
+			table, strings.Join(columns, ", "), strings.Join(rows, ", "), conflict))
+		args = append(args, batchArgs)
+	}
+	return statements, args
+}
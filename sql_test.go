@@ -0,0 +1,313 @@
+package tada
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// -- fake database/sql driver
+
+// a fakeDB returns canned query results and records every executed statement
+type fakeDB struct {
+	columns    []string
+	scanTypes  []reflect.Type
+	rows       [][]driver.Value
+	execErr    error
+	statements []string
+	args       [][]driver.Value
+	committed  bool
+}
+
+var (
+	fakeDBs   = make(map[string]*fakeDB)
+	fakeDBsMu sync.Mutex
+)
+
+func init() {
+	sql.Register("tadafake", fakeDriver{})
+}
+
+// openFakeDB registers db under a unique name and opens it with the fake driver
+func openFakeDB(name string, db *fakeDB) *sql.DB {
+	fakeDBsMu.Lock()
+	fakeDBs[name] = db
+	fakeDBsMu.Unlock()
+	ret, _ := sql.Open("tadafake", name)
+	return ret
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fakeDBsMu.Lock()
+	defer fakeDBsMu.Unlock()
+	db, ok := fakeDBs[name]
+	if !ok {
+		return nil, fmt.Errorf("fake db (%s) not found", name)
+	}
+	return &fakeConn{db: db}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{db: c.db, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return &fakeTx{db: c.db}, nil }
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (tx *fakeTx) Commit() error {
+	tx.db.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if s.db.execErr != nil {
+		return nil, s.db.execErr
+	}
+	s.db.statements = append(s.db.statements, s.query)
+	s.db.args = append(s.db.args, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{db: s.db}, nil
+}
+
+type fakeRows struct {
+	db      *fakeDB
+	current int
+}
+
+func (r *fakeRows) Columns() []string { return r.db.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.current >= len(r.db.rows) {
+		return io.EOF
+	}
+	copy(dest, r.db.rows[r.current])
+	r.current++
+	return nil
+}
+
+func (r *fakeRows) ColumnTypeScanType(index int) reflect.Type {
+	if r.db.scanTypes == nil {
+		return reflect.TypeOf(new(interface{})).Elem()
+	}
+	return r.db.scanTypes[index]
+}
+
+// --
+
+func TestSQLReader_Read(t *testing.T) {
+	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	typed := &fakeDB{
+		columns: []string{"foo", "bar", "baz", "qux"},
+		scanTypes: []reflect.Type{
+			reflect.TypeOf(sql.NullString{}), reflect.TypeOf(sql.NullInt64{}),
+			reflect.TypeOf(sql.NullTime{}), reflect.TypeOf(sql.NullBool{})},
+		rows: [][]driver.Value{
+			{"a", int64(1), d, true},
+			{nil, nil, nil, nil},
+			{[]byte("c"), int64(3), "2020-01-01", int64(0)},
+		},
+	}
+	untyped := &fakeDB{
+		columns: []string{"foo", "bar", "baz"},
+		rows: [][]driver.Value{
+			{"a", 1.5, d},
+			{int64(2), nil, nil},
+		},
+	}
+	untypedNumbers := &fakeDB{
+		columns: []string{"foo", "bar"},
+		rows: [][]driver.Value{
+			{int64(1<<53 + 1), int64(1)},
+			{int64(2), 2.5},
+		},
+	}
+	type fields struct {
+		LabelLevels int
+		db          *sql.DB
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - scan types", fields{LabelLevels: 1, db: openFakeDB("TestSQLReader_Read/typed", typed)},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []int64{1, 0, 3}, isNull: []bool{false, true, false}, id: mockID, name: "bar"},
+					{slice: []time.Time{d, {}, d}, isNull: []bool{false, true, false}, id: mockID, name: "baz"},
+					{slice: []bool{true, false, false}, isNull: []bool{false, true, false}, id: mockID, name: "qux"},
+				},
+				labels: []*valueContainer{
+					{slice: []string{"a", "", "c"}, isNull: []bool{false, true, false}, id: mockID, name: "foo"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - inferred types", fields{LabelLevels: 0, db: openFakeDB("TestSQLReader_Read/untyped", untyped)},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"a", "2"}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+					{slice: []float64{1.5, 0}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []time.Time{d, {}}, isNull: []bool{false, true}, id: mockID, name: "baz"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - inferred integers", fields{LabelLevels: 0, db: openFakeDB("TestSQLReader_Read/untypedNumbers", untypedNumbers)},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []int64{1<<53 + 1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+					{slice: []float64{1, 2.5}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - too many label levels", fields{LabelLevels: 3, db: openFakeDB("TestSQLReader_Read/untyped", untyped)},
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewSQLQueryReader(tt.fields.db, "SELECT * FROM foo WHERE bar = ?", 1)
+			r.LabelLevels = tt.fields.LabelLevels
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SQLReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("SQLReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLWriter_Write(t *testing.T) {
+	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	df := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{1, 2, 3}, isNull: []bool{false, true, false}, id: mockID, name: "foo"},
+			{slice: []time.Time{d, d, d}, isNull: []bool{false, false, false}, id: mockID, name: "bar"},
+		},
+		labels: []*valueContainer{
+			{slice: []string{"a", "b", "c"}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"},
+	}
+	type fields struct {
+		IncludeLabels        bool
+		CreateTable          bool
+		BatchSize            int
+		UpsertKeys           []string
+		NumberedPlaceholders bool
+		execErr              error
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		df             *DataFrame
+		wantStatements []string
+		wantArgs       [][]driver.Value
+		wantErr        bool
+	}{
+		{"pass - create and batch insert", fields{IncludeLabels: true, CreateTable: true, BatchSize: 2}, df,
+			[]string{
+				`CREATE TABLE "qux" ("*0" TEXT, "foo" DOUBLE PRECISION, "bar" TIMESTAMP)`,
+				`INSERT INTO "qux" ("*0", "foo", "bar") VALUES (?, ?, ?), (?, ?, ?)`,
+				`INSERT INTO "qux" ("*0", "foo", "bar") VALUES (?, ?, ?)`,
+			},
+			[][]driver.Value{
+				{},
+				{"a", 1.0, d, "b", nil, d},
+				{"c", 3.0, d},
+			},
+			false},
+		{"pass - upsert", fields{IncludeLabels: false, BatchSize: 5, UpsertKeys: []string{"bar"}, NumberedPlaceholders: true}, df,
+			[]string{
+				`INSERT INTO "qux" ("foo", "bar") VALUES ($1, $2), ($3, $4), ($5, $6) ON CONFLICT ("bar") DO UPDATE SET "foo" = excluded."foo"`,
+			},
+			[][]driver.Value{
+				{1.0, d, nil, d, 3.0, d},
+			},
+			false},
+		{"fail - upsert key not found", fields{UpsertKeys: []string{"corge"}}, df, nil, nil, true},
+		{"fail - exec error", fields{execErr: errors.New("foo")}, df, nil, nil, true},
+		{"fail - error in df", fields{}, dataFrameWithError(errors.New("foo")), nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeDB{execErr: tt.fields.execErr}
+			w := NewSQLWriter(openFakeDB("TestSQLWriter_Write/"+tt.name, fake), "qux")
+			w.IncludeLabels = tt.fields.IncludeLabels
+			w.CreateTable = tt.fields.CreateTable
+			w.BatchSize = tt.fields.BatchSize
+			w.UpsertKeys = tt.fields.UpsertKeys
+			w.NumberedPlaceholders = tt.fields.NumberedPlaceholders
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("SQLWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if fake.committed {
+					t.Errorf("SQLWriter.Write() committed transaction after error")
+				}
+				return
+			}
+			if !fake.committed {
+				t.Errorf("SQLWriter.Write() did not commit transaction")
+			}
+			if !reflect.DeepEqual(fake.statements, tt.wantStatements) {
+				t.Errorf("SQLWriter.Write() statements = %v, want %v", fake.statements, tt.wantStatements)
+			}
+			if !reflect.DeepEqual(fake.args, tt.wantArgs) {
+				t.Errorf("SQLWriter.Write() args = %v, want %v", fake.args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func Test_quoteSQLTableName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"foo", `"foo"`},
+		{"public.foo", `"public"."foo"`},
+		{`my"schema.foo`, `"my""schema"."foo"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quoteSQLTableName(tt.name); got != tt.want {
+				t.Errorf("quoteSQLTableName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
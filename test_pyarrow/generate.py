@@ -0,0 +1,74 @@
+"""Writes the pyarrow fixtures read by the tada tests, and checks that pyarrow reads the files written by tada.
+
+Usage (from the repository root, with pyarrow installed):
+
+    python3 test_pyarrow/generate.py
+
+Every fixture holds the same table:
+
+    id (int64)  name (string)  score (float64)  flag (bool)
+    1           foo            1.5              true
+    2           null           null             false
+    3           foo            3.0              null
+"""
+
+import os
+
+import pyarrow as pa
+import pyarrow.ipc as ipc
+import pyarrow.parquet as pq
+
+DIR = os.path.dirname(os.path.abspath(__file__))
+
+ROWS = {
+    "id": [1, 2, 3],
+    "name": ["foo", None, "foo"],
+    "score": [1.5, None, 3.0],
+    "flag": [True, False, None],
+}
+
+
+def table(dictionary=False):
+    name = pa.array(ROWS["name"], pa.string())
+    if dictionary:
+        name = name.dictionary_encode()
+    return pa.table(
+        {
+            "id": pa.array(ROWS["id"], pa.int64()),
+            "name": name,
+            "score": pa.array(ROWS["score"], pa.float64()),
+            "flag": pa.array(ROWS["flag"], pa.bool_()),
+        }
+    )
+
+
+def write_fixtures():
+    # pyarrow defaults: snappy compression, dictionary encoding, and version 1 data pages
+    pq.write_table(table(), os.path.join(DIR, "pyarrow.parquet"))
+    pq.write_table(
+        table(),
+        os.path.join(DIR, "pyarrow_v2_gzip.parquet"),
+        compression="gzip",
+        use_dictionary=False,
+        data_page_version="2.0",
+    )
+    with ipc.new_file(os.path.join(DIR, "pyarrow.arrow"), table().schema) as w:
+        w.write_table(table())
+    t = table(dictionary=True)
+    with ipc.new_stream(os.path.join(DIR, "pyarrow_dictionary.arrows"), t.schema) as w:
+        w.write_table(t)
+
+
+def check_tada_files():
+    # tada.parquet and tada.arrow are written by ParquetWriter and ArrowWriter with IncludeLabels = false
+    # (see TestParquetWriter_Write_pyarrowFixture and TestArrowWriter_Write_pyarrowFixture)
+    for name, read in [("tada.parquet", pq.read_table), ("tada.arrow", lambda p: ipc.open_file(p).read_all())]:
+        got = read(os.path.join(DIR, name)).to_pydict()
+        if got != ROWS:
+            raise SystemExit("%s: got %r, want %r" % (name, got, ROWS))
+        print("%s: ok" % name)
+
+
+if __name__ == "__main__":
+    write_fixtures()
+    check_tada_files()
@@ -86,6 +86,16 @@ type dataFrameAlias struct {
 	ColLevelNames []string          `json:"colLevelNames"`
 }
 
+// dataFrameMetadata records the DataFrame structure that cannot be represented in the schema of a columnar file format.
+type dataFrameMetadata struct {
+	Name           string   `json:"name"`
+	NumLabelLevels int      `json:"numLabelLevels"`
+	ColLevelNames  []string `json:"colLevelNames"`
+}
+
+// dataFrameMetadataKey is the key of the file-level metadata written by columnar file writers.
+const dataFrameMetadataKey = "tada"
+
 // A DataFrameIterator iterates over the rows in a DataFrame.
 type DataFrameIterator struct {
 	current int
@@ -140,6 +150,26 @@ type Matrix interface {
 	T() Matrix
 }
 
+// DenseMatrix is a matrix of float64 values stored in a contiguous row-major slice.
+// It satisfies the Matrix interface, and its RawData may be supplied to gonum's mat.NewDense.
+type DenseMatrix struct {
+	rows int
+	cols int
+	data []float64
+}
+
+// NullPolicy specifies how MatrixWriter handles null values.
+type NullPolicy int
+
+const (
+	// NullPolicyError -> returns an error
+	NullPolicyError NullPolicy = iota
+	// NullPolicyDrop -> drops every row with a null value in any selected column
+	NullPolicyDrop
+	// NullPolicyFill -> replaces null values with a fill value
+	NullPolicyFill
+)
+
 type floatValueContainer struct {
 	slice  []float64
 	isNull []bool
@@ -158,6 +188,18 @@ type dateTimeValueContainer struct {
 	index  []int
 }
 
+type int64ValueContainer struct {
+	slice  []int64
+	isNull []bool
+	index  []int
+}
+
+type boolValueContainer struct {
+	slice  []bool
+	isNull []bool
+	index  []int
+}
+
 // A Sorter supplies details to the Sort() function.
 // `Name` specifies the container (either label or column name) to sort.
 // If `Descending` is true, values are sorted in descending order.
@@ -169,6 +211,40 @@ type Sorter struct {
 	DType      DType
 }
 
+// A ColumnSchema declares how the values in one column are parsed when reading records.
+// `DType` specifies the data type to which every value is parsed.
+// `Layout` specifies the time.Parse layout for DateTime, Date, and Time values
+// (default: each of the datetime formats supported by Cast(), in order).
+// `NullTokens` specifies values (in addition to the global null strings) that are read as null.
+type ColumnSchema struct {
+	DType      DType
+	Layout     string
+	NullTokens []string
+}
+
+// FixedWidthColumn specifies one column of fixed-width text (see FixedWidthReader and FixedWidthWriter).
+// `Name` is the name of the column (or, for FixedWidthWriter, of either a column or a label level).
+// `Start` is the zero-indexed character offset at which the column begins, and `Width` is its number of characters.
+// `Schema`, if not nil, specifies how FixedWidthReader parses values (default: read as []string).
+// `Align` specifies how FixedWidthWriter pads values that are shorter than Width.
+type FixedWidthColumn struct {
+	Name   string
+	Start  int
+	Width  int
+	Schema *ColumnSchema
+	Align  Alignment
+}
+
+// Alignment is the alignment of values within a fixed-width column.
+type Alignment int
+
+const (
+	// AlignLeft -> padded on the right
+	AlignLeft Alignment = iota
+	// AlignRight -> padded on the left
+	AlignRight
+)
+
 // An Element is one {value, null status} pair in either a Series or DataFrame.
 type Element struct {
 	Val    interface{}
@@ -217,19 +293,51 @@ const (
 	Time
 	// Date -> civil.Date
 	Date
+	// Int64 -> int64
+	Int64
+	// Bool -> bool
+	Bool
+)
+
+// Compression is a compression format for reading and writing data.
+type Compression int
+
+const (
+	// NoCompression -> uncompressed
+	NoCompression Compression = iota
+	// Gzip -> gzip (.gz)
+	Gzip
+	// Bzip2 -> bzip2 (.bz2); reading only
+	Bzip2
+	// Zstd -> Zstandard (.zst)
+	Zstd
 )
 
 // A JoinOption configures a lookup or merge function.
 // Available lookup options: JoinOptionHow, JoinOptionLeftOn, JoinOptionRightOn
+// Available merge options: all lookup options, JoinOptionValidate, JoinOptionFuzzy, JoinOptionTopK
+// Available as-of merge options: JoinOptionLeftOn, JoinOptionRightOn, JoinOptionBy, JoinOptionDirection,
+// JoinOptionTolerance, JoinOptionToleranceDuration
+// Available interval merge options: JoinOptionHow, JoinOptionBy, JoinOptionBounds
 type JoinOption func(*joinConfig)
 
 // A joinConfig configures a lookup or merge function.
 // All lookup/merge functions accept zero or more modifiers that alter the default read config, which is:
 // left join, no specified join keys (so automatically uses shared label names as keys)
 type joinConfig struct {
-	how     string
-	leftOn  []string
-	rightOn []string
+	how               string
+	leftOn            []string
+	rightOn           []string
+	validate          string
+	by                []string
+	direction         string
+	tolerance         float64
+	hasTolerance      bool
+	durationTolerance bool
+	bounds            string
+	fuzzyMetric       string
+	fuzzyThreshold    float64
+	topK              int
 }
 
 // Resampler supplies logic for the Resample() function.
@@ -1,7 +1,9 @@
 package tada
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"time"
@@ -60,6 +62,38 @@ func (vc dateTimeValueContainer) Swap(i, j int) {
 	vc.index[i], vc.index[j] = vc.index[j], vc.index[i]
 }
 
+func (vc int64ValueContainer) Less(i, j int) bool {
+	if vc.slice[i] < vc.slice[j] {
+		return true
+	}
+	return false
+}
+
+func (vc int64ValueContainer) Len() int {
+	return len(vc.slice)
+}
+
+func (vc int64ValueContainer) Swap(i, j int) {
+	vc.slice[i], vc.slice[j] = vc.slice[j], vc.slice[i]
+	vc.isNull[i], vc.isNull[j] = vc.isNull[j], vc.isNull[i]
+	vc.index[i], vc.index[j] = vc.index[j], vc.index[i]
+}
+
+// false sorts before true
+func (vc boolValueContainer) Less(i, j int) bool {
+	return !vc.slice[i] && vc.slice[j]
+}
+
+func (vc boolValueContainer) Len() int {
+	return len(vc.slice)
+}
+
+func (vc boolValueContainer) Swap(i, j int) {
+	vc.slice[i], vc.slice[j] = vc.slice[j], vc.slice[i]
+	vc.isNull[i], vc.isNull[j] = vc.isNull[j], vc.isNull[i]
+	vc.index[i], vc.index[j] = vc.index[j], vc.index[i]
+}
+
 // converters
 
 func convertStringToFloat(val string, originalBool bool) (float64, bool) {
@@ -77,7 +111,9 @@ func convertBoolToFloat(val bool) float64 {
 	return 0
 }
 
-func (vc *valueContainer) cast(dtype DType) {
+// cast converts the values in vc to dtype in place.
+// Returns an error (and leaves the values in vc unchanged) if dtype is Int64 and a non-null value cannot be represented as int64.
+func (vc *valueContainer) cast(dtype DType) error {
 	if vc.isString() {
 		vc.setCache()
 	}
@@ -117,8 +153,24 @@ func (vc *valueContainer) cast(dtype DType) {
 			}
 			vc.slice = ret
 		}
+	case Int64:
+		_, ok := vc.slice.([]int64)
+		if !ok {
+			// convert a copy of the null values, so that vc is unchanged if there is an error
+			c := &valueContainer{slice: vc.slice, isNull: append([]bool(nil), vc.isNull...)}
+			converted, err := c.int64WithError()
+			if err != nil {
+				return err
+			}
+			vc.slice, vc.isNull = converted.slice, converted.isNull
+		}
+	case Bool:
+		_, ok := vc.slice.([]bool)
+		if !ok {
+			vc.slice = vc.bool().slice
+		}
 	}
-	return
+	return nil
 }
 
 // if already []float64, returns shared values, not new values
@@ -191,6 +243,275 @@ func (vc *valueContainer) float64() floatValueContainer {
 	return ret
 }
 
+// convertFloatToInt64 returns val as an int64 if it is a whole number within the range of int64.
+// Any other value (e.g., 1.5, NaN, or 1e19) is null, and returns an error.
+func convertFloatToInt64(val float64, originalBool bool) (int64, bool, error) {
+	if val != math.Trunc(val) || val < math.MinInt64 || val >= math.MaxInt64 {
+		return 0, true, fmt.Errorf("%v cannot be represented as int64", val)
+	}
+	return int64(val), originalBool, nil
+}
+
+// convertStringToInt64 parses val as a base-10 integer or, failing that, as a float64 that is a whole number.
+// A value that cannot be parsed as a number is null.
+// A number that overflows int64 or is not a whole number is also null, and returns an error.
+func convertStringToInt64(val string, originalBool bool) (int64, bool, error) {
+	parsedVal, err := strconv.ParseInt(val, 10, 64)
+	if err == nil {
+		return parsedVal, originalBool, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, true, fmt.Errorf("%v overflows int64", val)
+	}
+	parsedFloat, err := strconv.ParseFloat(val, 64)
+	if err == nil || errors.Is(err, strconv.ErrRange) {
+		ret, isNull, err := convertFloatToInt64(parsedFloat, originalBool)
+		if err != nil {
+			return 0, true, fmt.Errorf("%v cannot be represented as int64", val)
+		}
+		return ret, isNull, nil
+	}
+	return 0, true, nil
+}
+
+// convertUintToInt64 returns val as an int64. A value that overflows int64 is null, and returns an error.
+func convertUintToInt64(val uint64, originalBool bool) (int64, bool, error) {
+	if val > math.MaxInt64 {
+		return 0, true, fmt.Errorf("%v overflows int64", val)
+	}
+	return int64(val), originalBool, nil
+}
+
+// if already []int64, returns shared values, not new values.
+// Values that cannot be represented as int64 are null (see int64WithError).
+func (vc *valueContainer) int64() int64ValueContainer {
+	ret, _ := vc.int64WithError()
+	return ret
+}
+
+// int64WithError converts values to int64 in the same way as int64,
+// and also returns an error for the first non-null value that is a number but cannot be represented as int64
+// (e.g., 1.5 or an unsigned value that overflows int64).
+func (vc *valueContainer) int64WithError() (int64ValueContainer, error) {
+	if arr, ok := vc.slice.([]int64); ok {
+		return int64ValueContainer{
+			isNull: vc.isNull,
+			slice:  arr,
+		}, nil
+	}
+	newVals := make([]int64, vc.len())
+	isNull := vc.isNull
+	var firstErr error
+	// setConverted writes the converted value at row i, and retains the first error for a value that was not already null
+	setConverted := func(i int, val int64, null bool, err error) {
+		if err != nil && !isNull[i] && firstErr == nil {
+			firstErr = fmt.Errorf("row %d: %v", i, err)
+		}
+		newVals[i], isNull[i] = val, null
+	}
+	switch vc.slice.(type) {
+	case []string:
+		arr := vc.slice.([]string)
+		for i := range arr {
+			val, null, err := convertStringToInt64(arr[i], isNull[i])
+			setConverted(i, val, null, err)
+		}
+
+	case [][]byte:
+		arr := vc.slice.([][]byte)
+		for i := range arr {
+			val, null, err := convertStringToInt64(string(arr[i]), isNull[i])
+			setConverted(i, val, null, err)
+		}
+
+	case []float64:
+		arr := vc.slice.([]float64)
+		for i := range arr {
+			val, null, err := convertFloatToInt64(arr[i], isNull[i])
+			setConverted(i, val, null, err)
+		}
+	case []float32:
+		arr := vc.slice.([]float32)
+		for i := range arr {
+			val, null, err := convertFloatToInt64(float64(arr[i]), isNull[i])
+			setConverted(i, val, null, err)
+		}
+
+	case []int:
+		for i, v := range vc.slice.([]int) {
+			newVals[i] = int64(v)
+		}
+	case []int32:
+		for i, v := range vc.slice.([]int32) {
+			newVals[i] = int64(v)
+		}
+	case []int16:
+		for i, v := range vc.slice.([]int16) {
+			newVals[i] = int64(v)
+		}
+	case []int8:
+		for i, v := range vc.slice.([]int8) {
+			newVals[i] = int64(v)
+		}
+	case []uint:
+		for i, v := range vc.slice.([]uint) {
+			val, null, err := convertUintToInt64(uint64(v), isNull[i])
+			setConverted(i, val, null, err)
+		}
+	case []uint64:
+		for i, v := range vc.slice.([]uint64) {
+			val, null, err := convertUintToInt64(v, isNull[i])
+			setConverted(i, val, null, err)
+		}
+	case []uint32:
+		for i, v := range vc.slice.([]uint32) {
+			newVals[i] = int64(v)
+		}
+	case []uint16:
+		for i, v := range vc.slice.([]uint16) {
+			newVals[i] = int64(v)
+		}
+	case []uint8:
+		for i, v := range vc.slice.([]uint8) {
+			newVals[i] = int64(v)
+		}
+
+	case []bool:
+		arr := vc.slice.([]bool)
+		for i := range arr {
+			newVals[i] = int64(convertBoolToFloat(arr[i]))
+		}
+
+	case []interface{}:
+		arr := vc.slice.([]interface{})
+		for i := range arr {
+			switch v := arr[i].(type) {
+			case string:
+				val, null, err := convertStringToInt64(v, isNull[i])
+				setConverted(i, val, null, err)
+			case float32, float64:
+				val, null, err := convertFloatToInt64(reflect.ValueOf(v).Float(), isNull[i])
+				setConverted(i, val, null, err)
+			case int, int8, int16, int32, int64:
+				newVals[i] = reflect.ValueOf(v).Int()
+			case uint, uint8, uint16, uint32, uint64:
+				val, null, err := convertUintToInt64(reflect.ValueOf(v).Uint(), isNull[i])
+				setConverted(i, val, null, err)
+			case bool:
+				newVals[i] = int64(convertBoolToFloat(v))
+			default:
+				newVals[i], isNull[i] = 0, true
+			}
+		}
+
+	default:
+		for i := range newVals {
+			newVals[i] = 0
+			isNull[i] = true
+		}
+	}
+	ret := int64ValueContainer{
+		isNull: isNull,
+		slice:  newVals,
+	}
+	return ret, firstErr
+}
+
+// convertStringToBool parses val as in strconv.ParseBool (e.g., "true", "FALSE", "1", or "f").
+// A value that cannot be parsed is null.
+func convertStringToBool(val string, originalBool bool) (bool, bool) {
+	parsedVal, err := strconv.ParseBool(val)
+	if err == nil {
+		return parsedVal, originalBool
+	}
+	return false, true
+}
+
+// convertFloatToBool returns true if val is not zero, or null if val is NaN.
+func convertFloatToBool(val float64, originalBool bool) (bool, bool) {
+	if math.IsNaN(val) {
+		return false, true
+	}
+	return val != 0, originalBool
+}
+
+// if already []bool, returns shared values, not new values
+func (vc *valueContainer) bool() boolValueContainer {
+	if arr, ok := vc.slice.([]bool); ok {
+		return boolValueContainer{
+			isNull: vc.isNull,
+			slice:  arr,
+		}
+	}
+	newVals := make([]bool, vc.len())
+	isNull := vc.isNull
+	switch vc.slice.(type) {
+	case []string:
+		arr := vc.slice.([]string)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToBool(arr[i], isNull[i])
+		}
+
+	case [][]byte:
+		arr := vc.slice.([][]byte)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToBool(string(arr[i]), isNull[i])
+		}
+
+	case []int64:
+		for i, v := range vc.slice.([]int64) {
+			newVals[i] = v != 0
+		}
+	case []int:
+		for i, v := range vc.slice.([]int) {
+			newVals[i] = v != 0
+		}
+
+	case []float64:
+		arr := vc.slice.([]float64)
+		for i := range arr {
+			newVals[i], isNull[i] = convertFloatToBool(arr[i], isNull[i])
+		}
+
+	case []interface{}:
+		arr := vc.slice.([]interface{})
+		for i := range arr {
+			switch v := arr[i].(type) {
+			case bool:
+				newVals[i] = v
+			case string:
+				newVals[i], isNull[i] = convertStringToBool(v, isNull[i])
+			case float32, float64:
+				newVals[i], isNull[i] = convertFloatToBool(reflect.ValueOf(v).Float(), isNull[i])
+			case int, int8, int16, int32, int64:
+				newVals[i] = reflect.ValueOf(v).Int() != 0
+			case uint, uint8, uint16, uint32, uint64:
+				newVals[i] = reflect.ValueOf(v).Uint() != 0
+			default:
+				newVals[i], isNull[i] = false, true
+			}
+		}
+
+	case []uint, []uint8, []uint16, []uint32, []uint64, []int8, []int16, []int32, []float32:
+		// convert other numeric types by their float64 values
+		arr := vc.float64().slice
+		for i := range arr {
+			newVals[i], isNull[i] = convertFloatToBool(arr[i], isNull[i])
+		}
+
+	default:
+		for i := range newVals {
+			newVals[i] = false
+			isNull[i] = true
+		}
+	}
+	ret := boolValueContainer{
+		isNull: isNull,
+		slice:  newVals,
+	}
+	return ret
+}
+
 func convertDateTimeToString(v time.Time) string {
 	return v.Format(time.RFC3339)
 }
@@ -311,6 +632,15 @@ func (vc *valueContainer) dateTime() dateTimeValueContainer {
 				newVals[i] = time.Date(0, 0, 0, arr[i].Hour, arr[i].Minute, arr[i].Second, arr[i].Nanosecond, time.UTC)
 			}
 		}
+	case []civil.DateTime:
+		arr := vc.slice.([]civil.DateTime)
+		for i := range arr {
+			if isNull[i] {
+				newVals[i] = time.Time{}
+			} else {
+				newVals[i] = arr[i].In(time.UTC)
+			}
+		}
 	case []interface{}:
 		arr := vc.slice.([]interface{})
 		for i := range arr {
@@ -343,7 +673,7 @@ func (vc *valueContainer) resetCache() {
 }
 
 // conditions under which cache is set:
-// - concatenating multiple container levels (groupby, lookup, promote)
+// - hashing a key container that is neither int nor float64 (groupby, lookup, promote)
 // - casting from string
 // - calling vc.string()
 // ignores if cache is already set
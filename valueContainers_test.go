@@ -1,6 +1,8 @@
 package tada
 
 import (
+	"fmt"
+	"math"
 	"reflect"
 	"testing"
 	"time"
@@ -421,6 +423,115 @@ func Test_valueContainer_float64(t *testing.T) {
 	}
 }
 
+func Test_valueContainer_int64(t *testing.T) {
+	type fields struct {
+		slice  interface{}
+		isNull []bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int64ValueContainer
+	}{
+		{"[]int64", fields{slice: []int64{1 << 62}, isNull: []bool{false}},
+			int64ValueContainer{slice: []int64{1 << 62}, isNull: []bool{false}}},
+		{"[]string", fields{slice: []string{"", "foo", "9007199254740993", "2.0", "99999999999999999999"},
+			isNull: []bool{true, false, false, false, false}},
+			int64ValueContainer{slice: []int64{0, 0, 9007199254740993, 2, 0}, isNull: []bool{true, true, false, false, true}}},
+		{"[]float64", fields{slice: []float64{1, 1.5, 1e19, math.NaN()}, isNull: []bool{false, false, false, true}},
+			int64ValueContainer{slice: []int64{1, 0, 0, 0}, isNull: []bool{false, true, true, true}}},
+		{"[]uint64", fields{slice: []uint64{1, math.MaxUint64}, isNull: []bool{false, false}},
+			int64ValueContainer{slice: []int64{1, 0}, isNull: []bool{false, true}}},
+		{"[]bool", fields{slice: []bool{false, true}, isNull: []bool{false, false}},
+			int64ValueContainer{slice: []int64{0, 1}, isNull: []bool{false, false}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vc := &valueContainer{
+				slice:  tt.fields.slice,
+				isNull: tt.fields.isNull,
+			}
+			if got := vc.int64(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("valueContainer.int64() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_valueContainer_int64WithError(t *testing.T) {
+	type fields struct {
+		slice  interface{}
+		isNull []bool
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    int64ValueContainer
+		wantErr error
+	}{
+		{"not a number", fields{slice: []string{"1", "foo"}, isNull: []bool{false, false}},
+			int64ValueContainer{slice: []int64{1, 0}, isNull: []bool{false, true}}, nil},
+		{"null value is not an error", fields{slice: []float64{1, 1.5}, isNull: []bool{false, true}},
+			int64ValueContainer{slice: []int64{1, 0}, isNull: []bool{false, true}}, nil},
+		{"fractional string", fields{slice: []string{"1", "2.5"}, isNull: []bool{false, false}},
+			int64ValueContainer{slice: []int64{1, 0}, isNull: []bool{false, true}},
+			fmt.Errorf("row 1: 2.5 cannot be represented as int64")},
+		{"overflowing string", fields{slice: []string{"99999999999999999999"}, isNull: []bool{false}},
+			int64ValueContainer{slice: []int64{0}, isNull: []bool{true}},
+			fmt.Errorf("row 0: 99999999999999999999 overflows int64")},
+		{"overflowing uint", fields{slice: []interface{}{uint64(math.MaxUint64)}, isNull: []bool{false}},
+			int64ValueContainer{slice: []int64{0}, isNull: []bool{true}},
+			fmt.Errorf("row 0: 18446744073709551615 overflows int64")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vc := &valueContainer{
+				slice:  tt.fields.slice,
+				isNull: tt.fields.isNull,
+			}
+			got, err := vc.int64WithError()
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("valueContainer.int64WithError() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("valueContainer.int64WithError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_valueContainer_bool(t *testing.T) {
+	type fields struct {
+		slice  interface{}
+		isNull []bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   boolValueContainer
+	}{
+		{"[]bool", fields{slice: []bool{true}, isNull: []bool{false}},
+			boolValueContainer{slice: []bool{true}, isNull: []bool{false}}},
+		{"[]string", fields{slice: []string{"", "foo", "true", "FALSE"}, isNull: []bool{true, false, false, false}},
+			boolValueContainer{slice: []bool{false, false, true, false}, isNull: []bool{true, true, false, false}}},
+		{"[]float64", fields{slice: []float64{0, 2, math.NaN()}, isNull: []bool{false, false, true}},
+			boolValueContainer{slice: []bool{false, true, false}, isNull: []bool{false, false, true}}},
+		{"[]int64", fields{slice: []int64{0, -1}, isNull: []bool{false, false}},
+			boolValueContainer{slice: []bool{false, true}, isNull: []bool{false, false}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vc := &valueContainer{
+				slice:  tt.fields.slice,
+				isNull: tt.fields.isNull,
+			}
+			if got := vc.bool(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("valueContainer.bool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_valueContainer_string(t *testing.T) {
 	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
 	type fields struct {
@@ -595,30 +706,45 @@ func Test_valueContainer_cast(t *testing.T) {
 		dtype DType
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   *valueContainer
+		name    string
+		fields  fields
+		args    args
+		want    *valueContainer
+		wantErr bool
 	}{
 		{"float64 to float64", fields{slice: []float64{1}, isNull: []bool{false}, name: "foo"},
-			args{Float64}, &valueContainer{slice: []float64{1}, isNull: []bool{false}, name: "foo"}},
+			args{Float64}, &valueContainer{slice: []float64{1}, isNull: []bool{false}, name: "foo"}, false},
 		{"int to float64", fields{slice: []int{1}, isNull: []bool{false}, name: "foo"},
-			args{Float64}, &valueContainer{slice: []float64{1}, isNull: []bool{false}, name: "foo"}},
+			args{Float64}, &valueContainer{slice: []float64{1}, isNull: []bool{false}, name: "foo"}, false},
 		{"string to string", fields{slice: []string{"foo"}, isNull: []bool{false}, name: "foo"},
 			args{String}, &valueContainer{
 				cache: []string{"foo"},
-				slice: []string{"foo"}, isNull: []bool{false}, name: "foo"}},
+				slice: []string{"foo"}, isNull: []bool{false}, name: "foo"}, false},
 		{"int to string - set cache", fields{slice: []int{1}, isNull: []bool{false}, name: "foo"},
 			args{String}, &valueContainer{slice: []string{"1"}, isNull: []bool{false}, name: "foo",
-				cache: []string{"1"}}},
+				cache: []string{"1"}}, false},
 		{"datetime to datetime", fields{slice: []time.Time{d}, isNull: []bool{false}, name: "foo"},
-			args{DateTime}, &valueContainer{slice: []time.Time{d}, isNull: []bool{false}, name: "foo"}},
+			args{DateTime}, &valueContainer{slice: []time.Time{d}, isNull: []bool{false}, name: "foo"}, false},
 		{"int to datetime", fields{slice: []int{1}, isNull: []bool{false}, name: "foo"},
-			args{DateTime}, &valueContainer{slice: []time.Time{{}}, isNull: []bool{true}, name: "foo"}},
+			args{DateTime}, &valueContainer{slice: []time.Time{{}}, isNull: []bool{true}, name: "foo"}, false},
+		{"string to int64", fields{slice: []string{"9007199254740993", "foo", "1.5"}, isNull: []bool{false, false, true}, name: "foo"},
+			args{Int64}, &valueContainer{
+				cache: []string{"9007199254740993", "foo", "1.5"},
+				slice: []int64{9007199254740993, 0, 0}, isNull: []bool{false, true, true}, name: "foo"}, false},
+		{"fail - fractional string to int64", fields{slice: []string{"1", "1.5"}, isNull: []bool{false, false}, name: "foo"},
+			args{Int64}, &valueContainer{
+				cache: []string{"1", "1.5"},
+				slice: []string{"1", "1.5"}, isNull: []bool{false, false}, name: "foo"}, true},
+		{"fail - overflowing float64 to int64", fields{slice: []float64{1, 1e19}, isNull: []bool{false, false}, name: "foo"},
+			args{Int64}, &valueContainer{slice: []float64{1, 1e19}, isNull: []bool{false, false}, name: "foo"}, true},
+		{"fail - overflowing uint64 to int64", fields{slice: []uint64{math.MaxUint64}, isNull: []bool{false}, name: "foo"},
+			args{Int64}, &valueContainer{slice: []uint64{math.MaxUint64}, isNull: []bool{false}, name: "foo"}, true},
+		{"float64 to bool", fields{slice: []float64{0, 1}, isNull: []bool{false, false}, name: "foo"},
+			args{Bool}, &valueContainer{slice: []bool{false, true}, isNull: []bool{false, false}, name: "foo"}, false},
 		{"datetime to civil.Date", fields{slice: []time.Time{d}, isNull: []bool{false}, name: "foo"},
-			args{Date}, &valueContainer{slice: []civil.Date{civil.DateOf(d)}, isNull: []bool{false}, name: "foo"}},
+			args{Date}, &valueContainer{slice: []civil.Date{civil.DateOf(d)}, isNull: []bool{false}, name: "foo"}, false},
 		{"datetime to civil.Time", fields{slice: []time.Time{d}, isNull: []bool{false}, name: "foo"},
-			args{Time}, &valueContainer{slice: []civil.Time{civil.TimeOf(d)}, isNull: []bool{false}, name: "foo"}},
+			args{Time}, &valueContainer{slice: []civil.Time{civil.TimeOf(d)}, isNull: []bool{false}, name: "foo"}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -627,7 +753,10 @@ func Test_valueContainer_cast(t *testing.T) {
 				isNull: tt.fields.isNull,
 				name:   tt.fields.name,
 			}
-			vc.cast(tt.args.dtype)
+			err := vc.cast(tt.args.dtype)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("vc.cast() error = %v, wantErr %v", err, tt.wantErr)
+			}
 			if !reflect.DeepEqual(vc, tt.want) {
 				t.Errorf("vc.cast() -> %v, want %v", vc, tt.want)
 			}
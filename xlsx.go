@@ -0,0 +1,898 @@
+package tada
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"path"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// -- Excel (.xlsx)
+
+// XLSXReader reads a sheet of an Excel workbook (.xlsx) into a DataFrame.
+type XLSXReader struct {
+	// Sheet is the name of the sheet to read (default: the first sheet in the workbook).
+	Sheet string
+	// Range is the block of cells to read, in A1 notation (e.g., "B2:D10").
+	// By default, the smallest block that contains every non-empty cell is read.
+	Range       string
+	HeaderRows  int
+	LabelLevels int
+	Name        string
+	r           io.Reader
+}
+
+// NewXLSXReader returns a default XLSXReader.
+func NewXLSXReader(r io.Reader) XLSXReader {
+	return XLSXReader{
+		HeaderRows:  1,
+		LabelLevels: 0,
+		r:           r,
+	}
+}
+
+// Read reads a sheet into a DataFrame.
+// As in RecordReader, the first r.HeaderRows rows are read as column names (one level per row)
+// and the first r.LabelLevels columns are read as label levels.
+// Merged cells are read as if every cell in the merged range had the value of its top-left cell,
+// so merged header rows become multi-level column names. Header rows within label columns are combined into a single name.
+//
+// Empty cells and cells with errors (e.g., #N/A) are read as null values.
+// A column is read as []float64 if every non-null value is a number, as []time.Time if every non-null value is a date,
+// as []bool if every non-null value is a boolean, and as []string otherwise.
+// Numbers formatted as dates or times are converted from Excel serial dates to time.Time (in UTC).
+func (r XLSXReader) Read() (*DataFrame, error) {
+	b, err := ioutil.ReadAll(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("reading xlsx: %v", err)
+	}
+	grid, err := readXLSXSheet(b, r.Sheet, r.Range)
+	if err != nil {
+		return nil, fmt.Errorf("reading xlsx: %v", err)
+	}
+	if len(grid) <= r.HeaderRows {
+		return nil, fmt.Errorf("reading xlsx: must have at least one row after %d header rows", r.HeaderRows)
+	}
+	numCols := len(grid[0])
+	if r.LabelLevels >= numCols {
+		return nil, fmt.Errorf("reading xlsx: number of label levels (%d) must be less than number of columns (%d)",
+			r.LabelLevels, numCols)
+	}
+	containers := make([]*valueContainer, numCols)
+	for k := 0; k < numCols; k++ {
+		levels := make([]string, r.HeaderRows)
+		for l := range levels {
+			levels[l] = xlsxString(grid[l][k])
+		}
+		name := joinLevelsIntoName(levels)
+		if k < r.LabelLevels {
+			name = joinLevelsIntoName(distinctNonEmpty(levels))
+		}
+		vals := make([]interface{}, len(grid)-r.HeaderRows)
+		for i := range vals {
+			vals[i] = grid[r.HeaderRows+i][k]
+		}
+		containers[k] = xlsxValuesToContainer(vals, name)
+	}
+	return containersToDF(containers, r.HeaderRows, r.LabelLevels, r.Name), nil
+}
+
+// XLSXWriter writes DataFrames as the sheets of an Excel workbook (.xlsx).
+type XLSXWriter struct {
+	IncludeLabels bool
+	sheets        []xlsxSheet
+	w             io.Writer
+}
+
+// NewXLSXWriter returns an *XLSXWriter with default settings.
+// By default, label levels are included as the leftmost columns.
+func NewXLSXWriter(w io.Writer) *XLSXWriter {
+	return &XLSXWriter{
+		IncludeLabels: true,
+		w:             w,
+	}
+}
+
+// Write adds df to the workbook as a new sheet. The workbook is not written until Close is called.
+// The sheet is named after df (or Sheet1, Sheet2, etc. if df has no name or the name is already taken).
+//
+// Multi-level column names are written as one header row per level, and adjacent cells with the same name are merged.
+// Label levels are written as the leftmost columns, with their names in merged header cells.
+// The header rows and label columns are frozen.
+//
+// Numbers are written as numbers, []bool values as booleans, and []time.Time, []civil.Date, and []civil.Time values
+// as Excel serial dates formatted as datetimes, dates, and times, respectively. []time.Time values are written in UTC.
+// All other values are written as strings. Null values (and NaN or infinite floats) are written as empty cells.
+func (w *XLSXWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing xlsx: %v", df.err)
+	}
+	var labels []*valueContainer
+	if w.IncludeLabels {
+		labels = df.labels
+	}
+	name := xlsxSheetName(df.name, w.sheets)
+	w.sheets = append(w.sheets, xlsxSheet{
+		name: name,
+		data: writeXLSXSheet(labels, df.values, len(df.colLevelNames)),
+	})
+	return nil
+}
+
+// Close writes the workbook, containing every sheet added by Write, to w.
+func (w *XLSXWriter) Close() error {
+	if len(w.sheets) == 0 {
+		return fmt.Errorf("writing xlsx: must write at least one sheet")
+	}
+	b, err := writeXLSXWorkbook(w.sheets)
+	if err != nil {
+		return fmt.Errorf("writing xlsx: %v", err)
+	}
+	_, err = w.w.Write(b)
+	if err != nil {
+		return fmt.Errorf("writing xlsx: %v", err)
+	}
+	return nil
+}
+
+// -- xlsx internals
+
+type xlsxSheet struct {
+	name string
+	data []byte
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+	Properties struct {
+		Date1904 bool `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxRichText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (rt xlsxRichText) String() string {
+	ret := rt.Text
+	for _, run := range rt.Runs {
+		ret += run.Text
+	}
+	return ret
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxStyles struct {
+	NumFmts []struct {
+		ID   int    `xml:"numFmtId,attr"`
+		Code string `xml:"formatCode,attr"`
+	} `xml:"numFmts>numFmt"`
+	CellXfs []struct {
+		NumFmtID int `xml:"numFmtId,attr"`
+	} `xml:"cellXfs>xf"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string        `xml:"r,attr"`
+			T      string        `xml:"t,attr"`
+			S      int           `xml:"s,attr"`
+			V      *string       `xml:"v"`
+			Inline *xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+	MergeCells []struct {
+		Ref string `xml:"ref,attr"`
+	} `xml:"mergeCells>mergeCell"`
+}
+
+func readZipFile(files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("missing %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	defer rc.Close()
+	err = xml.NewDecoder(rc).Decode(v)
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	return nil
+}
+
+// readXLSXSheet reads a block of cells from a sheet into a rectangular grid (major dimension: rows).
+// Each cell is nil, float64, string, bool, or time.Time.
+func readXLSXSheet(b []byte, sheetName string, cellRange string) ([][]interface{}, error) {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[strings.TrimPrefix(f.Name, "/")] = f
+	}
+	var workbook xlsxWorkbook
+	err = readZipFile(files, "xl/workbook.xml", &workbook)
+	if err != nil {
+		return nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, fmt.Errorf("workbook has no sheets")
+	}
+	var relationshipID string
+	if sheetName == "" {
+		relationshipID = workbook.Sheets[0].ID
+	} else {
+		names := make([]string, len(workbook.Sheets))
+		for i, sheet := range workbook.Sheets {
+			names[i] = sheet.Name
+			if sheet.Name == sheetName {
+				relationshipID = sheet.ID
+			}
+		}
+		if relationshipID == "" {
+			return nil, fmt.Errorf("sheet (%s) not found in %v", sheetName, names)
+		}
+	}
+	var relationships xlsxRelationships
+	err = readZipFile(files, "xl/_rels/workbook.xml.rels", &relationships)
+	if err != nil {
+		return nil, err
+	}
+	var sheetPath string
+	for _, rel := range relationships.Relationships {
+		if rel.ID == relationshipID {
+			if strings.HasPrefix(rel.Target, "/") {
+				sheetPath = strings.TrimPrefix(rel.Target, "/")
+			} else {
+				sheetPath = path.Join("xl", rel.Target)
+			}
+		}
+	}
+	if sheetPath == "" {
+		return nil, fmt.Errorf("worksheet relationship (%s) not found", relationshipID)
+	}
+	var sharedStrings xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		err = readZipFile(files, "xl/sharedStrings.xml", &sharedStrings)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var styles xlsxStyles
+	if _, ok := files["xl/styles.xml"]; ok {
+		err = readZipFile(files, "xl/styles.xml", &styles)
+		if err != nil {
+			return nil, err
+		}
+	}
+	isDateStyle := make([]bool, len(styles.CellXfs))
+	customFormats := make(map[int]string)
+	for _, numFmt := range styles.NumFmts {
+		customFormats[numFmt.ID] = numFmt.Code
+	}
+	for i, xf := range styles.CellXfs {
+		if code, ok := customFormats[xf.NumFmtID]; ok {
+			isDateStyle[i] = isXLSXDateFormat(code)
+		} else {
+			isDateStyle[i] = isXLSXBuiltInDateFormat(xf.NumFmtID)
+		}
+	}
+	var sheet xlsxWorksheet
+	err = readZipFile(files, sheetPath, &sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	// read every cell by position
+	cells := make(map[[2]int]interface{})
+	minRow, minCol, maxRow, maxCol := math.MaxInt32, math.MaxInt32, -1, -1
+	rowPosition := -1
+	for _, row := range sheet.Rows {
+		if row.R > 0 {
+			rowPosition = row.R - 1
+		} else {
+			rowPosition++
+		}
+		colPosition := -1
+		for _, c := range row.Cells {
+			i, k := rowPosition, colPosition+1
+			if c.R != "" {
+				i, k, err = parseXLSXCellReference(c.R)
+				if err != nil {
+					return nil, err
+				}
+			}
+			colPosition = k
+			var v interface{}
+			switch c.T {
+			case "s":
+				if c.V == nil {
+					break
+				}
+				index, err := strconv.Atoi(*c.V)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("cell %s: invalid shared string (%s)", c.R, *c.V)
+				}
+				v = sharedStrings.Items[index].String()
+			case "inlineStr":
+				if c.Inline != nil {
+					v = c.Inline.String()
+				}
+			case "str":
+				if c.V != nil {
+					v = *c.V
+				}
+			case "b":
+				if c.V != nil {
+					v = *c.V == "1"
+				}
+			case "e":
+				// errors are null
+			case "d":
+				if c.V != nil {
+					t, isNull := convertStringToDateTime(*c.V)
+					if isNull {
+						v = *c.V
+					} else {
+						v = t
+					}
+				}
+			default:
+				if c.V == nil {
+					break
+				}
+				f, err := strconv.ParseFloat(*c.V, 64)
+				if err != nil {
+					return nil, fmt.Errorf("cell %s: invalid number (%s)", c.R, *c.V)
+				}
+				if c.S >= 0 && c.S < len(isDateStyle) && isDateStyle[c.S] {
+					v = convertXLSXSerialToDateTime(f, workbook.Properties.Date1904)
+				} else {
+					v = f
+				}
+			}
+			if v == nil {
+				continue
+			}
+			cells[[2]int{i, k}] = v
+			if i < minRow {
+				minRow = i
+			}
+			if i > maxRow {
+				maxRow = i
+			}
+			if k < minCol {
+				minCol = k
+			}
+			if k > maxCol {
+				maxCol = k
+			}
+		}
+	}
+	// copy the value of the top-left cell in each merged range into every cell in the range
+	for _, merge := range sheet.MergeCells {
+		startRow, startCol, endRow, endCol, err := parseXLSXRange(merge.Ref)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := cells[[2]int{startRow, startCol}]
+		if !ok {
+			continue
+		}
+		for i := startRow; i <= endRow; i++ {
+			for k := startCol; k <= endCol; k++ {
+				cells[[2]int{i, k}] = v
+			}
+		}
+	}
+	if cellRange != "" {
+		minRow, minCol, maxRow, maxCol, err = parseXLSXRange(cellRange)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if maxRow < minRow || maxCol < minCol {
+		return nil, fmt.Errorf("sheet has no values in range")
+	}
+	grid := make([][]interface{}, maxRow-minRow+1)
+	for i := range grid {
+		grid[i] = make([]interface{}, maxCol-minCol+1)
+		for k := range grid[i] {
+			grid[i][k] = cells[[2]int{minRow + i, minCol + k}]
+		}
+	}
+	return grid, nil
+}
+
+var xlsxCellReference = regexp.MustCompile(`^\$?([A-Za-z]{1,3})\$?([0-9]+)$`)
+
+// parseXLSXCellReference returns the zero-indexed row and column positions of a cell reference such as "B3".
+func parseXLSXCellReference(ref string) (int, int, error) {
+	match := xlsxCellReference.FindStringSubmatch(ref)
+	if match == nil {
+		return 0, 0, fmt.Errorf("invalid cell reference (%s)", ref)
+	}
+	var col int
+	for _, r := range strings.ToUpper(match[1]) {
+		col = col*26 + int(r-'A') + 1
+	}
+	row, _ := strconv.Atoi(match[2])
+	if row == 0 {
+		return 0, 0, fmt.Errorf("invalid cell reference (%s)", ref)
+	}
+	return row - 1, col - 1, nil
+}
+
+// parseXLSXRange returns the zero-indexed bounds (inclusive) of a range such as "A1:C10". A single cell is also a valid range.
+func parseXLSXRange(ref string) (startRow, startCol, endRow, endCol int, err error) {
+	parts := strings.Split(ref, ":")
+	if len(parts) > 2 {
+		return 0, 0, 0, 0, fmt.Errorf("invalid range (%s)", ref)
+	}
+	startRow, startCol, err = parseXLSXCellReference(parts[0])
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	endRow, endCol = startRow, startCol
+	if len(parts) == 2 {
+		endRow, endCol, err = parseXLSXCellReference(parts[1])
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+	}
+	if endRow < startRow || endCol < startCol {
+		return 0, 0, 0, 0, fmt.Errorf("invalid range (%s): end precedes start", ref)
+	}
+	return startRow, startCol, endRow, endCol, nil
+}
+
+// xlsxColumnName returns the letters that identify the zero-indexed column k (e.g., 0 -> A, 26 -> AA).
+func xlsxColumnName(k int) string {
+	var ret []byte
+	for k++; k > 0; k = (k - 1) / 26 {
+		ret = append([]byte{byte('A' + (k-1)%26)}, ret...)
+	}
+	return string(ret)
+}
+
+func isXLSXBuiltInDateFormat(id int) bool {
+	return (id >= 14 && id <= 22) || (id >= 45 && id <= 47)
+}
+
+var xlsxFormatLiterals = regexp.MustCompile(`"[^"]*"|\[[^\]]*\]|\\.|_.|\*.`)
+
+// isXLSXDateFormat reports whether a custom number format displays a date or time.
+func isXLSXDateFormat(code string) bool {
+	code = strings.ToLower(xlsxFormatLiterals.ReplaceAllString(code, ""))
+	return strings.ContainsAny(code, "ymdhs")
+}
+
+// Excel serial dates count days since 1899-12-30 (or 1904-01-01 in the 1904 date system).
+// Serial dates before March 1, 1900 are offset by one day, because Excel treats 1900 as a leap year.
+var (
+	xlsxEpoch     = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	xlsxEpoch1904 = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// convertXLSXSerialToDateTime converts an Excel serial date to a time, rounded to the nearest millisecond.
+func convertXLSXSerialToDateTime(serial float64, date1904 bool) time.Time {
+	epoch := xlsxEpoch
+	if date1904 {
+		epoch = xlsxEpoch1904
+	} else if serial < 61 && serial >= 1 {
+		epoch = epoch.AddDate(0, 0, 1)
+	}
+	days := math.Floor(serial)
+	millis := math.Round((serial - days) * 86400000)
+	return epoch.AddDate(0, 0, int(days)).Add(time.Duration(millis) * time.Millisecond)
+}
+
+// convertDateTimeToXLSXSerial converts a time (in UTC) to an Excel serial date.
+func convertDateTimeToXLSXSerial(t time.Time) float64 {
+	t = t.UTC()
+	serial := float64(t.Sub(xlsxEpoch)) / float64(24*time.Hour)
+	if serial < 61 && serial >= 1 {
+		serial--
+	}
+	return serial
+}
+
+// xlsxString returns the text of a cell value.
+func xlsxString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return convertDateTimeToString(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// distinctNonEmpty returns the non-empty strings in list, without consecutive duplicates.
+func distinctNonEmpty(list []string) []string {
+	var ret []string
+	for _, s := range list {
+		if s == "" || (len(ret) > 0 && ret[len(ret)-1] == s) {
+			continue
+		}
+		ret = append(ret, s)
+	}
+	return ret
+}
+
+// xlsxValuesToContainer converts cell values into a typed valueContainer.
+func xlsxValuesToContainer(vals []interface{}, name string) *valueContainer {
+	isNull := make([]bool, len(vals))
+	var numFloats, numTimes, numBools, numValues int
+	for i := range vals {
+		switch vals[i].(type) {
+		case nil:
+			isNull[i] = true
+			continue
+		case float64:
+			numFloats++
+		case time.Time:
+			numTimes++
+		case bool:
+			numBools++
+		}
+		numValues++
+	}
+	var slice interface{}
+	switch {
+	case numValues > 0 && numFloats == numValues:
+		arr := make([]float64, len(vals))
+		for i := range vals {
+			arr[i], _ = vals[i].(float64)
+		}
+		slice = arr
+	case numValues > 0 && numTimes == numValues:
+		arr := make([]time.Time, len(vals))
+		for i := range vals {
+			arr[i], _ = vals[i].(time.Time)
+		}
+		slice = arr
+	case numValues > 0 && numBools == numValues:
+		arr := make([]bool, len(vals))
+		for i := range vals {
+			arr[i], _ = vals[i].(bool)
+		}
+		slice = arr
+	default:
+		arr := make([]string, len(vals))
+		for i := range vals {
+			arr[i] = xlsxString(vals[i])
+		}
+		slice = arr
+	}
+	return newValueContainer(slice, isNull, name)
+}
+
+var xlsxInvalidSheetCharacters = regexp.MustCompile(`[\[\]:*?/\\]`)
+
+// xlsxSheetName returns a valid sheet name based on name that is not already used by any of sheets.
+func xlsxSheetName(name string, sheets []xlsxSheet) string {
+	taken := make(map[string]bool)
+	for _, sheet := range sheets {
+		taken[strings.ToLower(sheet.name)] = true
+	}
+	name = xlsxInvalidSheetCharacters.ReplaceAllString(name, "_")
+	if len(name) > 31 {
+		name = name[:31]
+	}
+	if name != "" && !taken[strings.ToLower(name)] {
+		return name
+	}
+	for i := len(sheets) + 1; ; i++ {
+		name = fmt.Sprintf("Sheet%d", i)
+		if !taken[strings.ToLower(name)] {
+			return name
+		}
+	}
+}
+
+// xlsx cell styles, as defined in xlsxStylesXML
+const (
+	xlsxStyleDefault  = 0
+	xlsxStyleDateTime = 1
+	xlsxStyleDate     = 2
+	xlsxStyleTime     = 3
+	xlsxStyleHeader   = 4
+)
+
+// xlsxCells returns the xml of every cell in vc (without the cell reference), or "" for empty cells.
+func xlsxCells(vc *valueContainer) []string {
+	ret := make([]string, vc.len())
+	number := func(v float64, style int) string {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return ""
+		}
+		var s string
+		if style != xlsxStyleDefault {
+			s = fmt.Sprintf(` s="%d"`, style)
+		}
+		return fmt.Sprintf(`%s><v>%s</v>`, s, strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	switch vc.slice.(type) {
+	case []float64, []float32, []int, []int8, []int16, []int32, []int64, []uint, []uint16, []uint32, []uint64:
+		vals := vc.float64().slice
+		for i := range vals {
+			ret[i] = number(vals[i], xlsxStyleDefault)
+		}
+	case []bool:
+		vals := vc.slice.([]bool)
+		for i := range vals {
+			if vals[i] {
+				ret[i] = ` t="b"><v>1</v>`
+			} else {
+				ret[i] = ` t="b"><v>0</v>`
+			}
+		}
+	case []time.Time:
+		vals := vc.slice.([]time.Time)
+		for i := range vals {
+			ret[i] = number(convertDateTimeToXLSXSerial(vals[i]), xlsxStyleDateTime)
+		}
+	case []civil.Date:
+		vals := vc.slice.([]civil.Date)
+		for i := range vals {
+			ret[i] = number(convertDateTimeToXLSXSerial(vals[i].In(time.UTC)), xlsxStyleDate)
+		}
+	case []civil.Time:
+		vals := vc.slice.([]civil.Time)
+		for i := range vals {
+			t := vals[i]
+			seconds := float64(t.Hour*3600+t.Minute*60+t.Second) + float64(t.Nanosecond)/1e9
+			ret[i] = number(seconds/86400, xlsxStyleTime)
+		}
+	default:
+		vals := vc.string().slice
+		for i := range vals {
+			ret[i] = xlsxInlineString(vals[i], xlsxStyleDefault)
+		}
+	}
+	for i := range ret {
+		if vc.isNull[i] {
+			ret[i] = ""
+		}
+	}
+	return ret
+}
+
+func xlsxInlineString(s string, style int) string {
+	buf := new(bytes.Buffer)
+	if style != xlsxStyleDefault {
+		fmt.Fprintf(buf, ` s="%d"`, style)
+	}
+	buf.WriteString(` t="inlineStr"><is><t xml:space="preserve">`)
+	xml.EscapeText(buf, []byte(s))
+	buf.WriteString(`</t></is>`)
+	return buf.String()
+}
+
+// writeXLSXSheet returns the worksheet xml for labels (written as frozen leftmost columns) and columns.
+func writeXLSXSheet(labels []*valueContainer, columns []*valueContainer, numLevels int) []byte {
+	if numLevels < 1 {
+		numLevels = 1
+	}
+	containers := append(append([]*valueContainer{}, labels...), columns...)
+	// header cells and merged ranges
+	headers := make([][]string, numLevels)
+	var merges []string
+	for l := range headers {
+		headers[l] = make([]string, len(containers))
+	}
+	for k := range labels {
+		headers[0][k] = labels[k].name
+		if numLevels > 1 {
+			merges = append(merges, fmt.Sprintf("%s1:%s%d", xlsxColumnName(k), xlsxColumnName(k), numLevels))
+		}
+	}
+	levels := make([][]string, len(columns))
+	for k := range columns {
+		levels[k] = splitNameIntoNumLevels(columns[k].name, numLevels)
+		for l := 0; l < numLevels && l < len(levels[k]); l++ {
+			headers[l][len(labels)+k] = levels[k][l]
+		}
+	}
+	for l := 0; l < numLevels; l++ {
+		for start := 0; start < len(columns); {
+			end := start + 1
+			for end < len(columns) && sharesXLSXHeader(levels[start], levels[end], l) {
+				end++
+			}
+			if end-start > 1 {
+				merges = append(merges, fmt.Sprintf("%s%d:%s%d",
+					xlsxColumnName(len(labels)+start), l+1, xlsxColumnName(len(labels)+end-1), l+1))
+			}
+			start = end
+		}
+	}
+	cells := make([][]string, len(containers))
+	for k := range containers {
+		cells[k] = xlsxCells(containers[k])
+	}
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	xSplit, ySplit := len(labels), numLevels
+	activePane := "bottomRight"
+	if xSplit == 0 {
+		activePane = "bottomLeft"
+	}
+	buf.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane `)
+	if xSplit > 0 {
+		fmt.Fprintf(buf, `xSplit="%d" `, xSplit)
+	}
+	fmt.Fprintf(buf, `ySplit="%d" topLeftCell="%s%d" activePane="%s" state="frozen"/></sheetView></sheetViews>`,
+		ySplit, xlsxColumnName(xSplit), ySplit+1, activePane)
+	buf.WriteString(`<sheetData>`)
+	for l := range headers {
+		fmt.Fprintf(buf, `<row r="%d">`, l+1)
+		for k := range headers[l] {
+			if headers[l][k] != "" {
+				fmt.Fprintf(buf, `<c r="%s%d"%s</c>`, xlsxColumnName(k), l+1, xlsxInlineString(headers[l][k], xlsxStyleHeader))
+			}
+		}
+		buf.WriteString(`</row>`)
+	}
+	numRows := 0
+	if len(containers) > 0 {
+		numRows = containers[0].len()
+	}
+	for i := 0; i < numRows; i++ {
+		row := numLevels + i + 1
+		fmt.Fprintf(buf, `<row r="%d">`, row)
+		for k := range cells {
+			if cells[k][i] != "" {
+				fmt.Fprintf(buf, `<c r="%s%d"%s</c>`, xlsxColumnName(k), row, cells[k][i])
+			}
+		}
+		buf.WriteString(`</row>`)
+	}
+	buf.WriteString(`</sheetData>`)
+	if len(merges) > 0 {
+		fmt.Fprintf(buf, `<mergeCells count="%d">`, len(merges))
+		for _, ref := range merges {
+			fmt.Fprintf(buf, `<mergeCell ref="%s"/>`, ref)
+		}
+		buf.WriteString(`</mergeCells>`)
+	}
+	buf.WriteString(`</worksheet>`)
+	return buf.Bytes()
+}
+
+// sharesXLSXHeader reports whether two columns have the same name at every level up to and including level l.
+func sharesXLSXHeader(a, b []string, l int) bool {
+	if len(a) <= l || len(b) <= l {
+		return false
+	}
+	return reflect.DeepEqual(a[:l+1], b[:l+1])
+}
+
+const xlsxStylesXML = `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="5">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="14" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="21" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1" applyAlignment="1">` +
+	`<alignment horizontal="center" vertical="center"/></xf>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
+
+// writeXLSXWorkbook packages sheets into an xlsx file.
+func writeXLSXWorkbook(sheets []xlsxSheet) ([]byte, error) {
+	const (
+		relationshipsNamespace = "http://schemas.openxmlformats.org/package/2006/relationships"
+		relationshipType       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/"
+		contentType            = "application/vnd.openxmlformats-officedocument.spreadsheetml."
+	)
+	contentTypes := new(bytes.Buffer)
+	workbook := new(bytes.Buffer)
+	workbookRels := new(bytes.Buffer)
+
+	contentTypes.WriteString(xml.Header)
+	contentTypes.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="` + contentType + `sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="` + contentType + `styles+xml"/>`)
+	workbook.WriteString(xml.Header)
+	workbook.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(xml.Header)
+	workbookRels.WriteString(`<Relationships xmlns="` + relationshipsNamespace + `">`)
+	for i, sheet := range sheets {
+		fmt.Fprintf(contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="%sworksheet+xml"/>`,
+			i+1, contentType)
+		workbook.WriteString(`<sheet name="`)
+		xml.EscapeText(workbook, []byte(sheet.name))
+		fmt.Fprintf(workbook, `" sheetId="%d" r:id="rId%d"/>`, i+1, i+1)
+		fmt.Fprintf(workbookRels, `<Relationship Id="rId%d" Type="%sworksheet" Target="worksheets/sheet%d.xml"/>`,
+			i+1, relationshipType, i+1)
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	fmt.Fprintf(workbookRels, `<Relationship Id="rId%d" Type="%sstyles" Target="styles.xml"/></Relationships>`,
+		len(sheets)+1, relationshipType)
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"[Content_Types].xml", contentTypes.Bytes()},
+		{"_rels/.rels", []byte(xml.Header + `<Relationships xmlns="` + relationshipsNamespace + `">` +
+			`<Relationship Id="rId1" Type="` + relationshipType + `officeDocument" Target="xl/workbook.xml"/></Relationships>`)},
+		{"xl/workbook.xml", workbook.Bytes()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.Bytes()},
+		{"xl/styles.xml", []byte(xml.Header + xlsxStylesXML)},
+	}
+	for i, sheet := range sheets {
+		files = append(files, struct {
+			name string
+			data []byte
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.data})
+	}
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return nil, err
+		}
+		_, err = fw.Write(f.data)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := zw.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
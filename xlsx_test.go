@@ -0,0 +1,308 @@
+package tada
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeMockXLSX packages a workbook with one sheet per element of sheets, in the layout Excel uses
+// (shared strings, styles with custom number formats, and relative relationship targets).
+func writeMockXLSX(sheets map[string]string, date1904 bool) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	write := func(name string, data string) {
+		fw, _ := zw.Create(name)
+		fw.Write([]byte(data))
+	}
+	var workbookPr string
+	if date1904 {
+		workbookPr = `<workbookPr date1904="1"/>`
+	}
+	var sheetList, rels string
+	i := 1
+	for _, name := range []string{"first", "second"} {
+		data, ok := sheets[name]
+		if !ok {
+			continue
+		}
+		id := string(rune('0' + i))
+		sheetList += `<sheet name="` + name + `" sheetId="` + id + `" r:id="rId` + id + `"/>`
+		rels += `<Relationship Id="rId` + id + `" Target="worksheets/sheet` + id + `.xml" ` +
+			`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"/>`
+		write("xl/worksheets/sheet"+id+".xml",
+			`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`+data+`</worksheet>`)
+		i++
+	}
+	write("xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" `+
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`+
+		workbookPr+`<sheets>`+sheetList+`</sheets></workbook>`)
+	write("xl/_rels/workbook.xml.rels",
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`+rels+`</Relationships>`)
+	write("xl/sharedStrings.xml", `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`+
+		`<si><t>foo</t></si><si><r><t>ba</t></r><r><t>r</t></r></si><si><t>baz</t></si></sst>`)
+	write("xl/styles.xml", `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`+
+		`<numFmts count="2"><numFmt numFmtId="164" formatCode="yyyy\-mm\-dd"/><numFmt numFmtId="165" formatCode="&quot;day&quot;0.00"/></numFmts>`+
+		`<cellXfs count="4"><xf numFmtId="0"/><xf numFmtId="164"/><xf numFmtId="165"/><xf numFmtId="14"/></cellXfs></styleSheet>`)
+	zw.Close()
+	return buf.Bytes()
+}
+
+func TestXLSXReader_Read(t *testing.T) {
+	simple := `<sheetData>` +
+		`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="inlineStr"><is><t>qux</t></is></c></row>` +
+		`<row r="2"><c r="A2"><v>1.5</v></c><c r="B2" s="1"><v>43831</v></c><c r="C2" t="b"><v>1</v></c></row>` +
+		`<row r="3"><c r="A3" s="2"><v>2</v></c><c r="B3" s="3"><v>43831.5</v></c><c r="C3" t="e"><v>#N/A</v></c></row>` +
+		`<row r="4"><c r="B4" t="str"><v>corge</v></c></row>` +
+		`</sheetData>`
+	merged := `<sheetData>` +
+		`<row r="2"><c r="B2" t="inlineStr"><is><t>id</t></is></c><c r="C2" t="s"><v>2</v></c></row>` +
+		`<row r="3"><c r="C3" t="s"><v>0</v></c><c r="D3" t="s"><v>1</v></c></row>` +
+		`<row r="4"><c r="B4" t="inlineStr"><is><t>x</t></is></c><c r="C4"><v>1</v></c><c r="D4"><v>2</v></c><c r="E4"><v>9</v></c></row>` +
+		`</sheetData><mergeCells count="2"><mergeCell ref="B2:B3"/><mergeCell ref="C2:D2"/></mergeCells>`
+	type fields struct {
+		Sheet       string
+		Range       string
+		HeaderRows  int
+		LabelLevels int
+		Name        string
+		data        []byte
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - first sheet", fields{HeaderRows: 1, data: writeMockXLSX(map[string]string{"first": simple}, false)},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1.5, 2, 0}, isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+					{slice: []string{"2020-01-01T00:00:00Z", "2020-01-01T12:00:00Z", "corge"},
+						isNull: []bool{false, false, false}, id: mockID, name: "bar"},
+					{slice: []bool{true, false, false}, isNull: []bool{false, true, true}, id: mockID, name: "qux"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - named sheet with range", fields{Sheet: "first", Range: "A2:B3", HeaderRows: 0, Name: "corge",
+			data: writeMockXLSX(map[string]string{"first": simple}, false)},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1.5, 2}, isNull: []bool{false, false}, id: mockID, name: "0"},
+					{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)},
+						isNull: []bool{false, false}, id: mockID, name: "1"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"},
+				name:          "corge"},
+			false},
+		{"pass - 1904 date system", fields{Range: "B2", HeaderRows: 0,
+			data: writeMockXLSX(map[string]string{"first": simple}, true)},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+						isNull: []bool{false}, id: mockID, name: "0"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - merged headers and labels", fields{Sheet: "second", Range: "B2:D4", HeaderRows: 2, LabelLevels: 1,
+			data: writeMockXLSX(map[string]string{"first": simple, "second": merged}, false)},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "baz|foo"},
+					{slice: []float64{2}, isNull: []bool{false}, id: mockID, name: "baz|bar"},
+				},
+				labels: []*valueContainer{
+					{slice: []string{"x"}, isNull: []bool{false}, id: mockID, name: "id"}},
+				colLevelNames: []string{"*0", "*1"}},
+			false},
+		{"fail - sheet not found", fields{Sheet: "third", HeaderRows: 1,
+			data: writeMockXLSX(map[string]string{"first": simple}, false)},
+			nil, true},
+		{"fail - invalid range", fields{Range: "B2:A1", HeaderRows: 1,
+			data: writeMockXLSX(map[string]string{"first": simple}, false)},
+			nil, true},
+		{"fail - no rows after header", fields{Range: "A1:C1", HeaderRows: 1,
+			data: writeMockXLSX(map[string]string{"first": simple}, false)},
+			nil, true},
+		{"fail - too many label levels", fields{HeaderRows: 1, LabelLevels: 3,
+			data: writeMockXLSX(map[string]string{"first": simple}, false)},
+			nil, true},
+		{"fail - not a zip file", fields{HeaderRows: 1, data: []byte("foo")},
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewXLSXReader(bytes.NewReader(tt.fields.data))
+			r.Sheet = tt.fields.Sheet
+			r.Range = tt.fields.Range
+			r.HeaderRows = tt.fields.HeaderRows
+			r.LabelLevels = tt.fields.LabelLevels
+			r.Name = tt.fields.Name
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("XLSXReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("XLSXReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXLSXWriter_Write(t *testing.T) {
+	df := func() *DataFrame {
+		return &DataFrame{
+			values: []*valueContainer{
+				{slice: []float64{1, 2}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+				{slice: []string{"x", "<y>"}, isNull: []bool{false, false}, id: mockID, name: "foo|b"},
+				{slice: []time.Time{time.Date(2020, 1, 1, 12, 30, 0, 0, time.UTC), {}},
+					isNull: []bool{false, true}, id: mockID, name: "bar|c"},
+				{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "bar|d"},
+			},
+			labels: []*valueContainer{
+				{slice: []int{10, 11}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0", "*1"},
+			name:          "qux"}
+	}
+	buf := new(bytes.Buffer)
+	w := NewXLSXWriter(buf)
+	if err := w.Write(df()); err != nil {
+		t.Fatalf("XLSXWriter.Write() error = %v", err)
+	}
+	w.IncludeLabels = false
+	if err := w.Write(df()); err != nil {
+		t.Fatalf("XLSXWriter.Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("XLSXWriter.Close() error = %v", err)
+	}
+	if got := w.sheets[1].name; got != "Sheet2" {
+		t.Errorf("XLSXWriter.Write() sheet name = %v, want Sheet2", got)
+	}
+	sheet := string(w.sheets[0].data)
+	for _, want := range []string{
+		`<pane xSplit="1" ySplit="2" topLeftCell="B3" activePane="bottomRight" state="frozen"/>`,
+		`<mergeCell ref="A1:A2"/>`, `<mergeCell ref="B1:C1"/>`, `<mergeCell ref="D1:E1"/>`, "&lt;y&gt;"} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("XLSXWriter.Write() sheet does not contain %v", want)
+		}
+	}
+
+	want := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+			{slice: []string{"x", "<y>"}, isNull: []bool{false, false}, id: mockID, name: "foo|b"},
+			{slice: []time.Time{time.Date(2020, 1, 1, 12, 30, 0, 0, time.UTC), {}},
+				isNull: []bool{false, true}, id: mockID, name: "bar|c"},
+			{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "bar|d"},
+		},
+		labels: []*valueContainer{
+			{slice: []float64{10, 11}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0", "*1"}}
+	r := NewXLSXReader(bytes.NewReader(buf.Bytes()))
+	r.Sheet = "qux"
+	r.HeaderRows = 2
+	r.LabelLevels = 1
+	got, err := r.Read()
+	if err != nil {
+		t.Fatalf("XLSXReader.Read() error = %v", err)
+	}
+	if !EqualDataFrames(got, want) {
+		t.Errorf("XLSXWriter.Write() -> XLSXReader.Read() = %v, want %v", got, want)
+	}
+
+	r = NewXLSXReader(bytes.NewReader(buf.Bytes()))
+	r.Sheet = "Sheet2"
+	r.HeaderRows = 2
+	got, err = r.Read()
+	if err != nil {
+		t.Fatalf("XLSXReader.Read() error = %v", err)
+	}
+	want.labels = []*valueContainer{
+		{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}}
+	if !EqualDataFrames(got, want) {
+		t.Errorf("XLSXWriter.Write() without labels -> XLSXReader.Read() = %v, want %v", got, want)
+	}
+}
+
+func TestXLSXWriter_Write_errors(t *testing.T) {
+	w := NewXLSXWriter(new(bytes.Buffer))
+	if err := w.Write(dataFrameWithError(errors.New("foo"))); err == nil {
+		t.Errorf("XLSXWriter.Write() error = nil, want error")
+	}
+	if err := w.Close(); err == nil {
+		t.Errorf("XLSXWriter.Close() with no sheets error = nil, want error")
+	}
+}
+
+func Test_convertXLSXSerialToDateTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		serial   float64
+		date1904 bool
+		want     time.Time
+	}{
+		{"1900 system", 43831.25, false, time.Date(2020, 1, 1, 6, 0, 0, 0, time.UTC)},
+		{"before 1900 leap day", 59, false, time.Date(1900, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"after 1900 leap day", 61, false, time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"first day", 1, false, time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"time only", 0.5, false, time.Date(1899, 12, 30, 12, 0, 0, 0, time.UTC)},
+		{"1904 system", 0, true, time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertXLSXSerialToDateTime(tt.serial, tt.date1904)
+			if !got.Equal(tt.want) {
+				t.Errorf("convertXLSXSerialToDateTime() = %v, want %v", got, tt.want)
+			}
+			if tt.date1904 {
+				return
+			}
+			if back := convertDateTimeToXLSXSerial(got); back != tt.serial {
+				t.Errorf("convertDateTimeToXLSXSerial() = %v, want %v", back, tt.serial)
+			}
+		})
+	}
+}
+
+func Test_xlsxColumnName(t *testing.T) {
+	for k, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumnName(k); got != want {
+			t.Errorf("xlsxColumnName(%d) = %v, want %v", k, got, want)
+		}
+		row, col, err := parseXLSXCellReference(want + "1")
+		if err != nil || row != 0 || col != k {
+			t.Errorf("parseXLSXCellReference(%v1) = %v, %v, %v, want 0, %v", want, row, col, err, k)
+		}
+	}
+}
+
+func Test_isXLSXDateFormat(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"yyyy-mm-dd", true},
+		{"h:mm AM/PM", true},
+		{"[$-409]d-mmm", true},
+		{"General", false},
+		{"0.00", false},
+		{`"days "0`, false},
+		{"[Red]#,##0", false},
+	}
+	for _, tt := range tests {
+		if got := isXLSXDateFormat(tt.code); got != tt.want {
+			t.Errorf("isXLSXDateFormat(%v) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,1096 @@
+package tada
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// -- zstd (RFC 8878)
+
+const (
+	zstdMagic           = 0xFD2FB528
+	zstdSkippableMagic  = 0x184D2A50
+	zstdMaxBlockSize    = 1 << 17
+	zstdMaxWindowSize   = 1 << 31
+	zstdEncoderWindow   = 1 << 20
+	zstdEncoderHashBits = 16
+)
+
+// literals length, match length, and offset codes
+var (
+	zstdLLBase = [36]uint32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		16, 18, 20, 22, 24, 28, 32, 40, 48, 64, 128, 256, 512, 1024, 2048, 4096, 8192, 16384, 32768, 65536}
+	zstdLLBits = [36]uint8{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	zstdMLBase = [53]uint32{3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26,
+		27, 28, 29, 30, 31, 32, 33, 34, 35, 37, 39, 41, 43, 47, 51, 59, 67, 83, 99, 131, 259, 515, 1027, 2051,
+		4099, 8195, 16387, 32771, 65539}
+	zstdMLBits = [53]uint8{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+)
+
+// default distributions, used when a sequence table is in predefined mode
+var (
+	zstdLLDefaultNorm = []int16{4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1, -1, -1, -1, -1}
+	zstdMLDefaultNorm = []int16{1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1, -1, -1}
+	zstdOFDefaultNorm = []int16{1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1}
+	zstdLLDefaultTable = buildFSEDecodingTable(zstdLLDefaultNorm, 6)
+	zstdMLDefaultTable = buildFSEDecodingTable(zstdMLDefaultNorm, 6)
+	zstdOFDefaultTable = buildFSEDecodingTable(zstdOFDefaultNorm, 5)
+)
+
+// -- bitstreams
+
+// a zstdForwardBits reads a little-endian bitstream from the first bit of b (used for table descriptions).
+type zstdForwardBits struct {
+	b   []byte
+	pos int
+}
+
+func (br *zstdForwardBits) bits(n int) (uint32, error) {
+	var v uint32
+	for i := 0; i < n; i++ {
+		if br.pos>>3 >= len(br.b) {
+			return 0, fmt.Errorf("table description is truncated")
+		}
+		v |= uint32(br.b[br.pos>>3]>>(br.pos&7)&1) << i
+		br.pos++
+	}
+	return v, nil
+}
+
+// a zstdBackwardBits reads a bitstream from its last bit to its first (used for entropy-coded data).
+// The highest set bit of the last byte marks the start of the stream.
+// Reading beyond the first bit yields zeros and is reported by overflow().
+type zstdBackwardBits struct {
+	b   []byte
+	pos int // number of unread bits
+}
+
+func newZstdBackwardBits(b []byte) (*zstdBackwardBits, error) {
+	if len(b) == 0 || b[len(b)-1] == 0 {
+		return nil, fmt.Errorf("bitstream is missing its end marker")
+	}
+	return &zstdBackwardBits{b: b, pos: 8*len(b) - bits.LeadingZeros8(b[len(b)-1]) - 1}, nil
+}
+
+func (br *zstdBackwardBits) bits(n int) uint64 {
+	var v uint64
+	for n > 0 {
+		if br.pos <= 0 {
+			v <<= uint(n)
+			br.pos -= n
+			break
+		}
+		byteIndex := (br.pos - 1) >> 3
+		take := br.pos - byteIndex*8
+		if take > n {
+			take = n
+		}
+		shift := br.pos - take - byteIndex*8
+		chunk := uint64(br.b[byteIndex]>>uint(shift)) & (1<<uint(take) - 1)
+		v = v<<uint(take) | chunk
+		br.pos -= take
+		n -= take
+	}
+	return v
+}
+
+func (br *zstdBackwardBits) peek(n int) uint64 {
+	pos := br.pos
+	v := br.bits(n)
+	br.pos = pos
+	return v
+}
+
+func (br *zstdBackwardBits) overflow() bool {
+	return br.pos < 0
+}
+
+// a zstdBitWriter writes a little-endian bitstream, to be read backward.
+type zstdBitWriter struct {
+	out []byte
+	acc uint64
+	n   uint
+}
+
+func (w *zstdBitWriter) addBits(v uint64, n uint) {
+	w.acc |= (v & (1<<n - 1)) << w.n
+	w.n += n
+	for w.n >= 8 {
+		w.out = append(w.out, byte(w.acc))
+		w.acc >>= 8
+		w.n -= 8
+	}
+}
+
+// close writes the end marker and pads the stream to a whole byte.
+func (w *zstdBitWriter) close() []byte {
+	w.addBits(1, 1)
+	if w.n > 0 {
+		w.out = append(w.out, byte(w.acc))
+	}
+	return w.out
+}
+
+// -- finite state entropy
+
+type fseEntry struct {
+	symbol uint8
+	nbBits uint8
+	base   uint16
+}
+
+type fseTable struct {
+	accuracyLog int
+	entries     []fseEntry
+}
+
+// readFSETableDescription reads the normalized counts of an FSE table
+// and returns them with the accuracy log and the number of bytes read.
+func readFSETableDescription(b []byte, maxSymbol int, maxLog int) ([]int16, int, int, error) {
+	br := &zstdForwardBits{b: b}
+	v, err := br.bits(4)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	accuracyLog := int(v) + 5
+	if accuracyLog > maxLog {
+		return nil, 0, 0, fmt.Errorf("accuracy log (%d) exceeds maximum (%d)", accuracyLog, maxLog)
+	}
+	remaining := 1<<uint(accuracyLog) + 1
+	threshold := 1 << uint(accuracyLog)
+	nbBits := accuracyLog + 1
+	var norm []int16
+	previous0 := false
+	for remaining > 1 {
+		if previous0 {
+			for {
+				repeat, err := br.bits(2)
+				if err != nil {
+					return nil, 0, 0, err
+				}
+				for i := 0; i < int(repeat); i++ {
+					norm = append(norm, 0)
+				}
+				if repeat != 3 {
+					break
+				}
+			}
+			if len(norm) > maxSymbol {
+				return nil, 0, 0, fmt.Errorf("too many symbols")
+			}
+		}
+		max := (2*threshold - 1) - remaining
+		low, err := br.bits(nbBits - 1)
+		if err != nil {
+			// the final value may end within the last byte
+			return nil, 0, 0, err
+		}
+		var count int
+		if int(low) < max {
+			count = int(low)
+		} else {
+			high, err := br.bits(1)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			count = int(low) | int(high)<<uint(nbBits-1)
+			if count >= threshold {
+				count -= max
+			}
+		}
+		count--
+		if count < 0 {
+			remaining += count
+		} else {
+			remaining -= count
+		}
+		norm = append(norm, int16(count))
+		if len(norm) > maxSymbol+1 {
+			return nil, 0, 0, fmt.Errorf("too many symbols")
+		}
+		previous0 = count == 0
+		for remaining < threshold && threshold > 1 {
+			nbBits--
+			threshold >>= 1
+		}
+	}
+	if remaining != 1 {
+		return nil, 0, 0, fmt.Errorf("invalid normalized counts")
+	}
+	return norm, accuracyLog, (br.pos + 7) / 8, nil
+}
+
+// fseSpread returns the symbol at each position of a table.
+func fseSpread(norm []int16, accuracyLog int) []uint8 {
+	tableSize := 1 << uint(accuracyLog)
+	symbols := make([]uint8, tableSize)
+	highThreshold := tableSize - 1
+	for s, count := range norm {
+		if count == -1 {
+			symbols[highThreshold] = uint8(s)
+			highThreshold--
+		}
+	}
+	step := tableSize>>1 + tableSize>>3 + 3
+	mask := tableSize - 1
+	pos := 0
+	for s, count := range norm {
+		for i := 0; i < int(count); i++ {
+			symbols[pos] = uint8(s)
+			pos = (pos + step) & mask
+			for pos > highThreshold {
+				pos = (pos + step) & mask
+			}
+		}
+	}
+	return symbols
+}
+
+func buildFSEDecodingTable(norm []int16, accuracyLog int) *fseTable {
+	tableSize := 1 << uint(accuracyLog)
+	symbols := fseSpread(norm, accuracyLog)
+	next := make([]int, len(norm))
+	for s, count := range norm {
+		if count == -1 {
+			next[s] = 1
+		} else {
+			next[s] = int(count)
+		}
+	}
+	entries := make([]fseEntry, tableSize)
+	for u := range entries {
+		s := symbols[u]
+		state := next[s]
+		next[s]++
+		nbBits := accuracyLog - (bits.Len(uint(state)) - 1)
+		entries[u] = fseEntry{symbol: s, nbBits: uint8(nbBits), base: uint16(state<<uint(nbBits) - tableSize)}
+	}
+	return &fseTable{accuracyLog: accuracyLog, entries: entries}
+}
+
+// an fseEncoder encodes symbols with the table described by the same normalized counts.
+type fseEncoder struct {
+	accuracyLog int
+	states      []uint16
+	deltaNbBits []uint32
+	deltaState  []int32
+}
+
+func newFSEEncoder(norm []int16, accuracyLog int) *fseEncoder {
+	tableSize := 1 << uint(accuracyLog)
+	symbols := fseSpread(norm, accuracyLog)
+	cumul := make([]int, len(norm)+1)
+	for s, count := range norm {
+		if count == -1 {
+			count = 1
+		}
+		cumul[s+1] = cumul[s] + int(count)
+	}
+	enc := &fseEncoder{
+		accuracyLog: accuracyLog,
+		states:      make([]uint16, tableSize),
+		deltaNbBits: make([]uint32, len(norm)),
+		deltaState:  make([]int32, len(norm)),
+	}
+	for u := 0; u < tableSize; u++ {
+		s := symbols[u]
+		enc.states[cumul[s]] = uint16(tableSize + u)
+		cumul[s]++
+	}
+	total := 0
+	for s, count := range norm {
+		switch count {
+		case 0:
+		case -1, 1:
+			enc.deltaNbBits[s] = uint32(accuracyLog<<16 - tableSize)
+			enc.deltaState[s] = int32(total - 1)
+			total++
+		default:
+			maxBitsOut := accuracyLog - (bits.Len(uint(count-1)) - 1)
+			minStatePlus := int(count) << uint(maxBitsOut)
+			enc.deltaNbBits[s] = uint32(maxBitsOut<<16 - minStatePlus)
+			enc.deltaState[s] = int32(total - int(count))
+			total += int(count)
+		}
+	}
+	return enc
+}
+
+func (enc *fseEncoder) init(symbol uint8) uint32 {
+	nbBitsOut := (enc.deltaNbBits[symbol] + 1<<15) >> 16
+	state := nbBitsOut<<16 - enc.deltaNbBits[symbol]
+	return uint32(enc.states[int32(state>>nbBitsOut)+enc.deltaState[symbol]])
+}
+
+func (enc *fseEncoder) encode(w *zstdBitWriter, state uint32, symbol uint8) uint32 {
+	nbBitsOut := (state + enc.deltaNbBits[symbol]) >> 16
+	w.addBits(uint64(state), uint(nbBitsOut))
+	return uint32(enc.states[int32(state>>nbBitsOut)+enc.deltaState[symbol]])
+}
+
+func (enc *fseEncoder) flush(w *zstdBitWriter, state uint32) {
+	w.addBits(uint64(state), uint(enc.accuracyLog))
+}
+
+var (
+	zstdLLEncoder = newFSEEncoder(zstdLLDefaultNorm, 6)
+	zstdMLEncoder = newFSEEncoder(zstdMLDefaultNorm, 6)
+	zstdOFEncoder = newFSEEncoder(zstdOFDefaultNorm, 5)
+)
+
+// -- huffman
+
+type huffEntry struct {
+	symbol uint8
+	nbBits uint8
+}
+
+type huffTable struct {
+	maxBits int
+	entries []huffEntry
+}
+
+// readHuffmanTable reads a Huffman tree description and returns the decoding table and the number of bytes read.
+func readHuffmanTable(b []byte) (*huffTable, int, error) {
+	if len(b) == 0 {
+		return nil, 0, fmt.Errorf("missing huffman tree description")
+	}
+	header := int(b[0])
+	var weights []uint8
+	var n int
+	if header < 128 {
+		// FSE-compressed weights
+		n = 1 + header
+		if len(b) < n {
+			return nil, 0, fmt.Errorf("huffman tree description is truncated")
+		}
+		norm, accuracyLog, k, err := readFSETableDescription(b[1:n], 255, 6)
+		if err != nil {
+			return nil, 0, fmt.Errorf("huffman weights: %v", err)
+		}
+		table := buildFSEDecodingTable(norm, accuracyLog)
+		br, err := newZstdBackwardBits(b[1+k : n])
+		if err != nil {
+			return nil, 0, fmt.Errorf("huffman weights: %v", err)
+		}
+		state1 := br.bits(accuracyLog)
+		state2 := br.bits(accuracyLog)
+		decode := func(state *uint64) uint8 {
+			e := table.entries[*state]
+			*state = uint64(e.base) + br.bits(int(e.nbBits))
+			return e.symbol
+		}
+		for {
+			weights = append(weights, decode(&state1))
+			if br.overflow() {
+				weights = append(weights, table.entries[state2].symbol)
+				break
+			}
+			weights = append(weights, decode(&state2))
+			if br.overflow() {
+				weights = append(weights, table.entries[state1].symbol)
+				break
+			}
+			if len(weights) > 255 {
+				return nil, 0, fmt.Errorf("too many huffman weights")
+			}
+		}
+	} else {
+		numWeights := header - 127
+		n = 1 + (numWeights+1)/2
+		if len(b) < n {
+			return nil, 0, fmt.Errorf("huffman tree description is truncated")
+		}
+		for i := 0; i < numWeights; i++ {
+			v := b[1+i/2]
+			if i%2 == 0 {
+				weights = append(weights, v>>4)
+			} else {
+				weights = append(weights, v&15)
+			}
+		}
+	}
+	if len(weights) > 255 {
+		return nil, 0, fmt.Errorf("too many huffman weights")
+	}
+	var sum int
+	for _, w := range weights {
+		if w > 11 {
+			return nil, 0, fmt.Errorf("invalid huffman weight (%d)", w)
+		}
+		if w > 0 {
+			sum += 1 << (w - 1)
+		}
+	}
+	if sum == 0 {
+		return nil, 0, fmt.Errorf("invalid huffman weights")
+	}
+	maxBits := bits.Len(uint(sum))
+	rest := 1<<uint(maxBits) - sum
+	if rest&(rest-1) != 0 {
+		return nil, 0, fmt.Errorf("invalid huffman weights")
+	}
+	weights = append(weights, uint8(bits.Len(uint(rest))))
+	if maxBits > 11 {
+		return nil, 0, fmt.Errorf("huffman codes exceed 11 bits")
+	}
+	entries := make([]huffEntry, 1<<uint(maxBits))
+	pos := 0
+	for w := 1; w <= maxBits; w++ {
+		for s, weight := range weights {
+			if int(weight) != w {
+				continue
+			}
+			length := 1 << uint(w-1)
+			for i := 0; i < length; i++ {
+				entries[pos+i] = huffEntry{symbol: uint8(s), nbBits: uint8(maxBits + 1 - w)}
+			}
+			pos += length
+		}
+	}
+	return &huffTable{maxBits: maxBits, entries: entries}, n, nil
+}
+
+func (t *huffTable) decodeStream(b []byte, n int, out []byte) ([]byte, error) {
+	br, err := newZstdBackwardBits(b)
+	if err != nil {
+		return nil, fmt.Errorf("huffman stream: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		e := t.entries[br.peek(t.maxBits)]
+		out = append(out, e.symbol)
+		br.bits(int(e.nbBits))
+	}
+	if br.pos != 0 {
+		return nil, fmt.Errorf("huffman stream: size does not match number of literals")
+	}
+	return out, nil
+}
+
+// -- decoder
+
+// a zstdReader decompresses one or more zstd frames.
+type zstdReader struct {
+	r          *bufio.Reader
+	inFrame    bool
+	checksum   bool
+	windowSize int
+	history    []byte
+	out        []byte
+	err        error
+	// state that persists across the blocks of a frame
+	reps    [3]int
+	huffman *huffTable
+	llTable *fseTable
+	ofTable *fseTable
+	mlTable *fseTable
+}
+
+func newZstdReader(r io.Reader) *zstdReader {
+	return &zstdReader{r: bufio.NewReader(r)}
+}
+
+func (z *zstdReader) Read(p []byte) (int, error) {
+	for len(z.out) == 0 {
+		if z.err != nil {
+			return 0, z.err
+		}
+		z.err = z.next()
+	}
+	n := copy(p, z.out)
+	z.out = z.out[n:]
+	return n, nil
+}
+
+// next decodes the next block (reading a frame header first, if necessary) into z.out.
+func (z *zstdReader) next() error {
+	if !z.inFrame {
+		err := z.readFrameHeader()
+		if err != nil {
+			return err
+		}
+	}
+	var header [3]byte
+	_, err := io.ReadFull(z.r, header[:])
+	if err != nil {
+		return fmt.Errorf("zstd: reading block header: %v", io.ErrUnexpectedEOF)
+	}
+	v := int(header[0]) | int(header[1])<<8 | int(header[2])<<16
+	last := v&1 == 1
+	blockType := (v >> 1) & 3
+	size := v >> 3
+	if size > zstdMaxBlockSize {
+		return fmt.Errorf("zstd: block size (%d) exceeds maximum", size)
+	}
+	start := len(z.history)
+	switch blockType {
+	case 0:
+		z.history = append(z.history, make([]byte, size)...)
+		_, err = io.ReadFull(z.r, z.history[start:])
+	case 1:
+		var b byte
+		b, err = z.r.ReadByte()
+		for i := 0; i < size; i++ {
+			z.history = append(z.history, b)
+		}
+	case 2:
+		data := make([]byte, size)
+		_, err = io.ReadFull(z.r, data)
+		if err == nil {
+			err = z.decodeCompressedBlock(data)
+			if err != nil {
+				return fmt.Errorf("zstd: %v", err)
+			}
+		}
+	default:
+		return fmt.Errorf("zstd: reserved block type")
+	}
+	if err != nil {
+		return fmt.Errorf("zstd: reading block: %v", io.ErrUnexpectedEOF)
+	}
+	z.out = z.history[start:]
+	if len(z.history) > 2*z.windowSize {
+		// z.out continues to refer to the old history
+		z.history = append([]byte(nil), z.history[len(z.history)-z.windowSize:]...)
+	}
+	if last {
+		z.inFrame = false
+		if z.checksum {
+			_, err = z.r.Discard(4)
+			if err != nil {
+				return fmt.Errorf("zstd: reading checksum: %v", io.ErrUnexpectedEOF)
+			}
+		}
+	}
+	return nil
+}
+
+func (z *zstdReader) readFrameHeader() error {
+	var magic [4]byte
+	_, err := io.ReadFull(z.r, magic[:])
+	if err == io.EOF {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("zstd: reading frame header: %v", io.ErrUnexpectedEOF)
+	}
+	m := binary.LittleEndian.Uint32(magic[:])
+	if m&0xFFFFFFF0 == zstdSkippableMagic {
+		var size [4]byte
+		_, err = io.ReadFull(z.r, size[:])
+		if err == nil {
+			_, err = z.r.Discard(int(binary.LittleEndian.Uint32(size[:])))
+		}
+		if err != nil {
+			return fmt.Errorf("zstd: reading skippable frame: %v", io.ErrUnexpectedEOF)
+		}
+		return z.readFrameHeader()
+	}
+	if m != zstdMagic {
+		return fmt.Errorf("zstd: invalid magic number")
+	}
+	descriptor, err := z.r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("zstd: reading frame header: %v", io.ErrUnexpectedEOF)
+	}
+	fcsFlag := descriptor >> 6
+	singleSegment := descriptor&(1<<5) != 0
+	if descriptor&(1<<3) != 0 {
+		return fmt.Errorf("zstd: reserved bit is set in frame header")
+	}
+	z.checksum = descriptor&(1<<2) != 0
+	dictIDSize := [4]int{0, 1, 2, 4}[descriptor&3]
+	fcsSize := [4]int{0, 2, 4, 8}[fcsFlag]
+	if fcsFlag == 0 && singleSegment {
+		fcsSize = 1
+	}
+	size := dictIDSize + fcsSize
+	if !singleSegment {
+		size++
+	}
+	header := make([]byte, size)
+	_, err = io.ReadFull(z.r, header)
+	if err != nil {
+		return fmt.Errorf("zstd: reading frame header: %v", io.ErrUnexpectedEOF)
+	}
+	if !singleSegment {
+		exponent := header[0] >> 3
+		mantissa := int(header[0] & 7)
+		windowBase := 1 << (10 + uint(exponent))
+		z.windowSize = windowBase + windowBase/8*mantissa
+		header = header[1:]
+	}
+	var dictID uint64
+	for i := dictIDSize - 1; i >= 0; i-- {
+		dictID = dictID<<8 | uint64(header[i])
+	}
+	if dictID != 0 {
+		return fmt.Errorf("zstd: dictionaries are not supported")
+	}
+	header = header[dictIDSize:]
+	if singleSegment {
+		var fcs uint64
+		for i := fcsSize - 1; i >= 0; i-- {
+			fcs = fcs<<8 | uint64(header[i])
+		}
+		if fcsSize == 2 {
+			fcs += 256
+		}
+		if fcs > zstdMaxWindowSize {
+			return fmt.Errorf("zstd: window size exceeds maximum")
+		}
+		z.windowSize = int(fcs)
+	}
+	if z.windowSize > zstdMaxWindowSize {
+		return fmt.Errorf("zstd: window size exceeds maximum")
+	}
+	z.inFrame = true
+	z.history = nil
+	z.reps = [3]int{1, 4, 8}
+	z.huffman = nil
+	z.llTable, z.ofTable, z.mlTable = nil, nil, nil
+	return nil
+}
+
+// decodeLiterals decodes the literals section of a compressed block and returns the literals and the number of bytes read.
+func (z *zstdReader) decodeLiterals(b []byte) ([]byte, int, error) {
+	if len(b) == 0 {
+		return nil, 0, fmt.Errorf("missing literals section")
+	}
+	literalsType := b[0] & 3
+	sizeFormat := (b[0] >> 2) & 3
+	if literalsType < 2 {
+		var size, headerSize int
+		switch sizeFormat {
+		case 0, 2:
+			size, headerSize = int(b[0]>>3), 1
+		case 1:
+			if len(b) < 2 {
+				return nil, 0, fmt.Errorf("literals header is truncated")
+			}
+			size, headerSize = int(b[0]>>4)|int(b[1])<<4, 2
+		case 3:
+			if len(b) < 3 {
+				return nil, 0, fmt.Errorf("literals header is truncated")
+			}
+			size, headerSize = int(b[0]>>4)|int(b[1])<<4|int(b[2])<<12, 3
+		}
+		if size > zstdMaxBlockSize {
+			return nil, 0, fmt.Errorf("literals size exceeds maximum")
+		}
+		if literalsType == 0 {
+			if len(b) < headerSize+size {
+				return nil, 0, fmt.Errorf("raw literals are truncated")
+			}
+			return b[headerSize : headerSize+size], headerSize + size, nil
+		}
+		if len(b) < headerSize+1 {
+			return nil, 0, fmt.Errorf("rle literals are truncated")
+		}
+		lits := make([]byte, size)
+		for i := range lits {
+			lits[i] = b[headerSize]
+		}
+		return lits, headerSize + 1, nil
+	}
+
+	var regenerated, compressed, headerSize int
+	numStreams := 4
+	switch sizeFormat {
+	case 0, 1:
+		if len(b) < 3 {
+			return nil, 0, fmt.Errorf("literals header is truncated")
+		}
+		v := int(b[0]) | int(b[1])<<8 | int(b[2])<<16
+		regenerated, compressed, headerSize = (v>>4)&0x3FF, (v>>14)&0x3FF, 3
+		if sizeFormat == 0 {
+			numStreams = 1
+		}
+	case 2:
+		if len(b) < 4 {
+			return nil, 0, fmt.Errorf("literals header is truncated")
+		}
+		v := int(binary.LittleEndian.Uint32(b))
+		regenerated, compressed, headerSize = (v>>4)&0x3FFF, (v>>18)&0x3FFF, 4
+	case 3:
+		if len(b) < 5 {
+			return nil, 0, fmt.Errorf("literals header is truncated")
+		}
+		v := int(binary.LittleEndian.Uint32(b)) | int(b[4])<<32
+		regenerated, compressed, headerSize = (v>>4)&0x3FFFF, (v>>22)&0x3FFFF, 5
+	}
+	if regenerated > zstdMaxBlockSize {
+		return nil, 0, fmt.Errorf("literals size exceeds maximum")
+	}
+	if len(b) < headerSize+compressed {
+		return nil, 0, fmt.Errorf("compressed literals are truncated")
+	}
+	payload := b[headerSize : headerSize+compressed]
+	if literalsType == 2 {
+		table, n, err := readHuffmanTable(payload)
+		if err != nil {
+			return nil, 0, err
+		}
+		z.huffman = table
+		payload = payload[n:]
+	} else if z.huffman == nil {
+		return nil, 0, fmt.Errorf("treeless literals without a previous huffman table")
+	}
+	lits := make([]byte, 0, regenerated)
+	var err error
+	if numStreams == 1 {
+		lits, err = z.huffman.decodeStream(payload, regenerated, lits)
+		if err != nil {
+			return nil, 0, err
+		}
+		return lits, headerSize + compressed, nil
+	}
+	if len(payload) < 6 {
+		return nil, 0, fmt.Errorf("literals jump table is truncated")
+	}
+	sizes := [4]int{
+		int(binary.LittleEndian.Uint16(payload)),
+		int(binary.LittleEndian.Uint16(payload[2:])),
+		int(binary.LittleEndian.Uint16(payload[4:])),
+	}
+	payload = payload[6:]
+	sizes[3] = len(payload) - sizes[0] - sizes[1] - sizes[2]
+	segment := (regenerated + 3) / 4
+	if sizes[3] < 0 || 3*segment > regenerated {
+		return nil, 0, fmt.Errorf("invalid literals jump table")
+	}
+	for i := 0; i < 4; i++ {
+		n := segment
+		if i == 3 {
+			n = regenerated - 3*segment
+		}
+		lits, err = z.huffman.decodeStream(payload[:sizes[i]], n, lits)
+		if err != nil {
+			return nil, 0, err
+		}
+		payload = payload[sizes[i]:]
+	}
+	return lits, headerSize + compressed, nil
+}
+
+// sequenceTable reads the table for one kind of sequence code according to mode and returns the number of bytes read.
+func sequenceTable(mode byte, b []byte, previous *fseTable, predefined *fseTable, maxSymbol int, maxLog int) (
+	*fseTable, int, error) {
+	switch mode {
+	case 0:
+		return predefined, 0, nil
+	case 1:
+		if len(b) == 0 || int(b[0]) > maxSymbol {
+			return nil, 0, fmt.Errorf("invalid rle sequence table")
+		}
+		return &fseTable{entries: []fseEntry{{symbol: b[0]}}}, 1, nil
+	case 2:
+		norm, accuracyLog, n, err := readFSETableDescription(b, maxSymbol, maxLog)
+		if err != nil {
+			return nil, 0, fmt.Errorf("sequence table: %v", err)
+		}
+		return buildFSEDecodingTable(norm, accuracyLog), n, nil
+	default:
+		if previous == nil {
+			return nil, 0, fmt.Errorf("repeated sequence table without a previous table")
+		}
+		return previous, 0, nil
+	}
+}
+
+func (z *zstdReader) decodeCompressedBlock(b []byte) error {
+	lits, n, err := z.decodeLiterals(b)
+	if err != nil {
+		return err
+	}
+	b = b[n:]
+	if len(b) == 0 {
+		return fmt.Errorf("missing sequences section")
+	}
+	numSequences := int(b[0])
+	switch {
+	case numSequences == 0:
+		z.history = append(z.history, lits...)
+		return nil
+	case numSequences < 128:
+		b = b[1:]
+	case numSequences < 255:
+		if len(b) < 2 {
+			return fmt.Errorf("sequences header is truncated")
+		}
+		numSequences = (numSequences-128)<<8 + int(b[1])
+		b = b[2:]
+	default:
+		if len(b) < 3 {
+			return fmt.Errorf("sequences header is truncated")
+		}
+		numSequences = int(b[1]) + int(b[2])<<8 + 0x7F00
+		b = b[3:]
+	}
+	if len(b) == 0 {
+		return fmt.Errorf("sequences header is truncated")
+	}
+	modes := b[0]
+	b = b[1:]
+	z.llTable, n, err = sequenceTable(modes>>6, b, z.llTable, zstdLLDefaultTable, 35, 9)
+	if err != nil {
+		return err
+	}
+	b = b[n:]
+	z.ofTable, n, err = sequenceTable((modes>>4)&3, b, z.ofTable, zstdOFDefaultTable, 31, 8)
+	if err != nil {
+		return err
+	}
+	b = b[n:]
+	z.mlTable, n, err = sequenceTable((modes>>2)&3, b, z.mlTable, zstdMLDefaultTable, 52, 9)
+	if err != nil {
+		return err
+	}
+	b = b[n:]
+
+	br, err := newZstdBackwardBits(b)
+	if err != nil {
+		return fmt.Errorf("sequences: %v", err)
+	}
+	llState := br.bits(z.llTable.accuracyLog)
+	ofState := br.bits(z.ofTable.accuracyLog)
+	mlState := br.bits(z.mlTable.accuracyLog)
+	var litPos int
+	for i := 0; i < numSequences; i++ {
+		llEntry, ofEntry, mlEntry := z.llTable.entries[llState], z.ofTable.entries[ofState], z.mlTable.entries[mlState]
+		if llEntry.symbol > 35 || mlEntry.symbol > 52 || ofEntry.symbol > 31 {
+			return fmt.Errorf("invalid sequence code")
+		}
+		offsetValue := 1<<ofEntry.symbol + int(br.bits(int(ofEntry.symbol)))
+		matchLength := int(zstdMLBase[mlEntry.symbol]) + int(br.bits(int(zstdMLBits[mlEntry.symbol])))
+		literalsLength := int(zstdLLBase[llEntry.symbol]) + int(br.bits(int(zstdLLBits[llEntry.symbol])))
+
+		var offset int
+		if offsetValue > 3 {
+			offset = offsetValue - 3
+			z.reps = [3]int{offset, z.reps[0], z.reps[1]}
+		} else {
+			index := offsetValue - 1
+			if literalsLength == 0 {
+				index++
+			}
+			switch index {
+			case 0:
+				offset = z.reps[0]
+			case 1:
+				offset = z.reps[1]
+				z.reps = [3]int{offset, z.reps[0], z.reps[2]}
+			case 2:
+				offset = z.reps[2]
+				z.reps = [3]int{offset, z.reps[0], z.reps[1]}
+			default:
+				offset = z.reps[0] - 1
+				z.reps = [3]int{offset, z.reps[0], z.reps[1]}
+			}
+		}
+		if i < numSequences-1 {
+			llState = uint64(llEntry.base) + br.bits(int(llEntry.nbBits))
+			mlState = uint64(mlEntry.base) + br.bits(int(mlEntry.nbBits))
+			ofState = uint64(ofEntry.base) + br.bits(int(ofEntry.nbBits))
+		}
+
+		if litPos+literalsLength > len(lits) {
+			return fmt.Errorf("sequence exceeds literals")
+		}
+		z.history = append(z.history, lits[litPos:litPos+literalsLength]...)
+		litPos += literalsLength
+		if offset <= 0 || offset > len(z.history) || offset > z.windowSize {
+			return fmt.Errorf("invalid match offset (%d)", offset)
+		}
+		start := len(z.history) - offset
+		for j := 0; j < matchLength; j++ {
+			z.history = append(z.history, z.history[start+j])
+		}
+	}
+	if br.pos != 0 {
+		return fmt.Errorf("sequences: bitstream size does not match number of sequences")
+	}
+	z.history = append(z.history, lits[litPos:]...)
+	return nil
+}
+
+// -- encoder
+
+// a zstdWriter buffers everything written to it and compresses it as a single zstd frame on Close.
+type zstdWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+func (z *zstdWriter) Write(p []byte) (int, error) {
+	z.buf = append(z.buf, p...)
+	return len(p), nil
+}
+
+func (z *zstdWriter) Close() error {
+	_, err := z.w.Write(zstdCompress(z.buf))
+	z.buf = nil
+	return err
+}
+
+type zstdSequence struct {
+	literalsLength int
+	matchLength    int
+	offset         int
+}
+
+// zstdCompress compresses src as a single zstd frame.
+// Matches are found greedily with a single hash table, literals are not entropy-coded,
+// and sequences are encoded with the predefined tables.
+func zstdCompress(src []byte) []byte {
+	out := make([]byte, 0, len(src)/2+32)
+	out = append(out, 0x28, 0xB5, 0x2F, 0xFD)
+	// frame header: 8-byte content size, no checksum, 1 MiB window
+	out = append(out, 3<<6, byte(bits.Len(zstdEncoderWindow)-1-10)<<3)
+	out = append(out, make([]byte, 8)...)
+	binary.LittleEndian.PutUint64(out[len(out)-8:], uint64(len(src)))
+
+	table := make([]int32, 1<<zstdEncoderHashBits)
+	start := 0
+	for {
+		end := start + zstdMaxBlockSize
+		if end > len(src) {
+			end = len(src)
+		}
+		last := 0
+		if end == len(src) {
+			last = 1
+		}
+		block := zstdCompressBlock(src, start, end, table)
+		blockType := 2
+		if block == nil || len(block) >= end-start {
+			block = src[start:end]
+			blockType = 0
+		}
+		header := last | blockType<<1 | len(block)<<3
+		out = append(out, byte(header), byte(header>>8), byte(header>>16))
+		out = append(out, block...)
+		if last == 1 {
+			return out
+		}
+		start = end
+	}
+}
+
+func zstdHash(b []byte) uint32 {
+	return (binary.LittleEndian.Uint32(b) * 2654435761) >> (32 - zstdEncoderHashBits)
+}
+
+// zstdCompressBlock returns the contents of a compressed block for src[start:end], or nil if there are no matches.
+// Matches may refer to any earlier position in src within the window.
+func zstdCompressBlock(src []byte, start int, end int, table []int32) []byte {
+	var sequences []zstdSequence
+	var lits []byte
+	litStart := start
+	for i := start; i+4 <= end; {
+		h := zstdHash(src[i:])
+		candidate := int(table[h]) - 1
+		table[h] = int32(i + 1)
+		if candidate < 0 || i-candidate > zstdEncoderWindow ||
+			binary.LittleEndian.Uint32(src[candidate:]) != binary.LittleEndian.Uint32(src[i:]) {
+			i++
+			continue
+		}
+		matchLength := 4
+		for i+matchLength < end && src[candidate+matchLength] == src[i+matchLength] {
+			matchLength++
+		}
+		lits = append(lits, src[litStart:i]...)
+		sequences = append(sequences, zstdSequence{literalsLength: i - litStart, matchLength: matchLength, offset: i - candidate})
+		i += matchLength
+		litStart = i
+		if i-2 > candidate && i+2 <= end {
+			table[zstdHash(src[i-2:])] = int32(i - 2 + 1)
+		}
+	}
+	if len(sequences) == 0 {
+		return nil
+	}
+	lits = append(lits, src[litStart:end]...)
+
+	// raw literals
+	var out []byte
+	switch n := len(lits); {
+	case n < 32:
+		out = append(out, byte(n<<3))
+	case n < 4096:
+		out = append(out, byte(1<<2|(n&15)<<4), byte(n>>4))
+	default:
+		out = append(out, byte(3<<2|(n&15)<<4), byte(n>>4), byte(n>>12))
+	}
+	out = append(out, lits...)
+
+	switch n := len(sequences); {
+	case n < 128:
+		out = append(out, byte(n))
+	case n < 0x7F00:
+		out = append(out, byte(n>>8+128), byte(n))
+	default:
+		out = append(out, 255, byte(n-0x7F00), byte((n-0x7F00)>>8))
+	}
+	// predefined tables
+	out = append(out, 0)
+
+	codes := make([][3]uint8, len(sequences))
+	for i, seq := range sequences {
+		codes[i] = [3]uint8{zstdLLCode(seq.literalsLength), zstdMLCode(seq.matchLength),
+			uint8(bits.Len(uint(seq.offset+3)) - 1)}
+	}
+	w := &zstdBitWriter{}
+	addExtraBits := func(i int) {
+		seq, code := sequences[i], codes[i]
+		w.addBits(uint64(seq.literalsLength)-uint64(zstdLLBase[code[0]]), uint(zstdLLBits[code[0]]))
+		w.addBits(uint64(seq.matchLength)-uint64(zstdMLBase[code[1]]), uint(zstdMLBits[code[1]]))
+		w.addBits(uint64(seq.offset+3)-1<<code[2], uint(code[2]))
+	}
+	n := len(sequences) - 1
+	mlState := zstdMLEncoder.init(codes[n][1])
+	ofState := zstdOFEncoder.init(codes[n][2])
+	llState := zstdLLEncoder.init(codes[n][0])
+	addExtraBits(n)
+	for i := n - 1; i >= 0; i-- {
+		ofState = zstdOFEncoder.encode(w, ofState, codes[i][2])
+		mlState = zstdMLEncoder.encode(w, mlState, codes[i][1])
+		llState = zstdLLEncoder.encode(w, llState, codes[i][0])
+		addExtraBits(i)
+	}
+	zstdMLEncoder.flush(w, mlState)
+	zstdOFEncoder.flush(w, ofState)
+	zstdLLEncoder.flush(w, llState)
+	return append(out, w.close()...)
+}
+
+func zstdLLCode(literalsLength int) uint8 {
+	if literalsLength < 16 {
+		return uint8(literalsLength)
+	}
+	code := len(zstdLLBase) - 1
+	for int(zstdLLBase[code]) > literalsLength {
+		code--
+	}
+	return uint8(code)
+}
+
+func zstdMLCode(matchLength int) uint8 {
+	if matchLength-3 < 32 {
+		return uint8(matchLength - 3)
+	}
+	code := len(zstdMLBase) - 1
+	for int(zstdMLBase[code]) > matchLength {
+		code--
+	}
+	return uint8(code)
+}
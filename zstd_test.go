@@ -0,0 +1,104 @@
+package tada
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// zstdFixtureText generates the text that was compressed with the zstd command-line tool (zstd -19) into zstdFixture.
+func zstdFixtureText() []byte {
+	words := []string{"alpha", "beta", "gamma", "delta", "epsilon", "zeta", "eta", "theta", "iota", "kappa"}
+	var b strings.Builder
+	for i := 0; i < 600; i++ {
+		fmt.Fprintf(&b, "%d,%s,%s\n", i*i%997, words[i%10], words[i*7%10])
+	}
+	return []byte(b.String())
+}
+
+var zstdFixture = "" +
+	"KLUv/WTQIg0mAIpnOAwZkCkZDit5Ff+oRwWe/59BkilJmRKhmTFhXNMAsgC0ADe1TAnhKojuhcSYPJPV/NVxbJE9XNmMpTurm2GM" +
+	"fc30mY5IUVFkYikj91dvdc4OhQvWSthyOBtFi27VUHAmm1MdJ8t0Zju+8V0ypy1k5nLHVD4z/KU0Oe2yElSluQopn1RBPxqni2hm" +
+	"I6kQR4McE0KSbSLkqVxPldEZeWbmv9pOsnv9p8qzW9NoamamHGu0vjIjCzGQGqYGBQSEQoHhY1BIOBhKgwUDLQGBoWJQSDBwEGC4" +
+	"AYIEBggoQDgYCgcOFAQMYzAgYSgkHAAYxKAwAMFAYYhC0lWnr49S12VYMgmZPjFBJUEhNxbtRWyKWVB74jBes6ZolKjVVnsmdKxC" +
+	"Ynzj0qpLXN2lL8Uo7lY00cTeNymT0oqz5ELDeozuU4ys+o3sjnUJh+ozRCHUBjc3S+En6CZ1XEjx5FXoHDmTxaTEMnIP0ikeRzn/" +
+	"RRXr6jPj8kj0KuJmqn5Msb3NSdzyIm6NU6dpRfhmGxzf0439yS3xlLlifKry0OJWS+7cz71Z1ftBsxUDsZOo5EKi4Yk0ubaqVNhO" +
+	"SWfX/vpMZMgk48dXQ6vNRFde7xEhLUT0ik8tasotF8s1fiQs8k5iWKLx/Jdx5q1fQVnZj0VTSES/a/p1qG4iEb701byIs+5FFXNE" +
+	"7Hp1NRQsizBGpF4vTG254s7resV4ScKvNG/XoooTJ6VXbRuiqvJGJYoHbU4xl0+m/IPYsOvNyyXZKF7siJ1UsfVHhETyCY7KHnGF" +
+	"V62YHrtam9chFyI/YpgPVW7UTC1Op7CFhPTI+pUnWpuhrR6XMZlE5rNX7bNL2k6FVdnIRZonormQSkix7267odhEcNV+TM8XysPh" +
+	"2EMoXw3daZTqmXOE5aLSGpnTFTWurqWgkRghMmgaZWIRCm/i6vTg41VUrFXUy3WUTB6PyeS6tuOeqFgVq6iiauLBF73q3CkWGS1I" +
+	"lBjSOIglWaXKsaPokrm0ouVGOK+jneGsKpSHYw4+hkKXejbr83YlEYzNhtruDoJbqCIofPQqhfYOUrZBkpwDIhAIBAGCgIXlMuUH" +
+	"8/tuD+HJDUWVJD6F3cNhiU5J9B0RKYbc8sF9VGxR973iB0vVeNi+JpvB2sXsbNwUo+YWrWa558+lJ/PaPona0y+qs9dzRx0iTXds" +
+	"nIeGNpAANOgNlzSRdCWmABd8ZafQFg+kn4b9SCuI9KM8dNuncm2OnazUW5FaIM7DppLvjJ7eP93f4+cdXo1aJ+NOzCR5jWZa3DBP" +
+	"CAWE7Nplx020OQfab1vjo/D2mN3jxyRX0lp0zsfXIGeRvN/CGstLAHcYqkAiDrbH3kziJydQYNy+c6gd/fiak/LYXf5vUuphdUhk" +
+	"aXZ76+I7Osc3odvJBnYZVe2jvzdaPztwZ3XJ3cdPvxm7zysM0R/jnZB3JtwJUAyRctWQi4Z8JuX5l+4PMzXdCHL8aOkWTgU5tyq7" +
+	"hJ4kk5A3khWlQVcIjhnqCMtkqDTKVQ9LU5l+97Ss4c9P1bPQ3mbMEEv8sKMNR8eNJo0OmdEBo+FFu6KlE/0R7UjDOfIgOq/Wbmqi" +
+	"O9PkyBsFR56GTAB9wEEDFs2EY0ytOECbfIUWc/Fi2A=="
+
+func Test_zstdReader(t *testing.T) {
+	fixture, _ := base64.StdEncoding.DecodeString(zstdFixture)
+	tests := []struct {
+		name    string
+		input   []byte
+		want    []byte
+		wantErr bool
+	}{
+		{"pass - compressed by reference encoder", fixture, zstdFixtureText(), false},
+		{"pass - raw block with checksum", []byte{0x28, 0xb5, 0x2f, 0xfd, 0x04, 0x58, 0x61, 0x00, 0x00,
+			'f', 'o', 'o', ',', 'b', 'a', 'r', '\n', '1', ',', '2', '\n', 0x47, 0x6e, 0xe6, 0xc4}, []byte("foo,bar\n1,2\n"), false},
+		{"pass - skippable frame and rle block", []byte{0x50, 0x2a, 0x4d, 0x18, 0x01, 0x00, 0x00, 0x00, 0xff,
+			0x28, 0xb5, 0x2f, 0xfd, 0x20, 0x03, 0x1b, 0x00, 0x00, 'x'}, []byte("xxx"), false},
+		{"pass - concatenated frames", append(append([]byte{}, fixture...), fixture...),
+			append(zstdFixtureText(), zstdFixtureText()...), false},
+		{"fail - bad magic", []byte{0x28, 0xb5, 0x2f, 0xfe, 0x00}, nil, true},
+		{"fail - truncated", fixture[:len(fixture)/2], nil, true},
+		{"fail - dictionary", []byte{0x28, 0xb5, 0x2f, 0xfd, 0x01, 0x50, 0x01}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ioutil.ReadAll(newZstdReader(bytes.NewReader(tt.input)))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("zstdReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !bytes.Equal(got, tt.want) {
+				t.Errorf("zstdReader.Read() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_zstdCompress(t *testing.T) {
+	random := make([]byte, 200000)
+	rand.New(rand.NewSource(1)).Read(random)
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{"empty", []byte{}},
+		{"short", []byte("foo")},
+		{"text", zstdFixtureText()},
+		{"long matches across blocks", bytes.Repeat([]byte("foo,bar,baz\n"), 50000)},
+		{"incompressible", random},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compressed := zstdCompress(tt.input)
+			got, err := ioutil.ReadAll(newZstdReader(bytes.NewReader(compressed)))
+			if err != nil {
+				t.Errorf("zstdCompress() -> zstdReader.Read() error = %v", err)
+				return
+			}
+			if !bytes.Equal(got, tt.input) {
+				t.Errorf("zstdCompress() -> zstdReader.Read() does not match input (%d bytes, want %d)", len(got), len(tt.input))
+			}
+		})
+	}
+	if n := len(zstdCompress(zstdFixtureText())); n >= len(zstdFixtureText())/2 {
+		t.Errorf("zstdCompress() = %d bytes, want less than half of %d", n, len(zstdFixtureText()))
+	}
+}